@@ -1,13 +1,16 @@
 package auther
 
 import (
+    "bytes"
     "context"
+    "crypto/ed25519"
     "crypto/rand"
     "encoding/hex"
     "errors"
     "fmt"
-    "sync"
     "strings"
+    "sync"
+    "sync/atomic"
     "time"
 
     "github.com/golang-jwt/jwt/v5"
@@ -19,6 +22,12 @@ var (
 	ErrRevokedToken     = errors.New("token revoked")
 	ErrInvalidTokenType = errors.New("invalid token type")
 	ErrSecretKeyEmpty   = errors.New("secret key is required")
+	// ErrInvalidSigningKey Ed25519 密钥长度不正确或公私钥不匹配
+	ErrInvalidSigningKey = errors.New("invalid signing key")
+	// ErrSigningKeyMissing 仅配置了验证公钥，无法签发令牌
+	ErrSigningKeyMissing = errors.New("signing key is not configured")
+	// ErrTokenReuseDetected 已轮换（撤销）的刷新令牌被再次使用，整个会话族已被撤销
+	ErrTokenReuseDetected = errors.New("refresh token reuse detected")
 )
 
 // jwtAuther JWT 认证器实现
@@ -29,6 +38,8 @@ type jwtAuther struct {
     stopChan  chan struct{}
     // closeOnce 确保 Close 只执行一次，避免重复关闭通道导致的 panic。
     closeOnce sync.Once
+    // latestExpiry 本实例已签发令牌中最晚的过期时间（Unix 纳秒），按用户、按会话族撤销的记录至少保留到该时间
+    latestExpiry atomic.Int64
 }
 
 // NewAuther 创建新的认证器
@@ -36,13 +47,32 @@ type jwtAuther struct {
 // 1) 统一覆盖传入配置到内部 config（包含 BlackListEnabled），避免默认值与调用方意图不一致；
 // 2) 使用内部 config 决定是否启动清理协程，并初始化 stopChan，便于后续 Close 停止协程。
 func NewAuther(authConfig *AutherConfig) (Auther, error) {
-    if authConfig == nil || strings.TrimSpace(authConfig.SecretKey) == "" {
+    if authConfig == nil {
+        return nil, ErrSecretKeyEmpty
+    }
+    // 配置 Ed25519 密钥时使用 EdDSA，否则使用 HS256 并要求 SecretKey
+    eddsa := len(authConfig.Ed25519PrivateKey) > 0 || len(authConfig.Ed25519PublicKey) > 0
+    if !eddsa && strings.TrimSpace(authConfig.SecretKey) == "" {
         return nil, ErrSecretKeyEmpty
     }
 
     // 从默认配置出发，覆盖调用方提供的配置
     config := DefaultAutherConfig
     config.SecretKey = strings.TrimSpace(authConfig.SecretKey)
+    config.PreviousSecretKey = strings.TrimSpace(authConfig.PreviousSecretKey)
+    config.PreviousSecretKeys = verificationSecrets(config.SecretKey, config.PreviousSecretKey, authConfig.PreviousSecretKeys)
+    if eddsa {
+        pub, priv, err := ed25519Keys(authConfig.Ed25519PublicKey, authConfig.Ed25519PrivateKey)
+        if err != nil {
+            return nil, err
+        }
+        config.Ed25519PublicKey, config.Ed25519PrivateKey = pub, priv
+    }
+    config.ClaimsValidator = authConfig.ClaimsValidator
+    config.Logger = authConfig.Logger
+    if config.Logger == nil {
+        config.Logger = nopLogger{}
+    }
 
     // 设置默认值
     if authConfig.AccessTokenExp > 0 {
@@ -54,15 +84,27 @@ func NewAuther(authConfig *AutherConfig) (Auther, error) {
     if authConfig.Issuer != "" {
         config.Issuer = strings.TrimSpace(authConfig.Issuer)
     }
+    // 复制受众列表，忽略空白项，避免调用方后续修改切片影响内部配置
+    for _, aud := range authConfig.Audience {
+        if aud = strings.TrimSpace(aud); aud != "" {
+            config.Audience = append(config.Audience, aud)
+        }
+    }
     // 修复：覆盖 BlackListEnabled，保持与调用方配置一致
     config.BlackListEnabled = authConfig.BlackListEnabled
     if authConfig.BlackListCleanupInterval > 0 {
         config.BlackListCleanupInterval = authConfig.BlackListCleanupInterval
     }
 
+    if authConfig.BlackListMaxSize > 0 {
+        config.BlackListMaxSize = authConfig.BlackListMaxSize
+    }
+    blackList := NewBlackList()
+    blackList.MaxSize = config.BlackListMaxSize
+
     auther := &jwtAuther{
         config:    config,
-        blackList: NewBlackList(),
+        blackList: blackList,
         stopChan:  make(chan struct{}),
     }
 
@@ -76,14 +118,17 @@ func NewAuther(authConfig *AutherConfig) (Auther, error) {
 
 // GenerateTokenPair 生成访问令牌和刷新令牌对
 func (a *jwtAuther) GenerateTokenPair(ctx context.Context, userID, username, role string, metadata map[string]string) (*TokenPair, error) {
-    // 生成访问令牌（使用 MintAccessToken，仅允许 AccessToken）
-    accessToken, err := a.MintAccessToken(ctx, userID, username, role, a.config.AccessTokenExp, metadata)
+    // 新的会话族：令牌对及其后续轮换共享同一 FamilyID，用于刷新令牌重放检测
+    familyID := generateJTI()
+
+    // 生成访问令牌
+    accessToken, err := a.generateTokenInternal(ctx, userID, username, role, AccessToken, a.config.AccessTokenExp, metadata, familyID)
     if err != nil {
         return nil, fmt.Errorf("failed to generate access token: %w", err)
     }
 
     // 生成刷新令牌（仅内部允许生成）
-    refreshToken, err := a.generateTokenInternal(ctx, userID, username, role, RefreshToken, a.config.RefreshTokenExp, nil)
+    refreshToken, err := a.generateTokenInternal(ctx, userID, username, role, RefreshToken, a.config.RefreshTokenExp, nil, familyID)
     if err != nil {
         return nil, fmt.Errorf("failed to generate refresh token: %w", err)
     }
@@ -94,32 +139,55 @@ func (a *jwtAuther) GenerateTokenPair(ctx context.Context, userID, username, rol
     }, nil
 }
 
+// GenerateAccessOnly 仅生成访问令牌，返回的 TokenPair 中 RefreshToken 为零值。
+func (a *jwtAuther) GenerateAccessOnly(ctx context.Context, userID, username, role string, metadata map[string]string) (*TokenPair, error) {
+    accessToken, err := a.MintAccessToken(ctx, userID, username, role, a.config.AccessTokenExp, metadata)
+    if err != nil {
+        return nil, fmt.Errorf("failed to generate access token: %w", err)
+    }
+
+    return &TokenPair{AccessToken: *accessToken}, nil
+}
+
 // generateTokenInternal 生成指定类型的令牌（内部方法）。
 // 该方法不暴露在接口中，用于在内部生成 RefreshToken，避免业务层误用。
-func (a *jwtAuther) generateTokenInternal(ctx context.Context, userID, username, role string, tokenType TokenType, exp time.Duration, metadata map[string]string) (*TokenInfo, error) {
+// familyID 为空表示令牌不属于任何会话族（如单独签发的访问令牌）。
+func (a *jwtAuther) generateTokenInternal(ctx context.Context, userID, username, role string, tokenType TokenType, exp time.Duration, metadata map[string]string, familyID string) (*TokenInfo, error) {
+    method, key, err := a.signingKey()
+    if err != nil {
+        return nil, err
+    }
+    return a.signToken(method, key, userID, username, role, tokenType, exp, metadata, familyID)
+}
+
+// signToken 使用已解析的签名算法与密钥构造 claims 并签名，批量签发时可复用同一密钥。
+func (a *jwtAuther) signToken(method jwt.SigningMethod, key interface{}, userID, username, role string, tokenType TokenType, exp time.Duration, metadata map[string]string, familyID string) (*TokenInfo, error) {
     now := time.Now()
     claims := &TokenClaims{
-        UserID:   userID,
-        Username: username,
-        Role:     role,
-        Type:     tokenType,
-        Metadata: metadata,
+        UserID:       userID,
+        Username:     username,
+        Role:         role,
+        Type:         tokenType,
+        Metadata:     metadata,
+        FamilyID:     familyID,
+        IssuedAtNano: now.UnixNano(),
         RegisteredClaims: jwt.RegisteredClaims{
             ID:        generateJTI(),
             Issuer:    a.config.Issuer,
             Subject:   userID,
-            Audience:  []string{a.config.Issuer},
+            Audience:  a.audience(),
             ExpiresAt: jwt.NewNumericDate(now.Add(exp)),
             NotBefore: jwt.NewNumericDate(now),
             IssuedAt:  jwt.NewNumericDate(now),
         },
     }
 
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    tokenString, err := token.SignedString([]byte(a.config.SecretKey))
+    token := jwt.NewWithClaims(method, claims)
+    tokenString, err := token.SignedString(key)
     if err != nil {
         return nil, fmt.Errorf("failed to sign token: %w", err)
     }
+    a.noteExpiry(claims.ExpiresAt.Time)
 
     return &TokenInfo{
         Token:     tokenString,
@@ -135,7 +203,26 @@ func (a *jwtAuther) generateTokenInternal(ctx context.Context, userID, username,
 // MintAccessToken 生成访问令牌（仅限 AccessToken）。
 // 注意：此方法不接受 tokenType 参数，始终生成 AccessToken；禁止生成 RefreshToken。
 func (a *jwtAuther) MintAccessToken(ctx context.Context, userID, username, role string, exp time.Duration, metadata map[string]string) (*TokenInfo, error) {
-    return a.generateTokenInternal(ctx, userID, username, role, AccessToken, exp, metadata)
+    return a.generateTokenInternal(ctx, userID, username, role, AccessToken, exp, metadata, "")
+}
+
+// MintAccessTokens 批量生成访问令牌，签名密钥只解析一次，返回的令牌与 reqs 顺序一致。
+// 任一令牌签名失败时立即返回错误（包含失败项的下标），不返回已生成的部分结果。
+func (a *jwtAuther) MintAccessTokens(ctx context.Context, reqs []MintRequest) ([]*TokenInfo, error) {
+    method, key, err := a.signingKey()
+    if err != nil {
+        return nil, err
+    }
+
+    tokens := make([]*TokenInfo, len(reqs))
+    for i, req := range reqs {
+        token, err := a.signToken(method, key, req.UserID, req.Username, req.Role, AccessToken, req.Exp, req.Metadata, "")
+        if err != nil {
+            return nil, fmt.Errorf("failed to mint access token at index %d: %w", i, err)
+        }
+        tokens[i] = token
+    }
+    return tokens, nil
 }
 
 // ValidateToken 验证令牌
@@ -143,7 +230,8 @@ func (a *jwtAuther) MintAccessToken(ctx context.Context, userID, username, role
 // 1) 在启用黑名单时检查令牌是否被撤销；
 // 2) 校验签名与标准声明；
 // 3) 稳定判定过期与 NotBefore；
-// 4) 显式校验 Issuer 与 Audience，避免跨签发者令牌被误接受。
+// 4) 显式校验 Issuer 与 Audience，避免跨签发者令牌被误接受；
+// 5) 配置了 ClaimsValidator 时执行自定义声明校验。
 func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClaims, error) {
     // 检查是否在黑名单中
     if a.config.BlackListEnabled {
@@ -158,13 +246,7 @@ func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClai
 
     // 解析和验证令牌
     claims := &TokenClaims{}
-    parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-        // 验证签名方法
-        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-            return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-        }
-        return []byte(a.config.SecretKey), nil
-    })
+    parsedToken, err := jwt.ParseWithClaims(token, claims, a.keyFunc)
 
     if err != nil {
         // 改进：避免依赖错误字符串，改用不验证签名/时间的解析提取 claims，进行稳定的过期与 NotBefore 判定
@@ -182,17 +264,8 @@ func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClai
         if tmpClaims.Issuer != "" && a.config.Issuer != "" && tmpClaims.Issuer != a.config.Issuer {
             return nil, ErrInvalidToken
         }
-        if len(tmpClaims.Audience) > 0 && a.config.Issuer != "" {
-            audOk := false
-            for _, aud := range tmpClaims.Audience {
-                if aud == a.config.Issuer {
-                    audOk = true
-                    break
-                }
-            }
-            if !audOk {
-                return nil, ErrInvalidToken
-            }
+        if len(tmpClaims.Audience) > 0 && !a.audienceMatches(tmpClaims.Audience) {
+            return nil, ErrInvalidToken
         }
         return nil, fmt.Errorf("failed to parse token: %w", err)
     }
@@ -201,6 +274,17 @@ func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClai
 		return nil, ErrInvalidToken
 	}
 
+    // 按用户撤销与按会话族撤销
+    if a.config.BlackListEnabled {
+        revoked, err := a.revokedByClaims(ctx, claims)
+        if err != nil {
+            return nil, fmt.Errorf("failed to check user revocation status: %w", err)
+        }
+        if revoked {
+            return nil, ErrRevokedToken
+        }
+    }
+
     // 补充 NotBefore 判定：尽管库已校验标准声明，这里显式检查以提升健壮性
     if claims.NotBefore != nil && time.Now().Before(claims.NotBefore.Time) {
         return nil, ErrInvalidToken
@@ -211,21 +295,127 @@ func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClai
         return nil, ErrInvalidToken
     }
 
-    // 显式校验 Audience：必须包含配置中的 Issuer（生成时设置为该值）
-    if a.config.Issuer != "" {
-        audOk := false
-        for _, aud := range claims.Audience {
-            if aud == a.config.Issuer {
-                audOk = true
-                break
+    // 显式校验 Audience：必须包含配置的受众（未配置 Audience 时为 Issuer）
+    if !a.audienceMatches(claims.Audience) {
+        return nil, ErrInvalidToken
+    }
+
+    // 自定义声明校验：标准校验通过后执行，错误同时保留 ErrInvalidToken 与原始错误以便调用方判断
+    if a.config.ClaimsValidator != nil {
+        if err := a.config.ClaimsValidator(claims); err != nil {
+            return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+        }
+    }
+
+	return claims, nil
+}
+
+// audience 返回签发与验证使用的受众：优先使用配置的 Audience，未配置时以 Issuer 作为受众（兼容旧行为）。
+func (a *jwtAuther) audience() []string {
+    if len(a.config.Audience) > 0 {
+        return a.config.Audience
+    }
+    if a.config.Issuer == "" {
+        return nil
+    }
+    return []string{a.config.Issuer}
+}
+
+// audienceMatches 判断令牌受众是否至少包含一个期望受众；未配置任何期望受众时不做限制。
+func (a *jwtAuther) audienceMatches(tokenAud []string) bool {
+    expected := a.audience()
+    if len(expected) == 0 {
+        return true
+    }
+    for _, aud := range tokenAud {
+        for _, want := range expected {
+            if aud == want {
+                return true
             }
         }
-        if !audOk {
-            return nil, ErrInvalidToken
+    }
+    return false
+}
+
+// keyFunc 返回用于验证签名的密钥：EdDSA 模式下仅接受 EdDSA 算法并返回公钥；
+// HS256 模式下先尝试当前密钥，再按顺序尝试旧密钥（PreviousSecretKey 与 PreviousSecretKeys），任一验证通过即接受。
+func (a *jwtAuther) keyFunc(token *jwt.Token) (interface{}, error) {
+    if a.config.Ed25519PublicKey != nil {
+        if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+            return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
         }
+        return a.config.Ed25519PublicKey, nil
     }
 
-	return claims, nil
+    // 验证签名方法
+    if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+        return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+    }
+    if len(a.config.PreviousSecretKeys) == 0 {
+        return []byte(a.config.SecretKey), nil
+    }
+    keys := make([]jwt.VerificationKey, 0, len(a.config.PreviousSecretKeys)+1)
+    keys = append(keys, []byte(a.config.SecretKey))
+    for _, key := range a.config.PreviousSecretKeys {
+        keys = append(keys, []byte(key))
+    }
+    return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+// signingKey 返回签发令牌使用的算法与密钥：EdDSA 模式使用 Ed25519 私钥，否则使用 HS256 与 SecretKey。
+func (a *jwtAuther) signingKey() (jwt.SigningMethod, interface{}, error) {
+    if a.config.Ed25519PublicKey == nil {
+        return jwt.SigningMethodHS256, []byte(a.config.SecretKey), nil
+    }
+    if a.config.Ed25519PrivateKey == nil {
+        return nil, nil, ErrSigningKeyMissing
+    }
+    return jwt.SigningMethodEdDSA, a.config.Ed25519PrivateKey, nil
+}
+
+// ed25519Keys 校验 Ed25519 密钥长度，公钥未设置时由私钥推导，两者均设置时要求匹配。
+func ed25519Keys(pub ed25519.PublicKey, priv ed25519.PrivateKey) (ed25519.PublicKey, ed25519.PrivateKey, error) {
+    if len(priv) > 0 && len(priv) != ed25519.PrivateKeySize {
+        return nil, nil, fmt.Errorf("%w: ed25519 private key must be %d bytes", ErrInvalidSigningKey, ed25519.PrivateKeySize)
+    }
+    if len(pub) > 0 && len(pub) != ed25519.PublicKeySize {
+        return nil, nil, fmt.Errorf("%w: ed25519 public key must be %d bytes", ErrInvalidSigningKey, ed25519.PublicKeySize)
+    }
+    if len(priv) == 0 {
+        return pub, nil, nil
+    }
+    derived := priv.Public().(ed25519.PublicKey)
+    if len(pub) > 0 && !bytes.Equal(pub, derived) {
+        return nil, nil, fmt.Errorf("%w: ed25519 public key does not match private key", ErrInvalidSigningKey)
+    }
+    return derived, priv, nil
+}
+
+// verificationSecrets 合并旧密钥：PreviousSecretKey 在前，随后为 PreviousSecretKeys，
+// 去除首尾空白并忽略空值、与当前密钥相同的值及重复项；返回新切片，调用方后续修改原切片不影响内部配置。
+func verificationSecrets(current, previous string, others []string) []string {
+    var keys []string
+    seen := map[string]bool{current: true}
+    for _, key := range append([]string{previous}, others...) {
+        if key = strings.TrimSpace(key); key == "" || seen[key] {
+            continue
+        }
+        seen[key] = true
+        keys = append(keys, key)
+    }
+    return keys
+}
+
+// ValidateTokenOfType 先执行 ValidateToken 的全部校验，再检查令牌类型是否为 expected。
+func (a *jwtAuther) ValidateTokenOfType(ctx context.Context, token string, expected TokenType) (*TokenClaims, error) {
+    claims, err := a.ValidateToken(ctx, token)
+    if err != nil {
+        return nil, err
+    }
+    if claims.Type != expected {
+        return nil, ErrInvalidTokenType
+    }
+    return claims, nil
 }
 
 // RefreshTokenRotate 刷新令牌旋转
@@ -238,9 +428,14 @@ func (a *jwtAuther) ValidateToken(ctx context.Context, token string) (*TokenClai
 // 3) 基于现有 claims 签发新的访问令牌与刷新令牌，并返回令牌对；
 // 4) 当 BlackListEnabled=false 时无法撤销旧刷新令牌，但仍会生成新的令牌对。
 func (a *jwtAuther) RefreshTokenRotate(ctx context.Context, refreshToken string) (*TokenPair, error) {
-    // 验证刷新令牌
+    // 验证刷新令牌；已撤销的刷新令牌再次出现视为重放，撤销整个会话族
     claims, err := a.ValidateToken(ctx, refreshToken)
     if err != nil {
+        if errors.Is(err, ErrRevokedToken) {
+            if reuseErr := a.handleRefreshReuse(ctx, refreshToken); reuseErr != nil {
+                return nil, reuseErr
+            }
+        }
         return nil, fmt.Errorf("invalid refresh token: %w", err)
     }
     if claims.Type != RefreshToken {
@@ -254,12 +449,18 @@ func (a *jwtAuther) RefreshTokenRotate(ctx context.Context, refreshToken string)
         }
     }
 
-    // 生成新的访问令牌（通过 MintAccessToken）与刷新令牌（内部生成）
-    newAccess, err := a.MintAccessToken(ctx, claims.UserID, claims.Username, claims.Role, a.config.AccessTokenExp, claims.Metadata)
+    // 沿用原会话族；旧版本签发的无 FamilyID 令牌从此开始新的会话族
+    familyID := claims.FamilyID
+    if familyID == "" {
+        familyID = generateJTI()
+    }
+
+    // 生成新的访问令牌与刷新令牌
+    newAccess, err := a.generateTokenInternal(ctx, claims.UserID, claims.Username, claims.Role, AccessToken, a.config.AccessTokenExp, claims.Metadata, familyID)
     if err != nil {
         return nil, fmt.Errorf("failed to generate new access token: %w", err)
     }
-    newRefresh, err := a.generateTokenInternal(ctx, claims.UserID, claims.Username, claims.Role, RefreshToken, a.config.RefreshTokenExp, nil)
+    newRefresh, err := a.generateTokenInternal(ctx, claims.UserID, claims.Username, claims.Role, RefreshToken, a.config.RefreshTokenExp, nil, familyID)
     if err != nil {
         return nil, fmt.Errorf("failed to generate new refresh token: %w", err)
     }
@@ -267,6 +468,48 @@ func (a *jwtAuther) RefreshTokenRotate(ctx context.Context, refreshToken string)
     return &TokenPair{AccessToken: *newAccess, RefreshToken: *newRefresh}, nil
 }
 
+// handleRefreshReuse 处理已撤销刷新令牌的再次使用：签名有效的刷新令牌被重放时撤销其整个会话族
+// （无 FamilyID 的旧令牌则撤销该用户全部令牌），并返回同时匹配 ErrTokenReuseDetected 与 ErrRevokedToken 的错误。
+// 签名无效或非刷新令牌时返回 nil，由调用方按普通撤销错误处理。
+func (a *jwtAuther) handleRefreshReuse(ctx context.Context, refreshToken string) error {
+    claims, err := a.GetTokenInfoVerified(refreshToken)
+    if err != nil || claims.Type != RefreshToken {
+        return nil
+    }
+
+    if claims.FamilyID == "" {
+        err = a.RevokeAllForUser(ctx, claims.UserID)
+    } else {
+        err = a.RevokeFamily(ctx, claims.FamilyID)
+    }
+    if err != nil {
+        return fmt.Errorf("failed to revoke token family: %w", err)
+    }
+
+    return fmt.Errorf("%w: %w", ErrTokenReuseDetected, ErrRevokedToken)
+}
+
+// revokedByClaims 检查令牌是否因按用户撤销（签发时间早于撤销时间点）或所属会话族被撤销而失效。
+// 签发时间优先取纳秒精度的 iat_ns；缺少时取秒级 iat（按该秒起点处理，同一秒内撤销前签发的令牌不会漏判），
+// 两者均缺少时按最早时间处理
+func (a *jwtAuther) revokedByClaims(ctx context.Context, claims *TokenClaims) (bool, error) {
+    var issuedAt time.Time
+    if claims.IssuedAtNano > 0 {
+        issuedAt = time.Unix(0, claims.IssuedAtNano)
+    } else if claims.IssuedAt != nil {
+        issuedAt = claims.IssuedAt.Time
+    }
+    revoked, err := a.blackList.IsRevokedForUser(ctx, claims.UserID, issuedAt)
+    if err != nil || revoked {
+        return revoked, err
+    }
+    if claims.FamilyID == "" {
+        return false, nil
+    }
+
+    return a.blackList.IsFamilyRevoked(ctx, claims.FamilyID)
+}
+
 // RevokeToken 撤销令牌（加入黑名单）
 func (a *jwtAuther) RevokeToken(ctx context.Context, token string) error {
 	if !a.config.BlackListEnabled {
@@ -284,13 +527,105 @@ func (a *jwtAuther) RevokeToken(ctx context.Context, token string) error {
 }
 
 
+// RevokeAllForUser 撤销指定用户此前签发的全部令牌（访问令牌与刷新令牌），用于修改密码或账号被盗等场景。
+// 实现为记录该用户的撤销时间点，ValidateToken 拒绝签发时间早于该时间点的令牌；签发时间按纳秒精度的 iat_ns 声明比较，
+// 撤销后立即签发的新令牌（如修改密码后重新登录）不受影响。未启用黑名单时为空操作。
+func (a *jwtAuther) RevokeAllForUser(ctx context.Context, userID string) error {
+    if !a.config.BlackListEnabled {
+        return nil
+    }
+    if strings.TrimSpace(userID) == "" {
+        return fmt.Errorf("user id is required")
+    }
+
+    // 撤销前签发的令牌全部过期后，该记录由 CleanupExpiredTokens 清理
+    now := time.Now()
+    return a.blackList.RevokeUserBefore(ctx, userID, now, a.revocationExpiry(now))
+}
+
+// RevokeFamily 撤销整个会话族：同一次登录签发的令牌对及其全部轮换结果（含当前有效的令牌）立即失效，
+// 同一用户的其他会话不受影响。未启用黑名单时为空操作。
+func (a *jwtAuther) RevokeFamily(ctx context.Context, familyID string) error {
+    if !a.config.BlackListEnabled {
+        return nil
+    }
+    if strings.TrimSpace(familyID) == "" {
+        return fmt.Errorf("family id is required")
+    }
+
+    return a.blackList.RevokeFamily(ctx, familyID, a.revocationExpiry(time.Now()))
+}
+
+// revocationExpiry 返回按用户、按会话族撤销记录的保留截止时间，此前签发的令牌届时均已过期：
+// 取 now 加上访问令牌与刷新令牌有效期中的较大者，与已签发令牌（含 MintAccessToken 以更长有效期签发的令牌）最晚过期时间中的较晚者。
+// 注意：最晚过期时间仅记录在内存中，进程重启前签发的长有效期令牌不计入。
+func (a *jwtAuther) revocationExpiry(now time.Time) time.Time {
+    exp := a.config.RefreshTokenExp
+    if a.config.AccessTokenExp > exp {
+        exp = a.config.AccessTokenExp
+    }
+    expiry := now.Add(exp)
+    if latest := time.Unix(0, a.latestExpiry.Load()); latest.After(expiry) {
+        return latest
+    }
+    return expiry
+}
+
+// noteExpiry 记录已签发令牌的过期时间，仅在晚于当前记录时更新。
+func (a *jwtAuther) noteExpiry(expiresAt time.Time) {
+    n := expiresAt.UnixNano()
+    for {
+        cur := a.latestExpiry.Load()
+        if n <= cur || a.latestExpiry.CompareAndSwap(cur, n) {
+            return
+        }
+    }
+}
+
 // IsTokenRevoked 检查令牌是否被撤销
 func (a *jwtAuther) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
 	if !a.config.BlackListEnabled {
 		return false, nil
 	}
 
-	return a.blackList.IsRevoked(ctx, token)
+	revoked, err := a.blackList.IsRevoked(ctx, token)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	// 同时检查按用户与按会话族撤销；签名无效的令牌无法确定归属，视为未撤销（ValidateToken 会拒绝它）
+	claims, err := a.GetTokenInfoVerified(token)
+	if err != nil {
+		return false, nil
+	}
+	return a.revokedByClaims(ctx, claims)
+}
+
+// BlacklistCountForUser 统计指定用户已撤销且尚未过期的令牌数量
+func (a *jwtAuther) BlacklistCountForUser(ctx context.Context, userID string) (int, error) {
+	if !a.config.BlackListEnabled {
+		return 0, nil
+	}
+
+	return a.blackList.CountByUser(ctx, userID)
+}
+
+// ExportBlackList 导出黑名单快照，未启用黑名单时返回空结果
+func (a *jwtAuther) ExportBlackList(ctx context.Context) (BlackListSnapshot, error) {
+	if !a.config.BlackListEnabled {
+		return BlackListSnapshot{}, nil
+	}
+
+	return a.blackList.Export()
+}
+
+// ImportBlackList 导入黑名单快照，未启用黑名单时为空操作
+func (a *jwtAuther) ImportBlackList(ctx context.Context, snapshot BlackListSnapshot) error {
+	if !a.config.BlackListEnabled {
+		return nil
+	}
+
+	return a.blackList.Import(snapshot)
 }
 
 // CleanupExpiredTokens 清理过期的黑名单令牌
@@ -303,6 +638,8 @@ func (a *jwtAuther) CleanupExpiredTokens(ctx context.Context) error {
 }
 
 // GetTokenInfo 从令牌中提取信息（不验证签名）
+// 警告：返回的 claims 未经任何校验，任何人都可以伪造内容，只能用于日志、调试等非安全场景；
+// 不得据此做鉴权决策。需要可信 claims 时请使用 GetTokenInfoVerified（仅验证签名）或 GetTokenInfoValidated（完整校验）。
 func (a *jwtAuther) GetTokenInfo(token string) (*TokenClaims, error) {
 	claims := &TokenClaims{}
 	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
@@ -313,6 +650,32 @@ func (a *jwtAuther) GetTokenInfo(token string) (*TokenClaims, error) {
 	return claims, nil
 }
 
+// GetTokenInfoVerified 验证签名（支持 PreviousSecretKey 与 PreviousSecretKeys）后提取令牌信息。
+// 仅保证 claims 由本服务签发且未被篡改，不校验过期时间、NotBefore、Issuer、Audience、黑名单与 ClaimsValidator；
+// 需要完整策略校验时请使用 GetTokenInfoValidated 或 ValidateToken。签名无效时返回的错误匹配 ErrInvalidToken。
+func (a *jwtAuther) GetTokenInfoVerified(token string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	if _, err := parser.ParseWithClaims(token, claims, a.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+// GetTokenInfoValidated 执行与 ValidateToken 相同的完整校验（签名、过期、NotBefore、Issuer、Audience、黑名单与 ClaimsValidator）后返回 claims。
+// 返回的错误总能以 errors.Is 匹配 ErrInvalidToken、ErrExpiredToken 或 ErrRevokedToken 之一，便于调用方分类处理。
+func (a *jwtAuther) GetTokenInfoValidated(ctx context.Context, token string) (*TokenClaims, error) {
+	claims, err := a.ValidateToken(ctx, token)
+	if err == nil {
+		return claims, nil
+	}
+	if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrExpiredToken) || errors.Is(err, ErrRevokedToken) {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+}
+
 // startBlackListCleanup 启动黑名单清理协程（周期清理过期项，支持显式关闭）。
 func (a *jwtAuther) startBlackListCleanup() {
     ticker := time.NewTicker(a.config.BlackListCleanupInterval)
@@ -321,10 +684,7 @@ func (a *jwtAuther) startBlackListCleanup() {
     for {
         select {
         case <-ticker.C:
-            ctx := context.Background()
-            if err := a.CleanupExpiredTokens(ctx); err != nil {
-                // TODO: 可接入日志记录错误，但不中断清理过程
-            }
+            a.runCleanup(a.CleanupExpiredTokens)
         case <-a.stopChan:
             // 收到关闭信号，退出协程
             return
@@ -332,6 +692,18 @@ func (a *jwtAuther) startBlackListCleanup() {
     }
 }
 
+// runCleanup 执行一次清理；错误与 panic 交由 Logger 记录，不中断清理协程。
+func (a *jwtAuther) runCleanup(cleanup func(ctx context.Context) error) {
+    defer func() {
+        if r := recover(); r != nil {
+            a.config.Logger.Errorf("auther: blacklist cleanup panicked: %v", r)
+        }
+    }()
+    if err := cleanup(context.Background()); err != nil {
+        a.config.Logger.Errorf("auther: blacklist cleanup failed: %v", err)
+    }
+}
+
 // Close 关闭后台黑名单清理协程（使用 sync.Once 保证幂等）。
 func (a *jwtAuther) Close() error {
     if a.stopChan == nil {
@@ -2,9 +2,15 @@ package auther
 
 import (
     "context"
+    "crypto/ed25519"
+    "crypto/rand"
+    "encoding/base64"
+    "encoding/json"
     "errors"
     "fmt"
     "runtime"
+    "strings"
+    "sync"
     "testing"
     "time"
     
@@ -349,4 +355,1192 @@ func TestValidateTokenIssuerMismatch_ParseErrorPath(t *testing.T) {
         // 打印一下具体错误类型，便于定位（不做强断言）
         _ = fmt.Sprintf("parse error: %v", err)
     }
-}
\ No newline at end of file
+}
+// TestValidateTokenPreviousSecret 验证密钥轮换窗口内，使用上一个密钥签发的令牌仍可通过验证，
+// 而新令牌始终使用当前密钥签发。
+func TestValidateTokenPreviousSecret(t *testing.T) {
+    oldCfg := AutherConfig{
+        SecretKey:                "old-secret",
+        AccessTokenExp:           1 * time.Hour,
+        RefreshTokenExp:          2 * time.Hour,
+        Issuer:                   "test-issuer",
+        BlackListEnabled:         false,
+        BlackListCleanupInterval: 1 * time.Hour,
+    }
+    rotatedCfg := oldCfg
+    rotatedCfg.SecretKey = "new-secret"
+    rotatedCfg.PreviousSecretKey = "old-secret"
+
+    oldAuther := newTestAuther(t, oldCfg)
+    rotated := newTestAuther(t, rotatedCfg)
+
+    // 旧密钥签发的令牌在轮换窗口内仍有效
+    oldToken, err := oldAuther.MintAccessToken(context.Background(), "u20", "user20", "role20", oldCfg.AccessTokenExp, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := rotated.ValidateToken(context.Background(), oldToken.Token); err != nil {
+        t.Fatalf("token signed with previous secret should be valid, got: %v", err)
+    }
+
+    // 新令牌使用当前密钥签发：仅配置新密钥的认证器可直接验证
+    newToken, err := rotated.MintAccessToken(context.Background(), "u20", "user20", "role20", rotatedCfg.AccessTokenExp, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    newOnly := newTestAuther(t, AutherConfig{SecretKey: "new-secret", Issuer: "test-issuer"})
+    if _, err := newOnly.ValidateToken(context.Background(), newToken.Token); err != nil {
+        t.Fatalf("new token should be signed with current secret, got: %v", err)
+    }
+    if _, err := oldAuther.ValidateToken(context.Background(), newToken.Token); err == nil {
+        t.Fatalf("new token should not validate with old secret only")
+    }
+
+    // 未知密钥签发的令牌仍应被拒绝
+    other := newTestAuther(t, AutherConfig{SecretKey: "other-secret", Issuer: "test-issuer"})
+    otherToken, err := other.MintAccessToken(context.Background(), "u21", "user21", "role21", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := rotated.ValidateToken(context.Background(), otherToken.Token); err == nil {
+        t.Fatalf("token signed with unknown secret should be rejected")
+    }
+}
+
+// TestValidateTokenPreviousSecretKeys 验证多个旧密钥按顺序尝试：经过两次轮换后，
+// 两代旧密钥签发的令牌仍可验证，新令牌始终使用当前密钥签发，移除旧密钥后其令牌被拒绝。
+func TestValidateTokenPreviousSecretKeys(t *testing.T) {
+    ctx := context.Background()
+    gen1 := newTestAuther(t, AutherConfig{SecretKey: "secret-v1", Issuer: "test-issuer"})
+    gen2 := newTestAuther(t, AutherConfig{SecretKey: "secret-v2", PreviousSecretKey: "secret-v1", Issuer: "test-issuer"})
+
+    tok1, err := gen1.MintAccessToken(ctx, "u22", "user22", "role22", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    tok2, err := gen2.MintAccessToken(ctx, "u22", "user22", "role22", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+
+    // 第二次轮换：当前密钥 v3，旧密钥列表含 v2 与 v1（空白与重复项被忽略）
+    keys := []string{"secret-v2", " ", "secret-v1", "secret-v2"}
+    gen3 := newTestAuther(t, AutherConfig{SecretKey: "secret-v3", PreviousSecretKeys: keys, Issuer: "test-issuer"})
+    keys[0] = "mutated"
+    for name, tok := range map[string]string{"v1": tok1.Token, "v2": tok2.Token} {
+        if _, err := gen3.ValidateToken(ctx, tok); err != nil {
+            t.Fatalf("token signed with %s should still validate, got: %v", name, err)
+        }
+        if _, err := gen3.GetTokenInfoVerified(tok); err != nil {
+            t.Fatalf("GetTokenInfoVerified should accept %s token, got: %v", name, err)
+        }
+    }
+
+    // 新令牌使用当前密钥签发
+    tok3, err := gen3.MintAccessToken(ctx, "u22", "user22", "role22", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    v3Only := newTestAuther(t, AutherConfig{SecretKey: "secret-v3", Issuer: "test-issuer"})
+    if _, err := v3Only.ValidateToken(ctx, tok3.Token); err != nil {
+        t.Fatalf("new token should be signed with current secret, got: %v", err)
+    }
+    if _, err := gen2.ValidateToken(ctx, tok3.Token); err == nil {
+        t.Fatal("new token should not validate with previous secrets only")
+    }
+
+    // 轮换窗口结束后移除 v1，其签发的令牌被拒绝
+    gen4 := newTestAuther(t, AutherConfig{SecretKey: "secret-v3", PreviousSecretKeys: []string{"secret-v2"}, Issuer: "test-issuer"})
+    if _, err := gen4.ValidateToken(ctx, tok1.Token); err == nil {
+        t.Fatalf("token signed with removed secret should be rejected, got: %v", err)
+    }
+    if _, err := gen4.ValidateToken(ctx, tok2.Token); err != nil {
+        t.Fatalf("token signed with retained secret should validate, got: %v", err)
+    }
+}
+
+// TestValidateTokenOfType 验证按类型校验：类型匹配时返回 claims，刷新令牌不能当作访问令牌使用，
+// 且其他校验错误（如已撤销）原样返回。
+func TestValidateTokenOfType(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    pair, err := a.GenerateTokenPair(ctx, "u23", "user23", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    claims, err := a.ValidateTokenOfType(ctx, pair.AccessToken.Token, AccessToken)
+    if err != nil || claims.UserID != "u23" {
+        t.Fatalf("access token should validate as AccessToken, got claims=%v err=%v", claims, err)
+    }
+    if _, err := a.ValidateTokenOfType(ctx, pair.RefreshToken.Token, RefreshToken); err != nil {
+        t.Fatalf("refresh token should validate as RefreshToken, got: %v", err)
+    }
+    if _, err := a.ValidateTokenOfType(ctx, pair.RefreshToken.Token, AccessToken); !errorsIs(err, ErrInvalidTokenType) {
+        t.Fatalf("expected ErrInvalidTokenType for refresh token, got: %v", err)
+    }
+
+    if err := a.RevokeToken(ctx, pair.AccessToken.Token); err != nil {
+        t.Fatalf("RevokeToken failed: %v", err)
+    }
+    if _, err := a.ValidateTokenOfType(ctx, pair.AccessToken.Token, AccessToken); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrRevokedToken, got: %v", err)
+    }
+}
+
+// TestExplicitAudience 验证配置 Audience 后令牌 aud 使用该列表而非 Issuer，且验证时按受众匹配。
+func TestExplicitAudience(t *testing.T) {
+    cfg := AutherConfig{
+        SecretKey:        "secret",
+        Issuer:           "test-issuer",
+        Audience:         []string{"api://orders", " "},
+        BlackListEnabled: false,
+    }
+    a := newTestAuther(t, cfg)
+
+    tok, err := a.MintAccessToken(context.Background(), "u30", "user30", "role30", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    info, err := a.GetTokenInfo(tok.Token)
+    if err != nil {
+        t.Fatalf("GetTokenInfo failed: %v", err)
+    }
+    if len(info.Audience) != 1 || info.Audience[0] != "api://orders" {
+        t.Fatalf("expected audience [api://orders], got: %v", info.Audience)
+    }
+    if info.Issuer != "test-issuer" {
+        t.Fatalf("issuer should be unchanged, got: %s", info.Issuer)
+    }
+    if _, err := a.ValidateToken(context.Background(), tok.Token); err != nil {
+        t.Fatalf("token with configured audience should be valid, got: %v", err)
+    }
+
+    // 同密钥同签发者但受众不同的验证方应拒绝该令牌
+    other := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "test-issuer", Audience: []string{"api://billing"}})
+    if _, err := other.ValidateToken(context.Background(), tok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for audience mismatch, got: %v", err)
+    }
+
+    // 未配置 Audience 的验证方沿用 Issuer 作为受众，不接受显式受众的令牌
+    legacy := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "test-issuer"})
+    if _, err := legacy.ValidateToken(context.Background(), tok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for issuer-as-audience verifier, got: %v", err)
+    }
+}
+
+// TestBlacklistCountForUser 为两个用户撤销多个令牌后验证按用户统计的数量，以及黑名单关闭时返回 0。
+func TestBlacklistCountForUser(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    revoke := func(userID string, n int) {
+        for i := 0; i < n; i++ {
+            tok, err := a.MintAccessToken(ctx, userID, "name", "role", time.Hour, nil)
+            if err != nil {
+                t.Fatalf("MintAccessToken failed: %v", err)
+            }
+            if err := a.RevokeToken(ctx, tok.Token); err != nil {
+                t.Fatalf("RevokeToken failed: %v", err)
+            }
+        }
+    }
+    revoke("alice", 3)
+    revoke("bob", 1)
+
+    for user, want := range map[string]int{"alice": 3, "bob": 1, "carol": 0} {
+        got, err := a.BlacklistCountForUser(ctx, user)
+        if err != nil {
+            t.Fatalf("BlacklistCountForUser failed: %v", err)
+        }
+        if got != want {
+            t.Errorf("user %s: expected %d revoked tokens, got %d", user, want, got)
+        }
+    }
+
+    disabled := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: false})
+    if got, err := disabled.BlacklistCountForUser(ctx, "alice"); err != nil || got != 0 {
+        t.Fatalf("expected 0 with blacklist disabled, got %d err=%v", got, err)
+    }
+}
+
+// TestClaimsValidator 验证自定义声明校验拒绝指定角色的令牌，且错误同时匹配 ErrInvalidToken 与校验器返回的错误。
+func TestClaimsValidator(t *testing.T) {
+    errGuest := errors.New("guest role not allowed")
+    a := newTestAuther(t, AutherConfig{
+        SecretKey: "secret",
+        ClaimsValidator: func(c *TokenClaims) error {
+            if c.Role == "guest" {
+                return errGuest
+            }
+            return nil
+        },
+    })
+    ctx := context.Background()
+
+    guest, err := a.MintAccessToken(ctx, "u40", "user40", "guest", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    _, err = a.ValidateToken(ctx, guest.Token)
+    if !errorsIs(err, ErrInvalidToken) || !errorsIs(err, errGuest) {
+        t.Fatalf("expected ErrInvalidToken wrapping validator error, got: %v", err)
+    }
+
+    admin, err := a.MintAccessToken(ctx, "u41", "user41", "admin", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, admin.Token); err != nil {
+        t.Fatalf("token passing validator should be valid, got: %v", err)
+    }
+}
+
+// TestGenerateAccessOnly 验证仅签发访问令牌：RefreshToken 为零值且 JSON 中省略，访问令牌仍可正常验证。
+func TestGenerateAccessOnly(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", AccessTokenExp: time.Hour})
+    ctx := context.Background()
+
+    pair, err := a.GenerateAccessOnly(ctx, "svc-1", "billing", "service", map[string]string{"scope": "internal"})
+    if err != nil {
+        t.Fatalf("GenerateAccessOnly failed: %v", err)
+    }
+    if pair.RefreshToken.Token != "" || pair.RefreshToken.Type != "" || !pair.RefreshToken.ExpiresAt.IsZero() {
+        t.Fatalf("expected zero-valued refresh token, got: %+v", pair.RefreshToken)
+    }
+    if pair.AccessToken.Type != AccessToken {
+        t.Fatalf("expected access token type, got %q", pair.AccessToken.Type)
+    }
+
+    claims, err := a.ValidateToken(ctx, pair.AccessToken.Token)
+    if err != nil {
+        t.Fatalf("access token should be valid, got: %v", err)
+    }
+    if claims.UserID != "svc-1" || claims.Type != AccessToken || claims.Metadata["scope"] != "internal" {
+        t.Fatalf("unexpected claims: %+v", claims)
+    }
+
+    data, err := json.Marshal(pair)
+    if err != nil {
+        t.Fatalf("marshal failed: %v", err)
+    }
+    if strings.Contains(string(data), "refresh_token") {
+        t.Fatalf("refresh_token should be omitted from JSON, got: %s", data)
+    }
+
+    if _, err := a.RefreshTokenRotate(ctx, pair.RefreshToken.Token); err == nil {
+        t.Fatal("empty refresh token must not be accepted for rotation")
+    }
+}
+
+// TestGetTokenInfoVerified 验证篡改过的令牌无法通过签名校验，但仍可被 GetTokenInfo 解析；
+// 且 GetTokenInfoVerified 不校验过期时间与 Issuer。
+func TestGetTokenInfoVerified(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "svc-a"})
+    ctx := context.Background()
+
+    tok, err := a.MintAccessToken(ctx, "u50", "user50", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    claims, err := a.GetTokenInfoVerified(tok.Token)
+    if err != nil || claims.UserID != "u50" {
+        t.Fatalf("expected verified claims, got %+v err=%v", claims, err)
+    }
+
+    // 篡改 payload：将角色提升为 admin 并保留原签名
+    tampered := tamperRole(t, tok.Token)
+
+    if _, err := a.GetTokenInfoVerified(tampered); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for tampered token, got: %v", err)
+    }
+    info, err := a.GetTokenInfo(tampered)
+    if err != nil || info.Role != "admin" {
+        t.Fatalf("GetTokenInfo should still parse tampered token, got %+v err=%v", info, err)
+    }
+
+    // 过期令牌与其他签发者的令牌：签名有效即可通过
+    expired, err := a.MintAccessToken(ctx, "u51", "user51", "user", -time.Minute, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.GetTokenInfoVerified(expired.Token); err != nil {
+        t.Fatalf("expired token with valid signature should verify, got: %v", err)
+    }
+    other := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "svc-b"})
+    otherTok, err := other.MintAccessToken(ctx, "u52", "user52", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.GetTokenInfoVerified(otherTok.Token); err != nil {
+        t.Fatalf("issuer is not checked by GetTokenInfoVerified, got: %v", err)
+    }
+
+    wrongKey := newTestAuther(t, AutherConfig{SecretKey: "another-secret", Issuer: "svc-a"})
+    if _, err := wrongKey.GetTokenInfoVerified(tok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for wrong key, got: %v", err)
+    }
+}
+
+// TestGetTokenInfoValidated 验证 GetTokenInfoValidated 执行完整校验：篡改、过期、其他签发者、错误密钥与已撤销的令牌均返回错误。
+func TestGetTokenInfoValidated(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "svc-a", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    tok, err := a.MintAccessToken(ctx, "u53", "user53", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    claims, err := a.GetTokenInfoValidated(ctx, tok.Token)
+    if err != nil || claims.UserID != "u53" {
+        t.Fatalf("expected validated claims, got %+v err=%v", claims, err)
+    }
+
+    if _, err := a.GetTokenInfoValidated(ctx, tamperRole(t, tok.Token)); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for tampered token, got: %v", err)
+    }
+
+    // 过期令牌
+    expired, err := a.MintAccessToken(ctx, "u54", "user54", "user", -time.Minute, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.GetTokenInfoValidated(ctx, expired.Token); !errorsIs(err, ErrExpiredToken) {
+        t.Fatalf("expected ErrExpiredToken, got: %v", err)
+    }
+
+    // 其他签发者与错误密钥
+    other := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "svc-b"})
+    otherTok, err := other.MintAccessToken(ctx, "u55", "user55", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.GetTokenInfoValidated(ctx, otherTok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for issuer mismatch, got: %v", err)
+    }
+    wrongKey := newTestAuther(t, AutherConfig{SecretKey: "another-secret", Issuer: "svc-a"})
+    if _, err := wrongKey.GetTokenInfoValidated(ctx, tok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("expected ErrInvalidToken for wrong key, got: %v", err)
+    }
+
+    // 已撤销令牌
+    if err := a.RevokeToken(ctx, tok.Token); err != nil {
+        t.Fatalf("RevokeToken failed: %v", err)
+    }
+    if _, err := a.GetTokenInfoValidated(ctx, tok.Token); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrRevokedToken, got: %v", err)
+    }
+}
+
+// tamperRole 将令牌 payload 中的角色改为 admin 并保留原签名
+func tamperRole(t *testing.T, token string) string {
+    t.Helper()
+    parts := strings.Split(token, ".")
+    payload, err := jwt.NewParser().DecodeSegment(parts[1])
+    if err != nil {
+        t.Fatalf("decode payload failed: %v", err)
+    }
+    forged := strings.Replace(string(payload), `"role":"user"`, `"role":"admin"`, 1)
+    parts[1] = base64.RawURLEncoding.EncodeToString([]byte(forged))
+    return strings.Join(parts, ".")
+}
+
+// TestRevokeAllForUser 验证按用户撤销使该用户此前签发的访问令牌与刷新令牌全部失效，
+// 其他用户与从未撤销过的用户不受影响，撤销后新签发的令牌可正常使用。
+func TestRevokeAllForUser(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    // 从未出现过的用户：撤销前后均可正常调用
+    if err := a.RevokeAllForUser(ctx, "ghost"); err != nil {
+        t.Fatalf("RevokeAllForUser for unseen user failed: %v", err)
+    }
+
+    victim, err := a.GenerateTokenPair(ctx, "u60", "user60", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    bystander, err := a.GenerateTokenPair(ctx, "u61", "user61", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+
+    if err := a.RevokeAllForUser(ctx, "u60"); err != nil {
+        t.Fatalf("RevokeAllForUser failed: %v", err)
+    }
+
+    if _, err := a.ValidateToken(ctx, victim.AccessToken.Token); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrRevokedToken for access token, got: %v", err)
+    }
+    if _, err := a.RefreshTokenRotate(ctx, victim.RefreshToken.Token); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrRevokedToken for refresh token, got: %v", err)
+    }
+    if revoked, err := a.IsTokenRevoked(ctx, victim.AccessToken.Token); err != nil || !revoked {
+        t.Fatalf("IsTokenRevoked should report user-wide revocation, got %v err=%v", revoked, err)
+    }
+    if _, err := a.ValidateToken(ctx, bystander.AccessToken.Token); err != nil {
+        t.Fatalf("other users must not be affected, got: %v", err)
+    }
+
+    // 撤销后立即签发的新令牌有效（与撤销处于同一秒内也不受影响）
+    fresh, err := a.GenerateTokenPair(ctx, "u60", "user60", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, fresh.AccessToken.Token); err != nil {
+        t.Fatalf("token issued after revocation should be valid, got: %v", err)
+    }
+
+    // 缺少 iat_ns 的旧令牌按秒级 iat 判定：与撤销同一秒内签发的令牌同样失效
+    now := time.Now()
+    legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, &TokenClaims{
+        UserID: "u60",
+        Type:   AccessToken,
+        RegisteredClaims: jwt.RegisteredClaims{
+            Issuer:    "conan",
+            Audience:  []string{"conan"},
+            ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+            IssuedAt:  jwt.NewNumericDate(now),
+        },
+    })
+    legacyToken, err := legacy.SignedString([]byte("secret"))
+    if err != nil {
+        t.Fatalf("failed to sign legacy token: %v", err)
+    }
+    if err := a.RevokeAllForUser(ctx, "u60"); err != nil {
+        t.Fatalf("RevokeAllForUser failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, legacyToken); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrRevokedToken for legacy token without iat_ns, got: %v", err)
+    }
+
+    if err := a.RevokeAllForUser(ctx, " "); err == nil {
+        t.Fatal("expected error for empty user id")
+    }
+}
+
+// TestUserCutoffCleanup 验证按用户撤销的记录在撤销前签发的令牌全部过期后由 Cleanup 清理，未过期的记录保留，
+// 重复撤销时撤销时间点与失效时间均取较晚者。
+func TestUserCutoffCleanup(t *testing.T) {
+    bl := NewBlackList()
+    ctx := context.Background()
+    now := time.Now()
+
+    if err := bl.RevokeUserBefore(ctx, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour)); err != nil {
+        t.Fatalf("RevokeUserBefore failed: %v", err)
+    }
+    if err := bl.RevokeUserBefore(ctx, "active", now.Add(-2*time.Hour), now.Add(-time.Hour)); err != nil {
+        t.Fatalf("RevokeUserBefore failed: %v", err)
+    }
+    // 较早的时间点不会覆盖已有记录，较晚的失效时间延长记录保留期
+    if err := bl.RevokeUserBefore(ctx, "active", now.Add(-3*time.Hour), now.Add(time.Hour)); err != nil {
+        t.Fatalf("RevokeUserBefore failed: %v", err)
+    }
+
+    if err := bl.Cleanup(ctx); err != nil {
+        t.Fatalf("Cleanup failed: %v", err)
+    }
+    if _, ok := bl.userCutoffs["expired"]; ok {
+        t.Fatal("expired user cutoff should be removed by Cleanup")
+    }
+    entry, ok := bl.userCutoffs["active"]
+    if !ok {
+        t.Fatal("unexpired user cutoff should be kept")
+    }
+    if !entry.cutoff.Equal(now.Add(-2*time.Hour)) || !entry.expiresAt.Equal(now.Add(time.Hour)) {
+        t.Fatalf("expected later cutoff and expiry to be kept, got %+v", entry)
+    }
+    if revoked, err := bl.IsRevokedForUser(ctx, "active", now.Add(-150*time.Minute)); err != nil || !revoked {
+        t.Fatalf("token issued before cutoff should be revoked, got %v err=%v", revoked, err)
+    }
+
+    // 通过认证器撤销时，记录保留至当前时间加上访问令牌与刷新令牌有效期的较大者
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true, AccessTokenExp: time.Hour, RefreshTokenExp: 3 * time.Hour}).(*jwtAuther)
+    defer a.Close()
+    if err := a.RevokeAllForUser(ctx, "u1"); err != nil {
+        t.Fatalf("RevokeAllForUser failed: %v", err)
+    }
+    got := a.blackList.userCutoffs["u1"]
+    if d := got.expiresAt.Sub(got.cutoff); d != 3*time.Hour {
+        t.Fatalf("expected cutoff to be retained for 3h, got %v", d)
+    }
+}
+
+// TestRevokeAllForUserLongLivedToken 验证以长于 AccessTokenExp、RefreshTokenExp 的有效期签发的令牌，
+// 在按用户撤销并经过这两个有效期后执行清理，仍被拒绝。
+func TestRevokeAllForUserLongLivedToken(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true, AccessTokenExp: 10 * time.Millisecond, RefreshTokenExp: 20 * time.Millisecond})
+    defer a.Close()
+    ctx := context.Background()
+
+    long, err := a.MintAccessToken(ctx, "u60", "user60", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if err := a.RevokeAllForUser(ctx, "u60"); err != nil {
+        t.Fatalf("RevokeAllForUser failed: %v", err)
+    }
+
+    // 超过两类令牌的默认有效期后清理，撤销记录应仍然保留
+    time.Sleep(30 * time.Millisecond)
+    if err := a.CleanupExpiredTokens(ctx); err != nil {
+        t.Fatalf("CleanupExpiredTokens failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, long.Token); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected long-lived token to stay revoked after cleanup, got: %v", err)
+    }
+}
+
+// TestRefreshTokenReuseDetection 验证重放已轮换的刷新令牌会返回 ErrTokenReuseDetected，
+// 并使同一会话族内轮换得到的令牌全部失效，而同一用户的其他会话不受影响。
+func TestRefreshTokenReuseDetection(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    pair, err := a.GenerateTokenPair(ctx, "u63", "user63", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    other, err := a.GenerateTokenPair(ctx, "u63", "user63", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+
+    first, err := a.RefreshTokenRotate(ctx, pair.RefreshToken.Token)
+    if err != nil {
+        t.Fatalf("RefreshTokenRotate failed: %v", err)
+    }
+    second, err := a.RefreshTokenRotate(ctx, first.RefreshToken.Token)
+    if err != nil {
+        t.Fatalf("RefreshTokenRotate failed: %v", err)
+    }
+
+    // 会话族在轮换间保持不变，不同登录的会话族不同
+    origClaims, _ := a.GetTokenInfoVerified(pair.AccessToken.Token)
+    rotatedClaims, _ := a.GetTokenInfoVerified(second.RefreshToken.Token)
+    otherClaims, _ := a.GetTokenInfoVerified(other.RefreshToken.Token)
+    if origClaims.FamilyID == "" || rotatedClaims.FamilyID != origClaims.FamilyID {
+        t.Fatalf("family ID should be carried across rotations, got %q and %q", origClaims.FamilyID, rotatedClaims.FamilyID)
+    }
+    if otherClaims.FamilyID == origClaims.FamilyID {
+        t.Fatal("separate logins must not share a family ID")
+    }
+
+    // 重放最初的刷新令牌
+    _, err = a.RefreshTokenRotate(ctx, pair.RefreshToken.Token)
+    if !errorsIs(err, ErrTokenReuseDetected) || !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("expected ErrTokenReuseDetected wrapping ErrRevokedToken, got: %v", err)
+    }
+
+    // 整个会话族失效，包括尚未撤销的最新令牌对
+    for name, tok := range map[string]string{
+        "original access": pair.AccessToken.Token,
+        "rotated access":  first.AccessToken.Token,
+        "latest access":   second.AccessToken.Token,
+        "latest refresh":  second.RefreshToken.Token,
+    } {
+        if _, err := a.ValidateToken(ctx, tok); !errorsIs(err, ErrRevokedToken) {
+            t.Fatalf("%s token should be revoked with its family, got: %v", name, err)
+        }
+        if revoked, err := a.IsTokenRevoked(ctx, tok); err != nil || !revoked {
+            t.Fatalf("IsTokenRevoked should report family revocation for %s token, got %v err=%v", name, revoked, err)
+        }
+    }
+    if _, err := a.RefreshTokenRotate(ctx, second.RefreshToken.Token); !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("latest refresh token must not rotate after reuse, got: %v", err)
+    }
+
+    // 同一用户的其他会话不受影响
+    if _, err := a.ValidateToken(ctx, other.AccessToken.Token); err != nil {
+        t.Fatalf("other session should remain valid, got: %v", err)
+    }
+    if _, err := a.RefreshTokenRotate(ctx, other.RefreshToken.Token); err != nil {
+        t.Fatalf("other session should still rotate, got: %v", err)
+    }
+
+    // 单独签发的访问令牌不属于任何会话族，被撤销后不触发重放检测
+    access, err := a.MintAccessToken(ctx, "u63", "user63", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if err := a.RevokeToken(ctx, access.Token); err != nil {
+        t.Fatalf("RevokeToken failed: %v", err)
+    }
+    if _, err := a.RefreshTokenRotate(ctx, access.Token); errorsIs(err, ErrTokenReuseDetected) || !errorsIs(err, ErrRevokedToken) {
+        t.Fatalf("revoked access token should fail without reuse detection, got: %v", err)
+    }
+}
+
+// TestRevokeAllForUserBlacklistDisabled 验证未启用黑名单时按用户撤销为空操作。
+func TestRevokeAllForUserBlacklistDisabled(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: false})
+    ctx := context.Background()
+
+    tok, err := a.MintAccessToken(ctx, "u62", "user62", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if err := a.RevokeAllForUser(ctx, "u62"); err != nil {
+        t.Fatalf("RevokeAllForUser should be a no-op, got: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, tok.Token); err != nil {
+        t.Fatalf("token should remain valid when blacklist is disabled, got: %v", err)
+    }
+}
+
+// TestAudienceIntersection 验证受众按交集匹配：令牌受众与验证方配置的受众至少有一项相同即可；
+// 未配置 Audience 时签发与验证均以 Issuer 作为受众。
+func TestAudienceIntersection(t *testing.T) {
+    ctx := context.Background()
+    issuer := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "idp", Audience: []string{"api://orders", "api://billing"}})
+    tok, err := issuer.MintAccessToken(ctx, "u70", "user70", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+
+    billing := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "idp", Audience: []string{"api://billing", "api://reports"}})
+    if _, err := billing.ValidateToken(ctx, tok.Token); err != nil {
+        t.Fatalf("overlapping audience should be accepted, got: %v", err)
+    }
+    reports := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "idp", Audience: []string{"api://reports"}})
+    if _, err := reports.ValidateToken(ctx, tok.Token); !errorsIs(err, ErrInvalidToken) {
+        t.Fatalf("disjoint audience should be rejected, got: %v", err)
+    }
+
+    // 兼容行为：未配置 Audience 时令牌受众为 Issuer
+    legacy := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "idp"})
+    legacyTok, err := legacy.MintAccessToken(ctx, "u71", "user71", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    claims, err := legacy.ValidateToken(ctx, legacyTok.Token)
+    if err != nil {
+        t.Fatalf("issuer-as-audience token should be valid, got: %v", err)
+    }
+    if len(claims.Audience) != 1 || claims.Audience[0] != "idp" {
+        t.Fatalf("expected audience [idp], got: %v", claims.Audience)
+    }
+}
+
+// TestClaimsValidatorMetadata 验证校验器可拒绝缺少必需元数据（租户 ID）的令牌，
+// 且只在成功路径上调用：过期令牌直接返回 ErrExpiredToken，不会触发校验器。
+func TestClaimsValidatorMetadata(t *testing.T) {
+    errNoTenant := errors.New("tenant_id is required")
+    calls := 0
+    a := newTestAuther(t, AutherConfig{
+        SecretKey: "secret",
+        ClaimsValidator: func(c *TokenClaims) error {
+            calls++
+            if c.Metadata["tenant_id"] == "" {
+                return errNoTenant
+            }
+            return nil
+        },
+    })
+    ctx := context.Background()
+
+    missing, err := a.MintAccessToken(ctx, "u80", "user80", "user", time.Hour, map[string]string{"plan": "pro"})
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, missing.Token); !errorsIs(err, ErrInvalidToken) || !errorsIs(err, errNoTenant) {
+        t.Fatalf("expected rejection for missing tenant_id, got: %v", err)
+    }
+
+    ok, err := a.MintAccessToken(ctx, "u81", "user81", "user", time.Hour, map[string]string{"tenant_id": "t-1"})
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if claims, err := a.ValidateToken(ctx, ok.Token); err != nil || claims.Metadata["tenant_id"] != "t-1" {
+        t.Fatalf("token with tenant_id should be valid, got %+v err=%v", claims, err)
+    }
+
+    before := calls
+    expired, err := a.MintAccessToken(ctx, "u82", "user82", "user", -time.Minute, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, expired.Token); !errorsIs(err, ErrExpiredToken) {
+        t.Fatalf("expected ErrExpiredToken, got: %v", err)
+    }
+    if calls != before {
+        t.Fatal("ClaimsValidator must not run when standard validation fails")
+    }
+}
+
+// captureLogger 记录 Errorf 输出，用于断言后台协程的错误日志。
+type captureLogger struct {
+    mu    sync.Mutex
+    lines []string
+}
+
+func (l *captureLogger) Errorf(format string, args ...any) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+// TestCleanupLogger 验证清理出错或 panic 时通过配置的 Logger 记录，未配置 Logger 时使用空实现且不 panic。
+func TestCleanupLogger(t *testing.T) {
+    logger := &captureLogger{}
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: false, Logger: logger}).(*jwtAuther)
+
+    a.runCleanup(func(context.Context) error { return errors.New("store unavailable") })
+    a.runCleanup(func(context.Context) error { panic("boom") })
+    a.runCleanup(a.CleanupExpiredTokens)
+
+    if len(logger.lines) != 2 {
+        t.Fatalf("expected 2 log lines, got %#v", logger.lines)
+    }
+    if !strings.Contains(logger.lines[0], "store unavailable") || !strings.Contains(logger.lines[1], "boom") {
+        t.Fatalf("unexpected log lines: %#v", logger.lines)
+    }
+
+    quiet := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: false}).(*jwtAuther)
+    quiet.runCleanup(func(context.Context) error { return errors.New("ignored") })
+}
+
+// TestBlackListMaxSize 验证超过上限时淘汰 ExpiresAt 最早的项：数量保持在上限内，过期最晚的项被保留。
+func TestBlackListMaxSize(t *testing.T) {
+    ctx := context.Background()
+    bl := NewBlackList()
+    bl.MaxSize = 3
+
+    now := time.Now()
+    for i := 1; i <= 5; i++ {
+        if err := bl.Add(ctx, fmt.Sprintf("token-%d", i), "u1", now.Add(time.Duration(i)*time.Hour)); err != nil {
+            t.Fatalf("Add failed: %v", err)
+        }
+        if bl.Size() > bl.MaxSize {
+            t.Fatalf("size %d exceeds MaxSize %d", bl.Size(), bl.MaxSize)
+        }
+    }
+    for i := 1; i <= 5; i++ {
+        revoked, _ := bl.IsRevoked(ctx, fmt.Sprintf("token-%d", i))
+        if want := i >= 3; revoked != want {
+            t.Fatalf("token-%d revoked=%v, want %v", i, revoked, want)
+        }
+    }
+
+    // 新增项过期时间最早时淘汰它自身
+    _ = bl.Add(ctx, "short-lived", "u1", now.Add(time.Minute))
+    if revoked, _ := bl.IsRevoked(ctx, "short-lived"); revoked || bl.Size() != 3 {
+        t.Fatalf("short-lived entry should be evicted first, revoked=%v size=%d", revoked, bl.Size())
+    }
+
+    if n := bl.PruneOldest(2); n != 2 || bl.Size() != 1 {
+        t.Fatalf("PruneOldest(2) = %d, size %d", n, bl.Size())
+    }
+    if revoked, _ := bl.IsRevoked(ctx, "token-5"); !revoked {
+        t.Fatal("longest-lived entry should be retained")
+    }
+    if n := bl.PruneOldest(10); n != 1 || bl.Size() != 0 {
+        t.Fatalf("PruneOldest beyond size = %d, size %d", n, bl.Size())
+    }
+}
+
+// TestBlackListMaxSizeConfig 验证 BlackListMaxSize 传递到认证器的黑名单。
+func TestBlackListMaxSizeConfig(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true, BlackListMaxSize: 2}).(*jwtAuther)
+    defer a.Close()
+    ctx := context.Background()
+
+    for i := 0; i < 4; i++ {
+        tok, err := a.MintAccessToken(ctx, "u80", "user80", "user", time.Duration(i+1)*time.Hour, nil)
+        if err != nil {
+            t.Fatalf("MintAccessToken failed: %v", err)
+        }
+        if err := a.RevokeToken(ctx, tok.Token); err != nil {
+            t.Fatalf("RevokeToken failed: %v", err)
+        }
+    }
+    if a.blackList.Size() != 2 {
+        t.Fatalf("expected blacklist bounded to 2 entries, got %d", a.blackList.Size())
+    }
+}
+
+// TestEd25519RoundTrip 验证 EdDSA 模式：私钥签发、公钥验证，HS256 令牌被拒绝，仅配置公钥时只能验证不能签发。
+func TestEd25519RoundTrip(t *testing.T) {
+    ctx := context.Background()
+    pub, priv, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatalf("GenerateKey failed: %v", err)
+    }
+
+    signer := newTestAuther(t, AutherConfig{Ed25519PrivateKey: priv, Issuer: "edge"})
+    pair, err := signer.GenerateTokenPair(ctx, "u90", "user90", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    header := strings.SplitN(pair.AccessToken.Token, ".", 2)[0]
+    raw, _ := base64.RawURLEncoding.DecodeString(header)
+    if !strings.Contains(string(raw), `"alg":"EdDSA"`) {
+        t.Fatalf("expected EdDSA header, got %s", raw)
+    }
+    if _, err := signer.ValidateToken(ctx, pair.AccessToken.Token); err != nil {
+        t.Fatalf("token should validate with derived public key, got: %v", err)
+    }
+    if _, err := signer.RefreshTokenRotate(ctx, pair.RefreshToken.Token); err != nil {
+        t.Fatalf("RefreshTokenRotate failed: %v", err)
+    }
+
+    // 仅持有公钥的验证方
+    verifier := newTestAuther(t, AutherConfig{Ed25519PublicKey: pub, Issuer: "edge"})
+    claims, err := verifier.ValidateToken(ctx, pair.AccessToken.Token)
+    if err != nil || claims.UserID != "u90" {
+        t.Fatalf("public-key verifier should accept token, got claims=%v err=%v", claims, err)
+    }
+    if _, err := verifier.MintAccessToken(ctx, "u90", "user90", "user", time.Hour, nil); !errors.Is(err, ErrSigningKeyMissing) {
+        t.Fatalf("expected ErrSigningKeyMissing for verify-only auther, got: %v", err)
+    }
+
+    // 其他密钥对与 HS256 签名的令牌均被拒绝
+    _, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+    other := newTestAuther(t, AutherConfig{Ed25519PrivateKey: otherPriv, Issuer: "edge"})
+    otherTok, err := other.MintAccessToken(ctx, "u91", "user91", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := verifier.ValidateToken(ctx, otherTok.Token); err == nil {
+        t.Fatal("token signed by another key pair should be rejected")
+    }
+    hs := newTestAuther(t, AutherConfig{SecretKey: "secret", Issuer: "edge"})
+    hsTok, err := hs.MintAccessToken(ctx, "u92", "user92", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := verifier.ValidateToken(ctx, hsTok.Token); err == nil {
+        t.Fatal("HS256 token should be rejected in EdDSA mode")
+    }
+    if _, err := hs.ValidateToken(ctx, pair.AccessToken.Token); err == nil {
+        t.Fatal("EdDSA token should be rejected in HS256 mode")
+    }
+}
+
+// TestEd25519KeyValidation 验证密钥长度错误或公私钥不匹配时 NewAuther 返回 ErrInvalidSigningKey。
+func TestEd25519KeyValidation(t *testing.T) {
+    pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+    otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+    cases := map[string]AutherConfig{
+        "short private key": {Ed25519PrivateKey: priv[:10]},
+        "short public key":  {Ed25519PublicKey: pub[:10]},
+        "mismatched pair":   {Ed25519PrivateKey: priv, Ed25519PublicKey: otherPub},
+    }
+    for name, cfg := range cases {
+        if _, err := NewAuther(&cfg); !errors.Is(err, ErrInvalidSigningKey) {
+            t.Errorf("%s: expected ErrInvalidSigningKey, got: %v", name, err)
+        }
+    }
+    if a, err := NewAuther(&AutherConfig{Ed25519PrivateKey: priv, Ed25519PublicKey: pub}); err != nil {
+        t.Fatalf("matching key pair should be accepted, got: %v", err)
+    } else {
+        _ = a.Close()
+    }
+}
+
+// TestMintAccessTokens 验证批量签发：返回顺序与请求一致，每个令牌均可独立验证；无法签名时直接返回错误。
+func TestMintAccessTokens(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret"})
+    ctx := context.Background()
+
+    reqs := make([]MintRequest, 100)
+    for i := range reqs {
+        reqs[i] = MintRequest{
+            UserID:   fmt.Sprintf("svc-%d", i),
+            Username: fmt.Sprintf("service-%d", i),
+            Role:     "service",
+            Exp:      time.Hour,
+            Metadata: map[string]string{"index": fmt.Sprint(i)},
+        }
+    }
+    tokens, err := a.MintAccessTokens(ctx, reqs)
+    if err != nil {
+        t.Fatalf("MintAccessTokens failed: %v", err)
+    }
+    if len(tokens) != len(reqs) {
+        t.Fatalf("expected %d tokens, got %d", len(reqs), len(tokens))
+    }
+    seen := make(map[string]bool, len(tokens))
+    for i, tok := range tokens {
+        claims, err := a.ValidateTokenOfType(ctx, tok.Token, AccessToken)
+        if err != nil {
+            t.Fatalf("token %d should validate, got: %v", i, err)
+        }
+        if claims.UserID != reqs[i].UserID || claims.Metadata["index"] != fmt.Sprint(i) || tok.UserID != reqs[i].UserID {
+            t.Fatalf("token %d out of order: claims=%+v", i, claims)
+        }
+        if seen[claims.ID] {
+            t.Fatalf("token %d reuses jti %s", i, claims.ID)
+        }
+        seen[claims.ID] = true
+    }
+
+    if tokens, err := a.MintAccessTokens(ctx, nil); err != nil || len(tokens) != 0 {
+        t.Fatalf("empty batch should succeed with no tokens, got %v err=%v", tokens, err)
+    }
+
+    pub, _, _ := ed25519.GenerateKey(rand.Reader)
+    verifier := newTestAuther(t, AutherConfig{Ed25519PublicKey: pub})
+    if _, err := verifier.MintAccessTokens(ctx, reqs); !errors.Is(err, ErrSigningKeyMissing) {
+        t.Fatalf("expected ErrSigningKeyMissing, got: %v", err)
+    }
+}
+
+// TestBlackListExportImport 验证导出的黑名单快照导入到新的认证器后，按令牌、按用户与按会话族撤销的令牌仍被拒绝，过期记录被跳过。
+func TestBlackListExportImport(t *testing.T) {
+    ctx := context.Background()
+    cfg := AutherConfig{SecretKey: "secret", BlackListEnabled: true}
+
+    before := newTestAuther(t, cfg)
+    defer before.Close()
+    tok, err := before.MintAccessToken(ctx, "u100", "user100", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if err := before.RevokeToken(ctx, tok.Token); err != nil {
+        t.Fatalf("RevokeToken failed: %v", err)
+    }
+    userPair, err := before.GenerateTokenPair(ctx, "u101", "user101", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    if err := before.RevokeAllForUser(ctx, "u101"); err != nil {
+        t.Fatalf("RevokeAllForUser failed: %v", err)
+    }
+    familyPair, err := before.GenerateTokenPair(ctx, "u102", "user102", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    familyClaims, err := before.GetTokenInfo(familyPair.AccessToken.Token)
+    if err != nil {
+        t.Fatalf("GetTokenInfo failed: %v", err)
+    }
+    if err := before.RevokeFamily(ctx, familyClaims.FamilyID); err != nil {
+        t.Fatalf("RevokeFamily failed: %v", err)
+    }
+
+    snapshot, err := before.ExportBlackList(ctx)
+    if err != nil || len(snapshot.Items) != 1 || len(snapshot.UserCutoffs) != 1 || len(snapshot.Families) != 1 {
+        t.Fatalf("unexpected snapshot %+v, err=%v", snapshot, err)
+    }
+    if snapshot.UserCutoffs[0].UserID != "u101" || snapshot.Families[0].FamilyID != familyClaims.FamilyID {
+        t.Fatalf("unexpected snapshot records: %+v", snapshot)
+    }
+
+    // 快照经 JSON 序列化后恢复（模拟持久化），并追加已过期的记录
+    data, err := json.Marshal(snapshot)
+    if err != nil {
+        t.Fatalf("marshal snapshot failed: %v", err)
+    }
+    var restored BlackListSnapshot
+    if err := json.Unmarshal(data, &restored); err != nil {
+        t.Fatalf("unmarshal snapshot failed: %v", err)
+    }
+    expired := time.Now().Add(-time.Minute)
+    restored.Items = append(restored.Items, BlackListItem{TokenHash: "expired", ExpiresAt: expired})
+    restored.UserCutoffs = append(restored.UserCutoffs, UserCutoff{UserID: "stale", Cutoff: expired, ExpiresAt: expired})
+    restored.Families = append(restored.Families, RevokedFamily{FamilyID: "stale", ExpiresAt: expired})
+
+    after := newTestAuther(t, cfg)
+    defer after.Close()
+    for _, token := range []string{tok.Token, userPair.AccessToken.Token, familyPair.AccessToken.Token} {
+        if _, err := after.ValidateToken(ctx, token); err != nil {
+            t.Fatalf("fresh blacklist should accept token before import, got: %v", err)
+        }
+    }
+    if err := after.ImportBlackList(ctx, restored); err != nil {
+        t.Fatalf("ImportBlackList failed: %v", err)
+    }
+    if revoked, err := after.IsTokenRevoked(ctx, tok.Token); err != nil || !revoked {
+        t.Fatalf("expected token revoked after import, revoked=%v err=%v", revoked, err)
+    }
+    for _, token := range []string{tok.Token, userPair.AccessToken.Token, userPair.RefreshToken.Token, familyPair.AccessToken.Token} {
+        if _, err := after.ValidateToken(ctx, token); !errors.Is(err, ErrRevokedToken) {
+            t.Fatalf("expected ErrRevokedToken after import, got: %v", err)
+        }
+    }
+    bl := after.(*jwtAuther).blackList
+    if size := bl.Size(); size != 1 {
+        t.Fatalf("expected expired item to be skipped, size=%d", size)
+    }
+    if _, ok := bl.userCutoffs["stale"]; ok {
+        t.Fatal("expected expired user cutoff to be skipped")
+    }
+    if _, ok := bl.families["stale"]; ok {
+        t.Fatal("expected expired family to be skipped")
+    }
+
+    invalid := []BlackListSnapshot{
+        {Items: []BlackListItem{{ExpiresAt: time.Now().Add(time.Hour)}}},
+        {UserCutoffs: []UserCutoff{{Cutoff: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}}},
+        {Families: []RevokedFamily{{ExpiresAt: time.Now().Add(time.Hour)}}},
+    }
+    for i, snap := range invalid {
+        if err := NewBlackList().Import(snap); err == nil {
+            t.Fatalf("case %d: expected error for record without id", i)
+        }
+    }
+}
+
+// TestBlackListCancelledContext 验证已取消的上下文使黑名单操作直接返回上下文错误且不修改状态。
+func TestBlackListCancelledContext(t *testing.T) {
+    bl := NewBlackList()
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    if err := bl.Add(ctx, "token", "u1", time.Now().Add(time.Hour)); !errors.Is(err, context.Canceled) {
+        t.Fatalf("Add: expected context.Canceled, got: %v", err)
+    }
+    if bl.Size() != 0 {
+        t.Fatalf("Add with cancelled context should not modify blacklist, size=%d", bl.Size())
+    }
+    if _, err := bl.IsRevoked(ctx, "token"); !errors.Is(err, context.Canceled) {
+        t.Fatalf("IsRevoked: expected context.Canceled, got: %v", err)
+    }
+    if err := bl.Cleanup(ctx); !errors.Is(err, context.Canceled) {
+        t.Fatalf("Cleanup: expected context.Canceled, got: %v", err)
+    }
+
+    deadline, cancelDeadline := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+    defer cancelDeadline()
+    if _, err := bl.CountByUser(deadline, "u1"); !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("CountByUser: expected context.DeadlineExceeded, got: %v", err)
+    }
+
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    tok, err := a.MintAccessToken(context.Background(), "u110", "user110", "user", time.Hour, nil)
+    if err != nil {
+        t.Fatalf("MintAccessToken failed: %v", err)
+    }
+    if _, err := a.ValidateToken(ctx, tok.Token); !errors.Is(err, context.Canceled) {
+        t.Fatalf("ValidateToken: expected context.Canceled, got: %v", err)
+    }
+}
+
+// TestRevokeFamily 验证显式撤销会话族：轮换链上的最新令牌随之失效，其他会话不受影响。
+func TestRevokeFamily(t *testing.T) {
+    a := newTestAuther(t, AutherConfig{SecretKey: "secret", BlackListEnabled: true})
+    defer a.Close()
+    ctx := context.Background()
+
+    pair, err := a.GenerateTokenPair(ctx, "u120", "user120", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    other, err := a.GenerateTokenPair(ctx, "u120", "user120", "user", nil)
+    if err != nil {
+        t.Fatalf("GenerateTokenPair failed: %v", err)
+    }
+    current, err := a.RefreshTokenRotate(ctx, pair.RefreshToken.Token)
+    if err != nil {
+        t.Fatalf("RefreshTokenRotate failed: %v", err)
+    }
+
+    claims, err := a.ValidateToken(ctx, current.AccessToken.Token)
+    if err != nil {
+        t.Fatalf("current access token should be valid before revocation: %v", err)
+    }
+    if err := a.RevokeFamily(ctx, claims.FamilyID); err != nil {
+        t.Fatalf("RevokeFamily failed: %v", err)
+    }
+    for name, tok := range map[string]string{
+        "current access":  current.AccessToken.Token,
+        "current refresh": current.RefreshToken.Token,
+    } {
+        if _, err := a.ValidateToken(ctx, tok); !errorsIs(err, ErrRevokedToken) {
+            t.Fatalf("%s token should be revoked with its family, got: %v", name, err)
+        }
+    }
+    if _, err := a.ValidateToken(ctx, other.AccessToken.Token); err != nil {
+        t.Fatalf("other session should remain valid, got: %v", err)
+    }
+
+    if err := a.RevokeFamily(ctx, " "); err == nil {
+        t.Fatal("expected error for empty family id")
+    }
+    disabled := newTestAuther(t, AutherConfig{SecretKey: "secret"})
+    if err := disabled.RevokeFamily(ctx, claims.FamilyID); err != nil {
+        t.Fatalf("RevokeFamily should be a no-op without blacklist, got: %v", err)
+    }
+}
+
+// TestBlackListShardedCleanupConcurrent 在并发撤销与校验的同时执行清理（配合 -race 运行），
+// 验证不发生死锁，且所有分片中的过期项均被清理、有效项保留。
+func TestBlackListShardedCleanupConcurrent(t *testing.T) {
+    ctx := context.Background()
+    bl := NewBlackList()
+    now := time.Now()
+
+    const n = 512
+    for i := 0; i < n; i++ {
+        _ = bl.Add(ctx, fmt.Sprintf("expired-%d", i), "u1", now.Add(-time.Minute))
+        _ = bl.Add(ctx, fmt.Sprintf("live-%d", i), "u1", now.Add(time.Hour))
+    }
+    for i, shard := range bl.shards {
+        if len(shard.items) == 0 {
+            t.Fatalf("shard %d is empty, tokens are not spread across shards", i)
+        }
+    }
+
+    stop := make(chan struct{})
+    var wg sync.WaitGroup
+    for w := 0; w < 4; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := 0; ; i++ {
+                select {
+                case <-stop:
+                    return
+                default:
+                }
+                if revoked, err := bl.IsRevoked(ctx, fmt.Sprintf("live-%d", i%n)); err != nil || !revoked {
+                    t.Errorf("live token should stay revoked, got %v err=%v", revoked, err)
+                    return
+                }
+                _ = bl.Add(ctx, fmt.Sprintf("worker-%d-%d", w, i%64), "u2", now.Add(time.Hour))
+            }
+        }(w)
+    }
+
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        for i := 0; i < 20; i++ {
+            if err := bl.Cleanup(ctx); err != nil {
+                t.Errorf("Cleanup failed: %v", err)
+                return
+            }
+        }
+    }()
+    select {
+    case <-done:
+    case <-time.After(10 * time.Second):
+        t.Fatal("cleanup did not finish, possible deadlock")
+    }
+    close(stop)
+    wg.Wait()
+
+    total := 0
+    for i, shard := range bl.shards {
+        for hash, item := range shard.items {
+            if now.After(item.ExpiresAt) {
+                t.Fatalf("shard %d still holds expired item %s", i, hash)
+            }
+        }
+        total += len(shard.items)
+    }
+    if total != bl.Size() {
+        t.Fatalf("size counter %d does not match shard contents %d", bl.Size(), total)
+    }
+    if count, _ := bl.CountByUser(ctx, "u1"); count != n {
+        t.Fatalf("expected %d live items for u1, got %d", n, count)
+    }
+}
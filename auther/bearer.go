@@ -0,0 +1,27 @@
+package auther
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrMalformedAuthHeader Authorization 请求头不是合法的 "Bearer <token>" 格式
+var ErrMalformedAuthHeader = errors.New("malformed authorization header")
+
+// bearerScheme Authorization 请求头中的认证方案名（比较时不区分大小写）
+const bearerScheme = "Bearer"
+
+// ExtractBearerToken 从 Authorization 请求头中提取令牌，要求格式为 "Bearer <token>"：
+// 方案名不区分大小写，方案名与令牌之间有且仅有一个空格，令牌非空且不含空白字符；
+// 不满足时返回 ErrMalformedAuthHeader。返回的令牌仍需调用 ValidateToken 校验。
+func ExtractBearerToken(header string) (string, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", ErrMalformedAuthHeader
+	}
+	if token == "" || strings.IndexFunc(token, unicode.IsSpace) >= 0 {
+		return "", ErrMalformedAuthHeader
+	}
+	return token, nil
+}
@@ -0,0 +1,39 @@
+package auther
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExtractBearerToken 验证 Bearer 请求头解析：方案名不区分大小写，缺少方案、空令牌与多余空白均返回 ErrMalformedAuthHeader。
+func TestExtractBearerToken(t *testing.T) {
+	valid := map[string]string{
+		"Bearer abc.def.ghi": "abc.def.ghi",
+		"bearer abc.def.ghi": "abc.def.ghi",
+		"BEARER token":       "token",
+	}
+	for header, want := range valid {
+		got, err := ExtractBearerToken(header)
+		if err != nil || got != want {
+			t.Errorf("ExtractBearerToken(%q) = %q, %v; want %q", header, got, err, want)
+		}
+	}
+
+	malformed := []string{
+		"",
+		"abc.def.ghi",         // 缺少方案名
+		"Basic dXNlcjpwYXNz",  // 其他方案
+		"Bearer",              // 缺少令牌
+		"Bearer ",             // 空令牌
+		"Bearer  abc.def.ghi", // 多余空格
+		" Bearer abc.def.ghi", // 前导空白
+		"Bearer abc.def.ghi ", // 尾随空白
+		"Bearer abc def",      // 令牌含空白
+		"Bearer\tabc.def.ghi", // 非空格分隔
+	}
+	for _, header := range malformed {
+		if got, err := ExtractBearerToken(header); !errors.Is(err, ErrMalformedAuthHeader) || got != "" {
+			t.Errorf("ExtractBearerToken(%q) = %q, %v; want ErrMalformedAuthHeader", header, got, err)
+		}
+	}
+}
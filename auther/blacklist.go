@@ -4,7 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,43 +20,184 @@ type BlackListItem struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// UserCutoff 按用户撤销记录的快照项：该用户签发时间早于 Cutoff 的令牌均已撤销，ExpiresAt 之后记录失效
+type UserCutoff struct {
+	UserID    string    `json:"user_id"`
+	Cutoff    time.Time `json:"cutoff"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RevokedFamily 会话族撤销记录的快照项，ExpiresAt 之后族内令牌均已过期，记录失效
+type RevokedFamily struct {
+	FamilyID  string    `json:"family_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BlackListSnapshot 黑名单快照，包含按令牌、按用户与按会话族的全部撤销记录，可直接序列化为 JSON 持久化
+type BlackListSnapshot struct {
+	Items       []BlackListItem `json:"items"`
+	UserCutoffs []UserCutoff    `json:"user_cutoffs,omitempty"`
+	Families    []RevokedFamily `json:"families,omitempty"`
+}
+
+// blackListShardCount 令牌项的分片数量，令牌按哈希分散到各分片以降低锁竞争
+const blackListShardCount = 16
+
+// blackListShard 令牌项分片，持有独立的读写锁
+type blackListShard struct {
+	mu    sync.RWMutex
+	items map[string]*BlackListItem
+}
+
 // BlackList 黑名单管理器
+// 令牌项按哈希分片存储，撤销与校验只锁定所在分片，Cleanup 逐个分片清理，不会一次性阻塞全部校验。
+// 带 ctx 参数的方法在入口检查上下文，已取消或已超时时直接返回 ctx.Err()，不读写黑名单
 type BlackList struct {
-    items map[string]*BlackListItem
-    mu    sync.RWMutex
+	shards [blackListShardCount]*blackListShard
+	// size 各分片令牌项总数
+	size atomic.Int64
+	// userCutoffs 记录按用户撤销的时间点：该用户签发时间早于此时间点的令牌均视为已撤销；
+	// 撤销前签发的令牌全部过期后该记录失去作用，由 Cleanup 清理
+	userCutoffs map[string]userCutoff
+	// families 记录被整体撤销的会话族（刷新令牌轮换链）及其失效时间，过期后由 Cleanup 清理
+	families map[string]time.Time
+	// mu 保护 userCutoffs 与 families
+	mu sync.RWMutex
+	// MaxSize 黑名单令牌项上限，0 表示不限制；超出时优先淘汰 ExpiresAt 最早的项。应在开始使用前设置
+	MaxSize int
 }
 
 // NewBlackList 创建新的黑名单
 func NewBlackList() *BlackList {
-	return &BlackList{
-		items: make(map[string]*BlackListItem),
+	bl := &BlackList{
+		userCutoffs: make(map[string]userCutoff),
+		families:    make(map[string]time.Time),
+	}
+	for i := range bl.shards {
+		bl.shards[i] = &blackListShard{items: make(map[string]*BlackListItem)}
 	}
+	return bl
 }
 
 // Add 添加令牌到黑名单
 func (bl *BlackList) Add(ctx context.Context, token string, userID string, expiresAt time.Time) error {
-	bl.mu.Lock()
-	defer bl.mu.Unlock()
+	if err := ctxErr(ctx); err != nil {
+		return err
+	}
 
 	tokenHash := bl.hashToken(token)
-	bl.items[tokenHash] = &BlackListItem{
+	shard := bl.shard(tokenHash)
+	shard.mu.Lock()
+	if _, exists := shard.items[tokenHash]; !exists {
+		bl.size.Add(1)
+	}
+	shard.items[tokenHash] = &BlackListItem{
 		TokenHash: tokenHash,
 		ExpiresAt: expiresAt,
 		UserID:    userID,
 		CreatedAt: time.Now(),
 	}
+	shard.mu.Unlock()
+	bl.enforceMaxSize()
 
 	return nil
 }
 
+// shard 返回令牌哈希所在的分片
+func (bl *BlackList) shard(tokenHash string) *blackListShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tokenHash))
+	return bl.shards[h.Sum32()%blackListShardCount]
+}
+
+// lockAll 按固定顺序锁定全部分片，用于需要全局视图的淘汰操作；其他操作同一时刻只持有一个分片锁，不会形成死锁
+func (bl *BlackList) lockAll() {
+	for _, shard := range bl.shards {
+		shard.mu.Lock()
+	}
+}
+
+// unlockAll 释放 lockAll 持有的全部分片锁
+func (bl *BlackList) unlockAll() {
+	for _, shard := range bl.shards {
+		shard.mu.Unlock()
+	}
+}
+
+// enforceMaxSize 令牌项总数超过 MaxSize 时淘汰超出的部分
+func (bl *BlackList) enforceMaxSize() {
+	if bl.MaxSize <= 0 || int(bl.size.Load()) <= bl.MaxSize {
+		return
+	}
+
+	bl.lockAll()
+	defer bl.unlockAll()
+	// 持有全部分片锁后重新计算，避免并发 Add 重复淘汰
+	bl.evictLocked(int(bl.size.Load()) - bl.MaxSize)
+}
+
+// PruneOldest 淘汰 n 个 ExpiresAt 最早的令牌项（含已过期项），返回实际淘汰数量。
+// 注意：被淘汰且尚未过期的令牌将重新被视为有效，仅应在内存受限时使用。
+func (bl *BlackList) PruneOldest(n int) int {
+	bl.lockAll()
+	defer bl.unlockAll()
+
+	return bl.evictLocked(n)
+}
+
+// evictLocked 淘汰 n 个 ExpiresAt 最早的令牌项，调用方需通过 lockAll 持有全部分片锁。
+// 仅淘汰一项时线性查找最早项，避免在达到上限后的每次 Add 中排序。
+func (bl *BlackList) evictLocked(n int) int {
+	if n <= 0 || bl.size.Load() == 0 {
+		return 0
+	}
+	if n == 1 {
+		var (
+			oldest      *BlackListItem
+			oldestShard *blackListShard
+		)
+		for _, shard := range bl.shards {
+			for _, item := range shard.items {
+				if oldest == nil || item.ExpiresAt.Before(oldest.ExpiresAt) {
+					oldest, oldestShard = item, shard
+				}
+			}
+		}
+		delete(oldestShard.items, oldest.TokenHash)
+		bl.size.Add(-1)
+		return 1
+	}
+
+	items := make([]*BlackListItem, 0, bl.size.Load())
+	for _, shard := range bl.shards {
+		for _, item := range shard.items {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ExpiresAt.Before(items[j].ExpiresAt) })
+	if n > len(items) {
+		n = len(items)
+	}
+	for _, item := range items[:n] {
+		delete(bl.shard(item.TokenHash).items, item.TokenHash)
+	}
+	bl.size.Add(-int64(n))
+	return n
+}
+
 
 // IsRevoked 检查令牌是否被撤销
 func (bl *BlackList) IsRevoked(ctx context.Context, token string) (bool, error) {
-	bl.mu.RLock()
-	defer bl.mu.RUnlock()
+	if err := ctxErr(ctx); err != nil {
+		return false, err
+	}
 
 	tokenHash := bl.hashToken(token)
-	item, exists := bl.items[tokenHash]
+	shard := bl.shard(tokenHash)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+
+	item, exists := shard.items[tokenHash]
 	if !exists {
 		return false, nil
 	}
@@ -67,15 +212,240 @@ func (bl *BlackList) IsRevoked(ctx context.Context, token string) (bool, error)
 
 
 
-// Cleanup 清理过期的黑名单项
+// userCutoff 按用户撤销的记录：cutoff 为撤销时间点，expiresAt 之后撤销前签发的令牌均已过期，记录可被清理
+type userCutoff struct {
+	cutoff    time.Time
+	expiresAt time.Time
+}
+
+// RevokeUserBefore 撤销指定用户在 cutoff 之前签发的全部令牌，expiresAt 之后这些令牌均已过期，该记录可被清理；
+// 多次调用时撤销时间点与失效时间均保留较晚者。
+func (bl *BlackList) RevokeUserBefore(ctx context.Context, userID string, cutoff, expiresAt time.Time) error {
+	if err := ctxErr(ctx); err != nil {
+		return err
+	}
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+
+	bl.mergeUserCutoffLocked(userID, userCutoff{cutoff: cutoff, expiresAt: expiresAt})
+
+	return nil
+}
+
+// mergeUserCutoffLocked 合并按用户撤销记录，撤销时间点与失效时间均保留较晚者；调用方需持有 bl.mu
+func (bl *BlackList) mergeUserCutoffLocked(userID string, entry userCutoff) {
+	if prev, ok := bl.userCutoffs[userID]; ok {
+		if prev.cutoff.After(entry.cutoff) {
+			entry.cutoff = prev.cutoff
+		}
+		if prev.expiresAt.After(entry.expiresAt) {
+			entry.expiresAt = prev.expiresAt
+		}
+	}
+	bl.userCutoffs[userID] = entry
+}
+
+// IsRevokedForUser 检查签发时间为 issuedAt 的令牌是否已被按用户撤销；用户从未被撤销时返回 false
+func (bl *BlackList) IsRevokedForUser(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	if err := ctxErr(ctx); err != nil {
+		return false, err
+	}
+
+	bl.mu.RLock()
+	defer bl.mu.RUnlock()
+
+	entry, ok := bl.userCutoffs[userID]
+	if !ok {
+		return false, nil
+	}
+
+	return issuedAt.Before(entry.cutoff), nil
+}
+
+// RevokeFamily 撤销整个会话族，expiresAt 之后族内令牌均已过期，该记录可被清理；多次调用时保留较晚的时间
+func (bl *BlackList) RevokeFamily(ctx context.Context, familyID string, expiresAt time.Time) error {
+	if err := ctxErr(ctx); err != nil {
+		return err
+	}
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+
+	bl.mergeFamilyLocked(familyID, expiresAt)
+
+	return nil
+}
+
+// mergeFamilyLocked 合并会话族撤销记录，保留较晚的失效时间；调用方需持有 bl.mu
+func (bl *BlackList) mergeFamilyLocked(familyID string, expiresAt time.Time) {
+	if prev, ok := bl.families[familyID]; !ok || expiresAt.After(prev) {
+		bl.families[familyID] = expiresAt
+	}
+}
+
+// IsFamilyRevoked 检查会话族是否已被撤销
+func (bl *BlackList) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	if err := ctxErr(ctx); err != nil {
+		return false, err
+	}
+
+	bl.mu.RLock()
+	defer bl.mu.RUnlock()
+
+	expiresAt, ok := bl.families[familyID]
+	if !ok {
+		return false, nil
+	}
+
+	return !time.Now().After(expiresAt), nil
+}
+
+// CountByUser 统计指定用户在黑名单中尚未过期的令牌数量
+func (bl *BlackList) CountByUser(ctx context.Context, userID string) (int, error) {
+	if err := ctxErr(ctx); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	count := 0
+	for _, shard := range bl.shards {
+		shard.mu.RLock()
+		for _, item := range shard.items {
+			if item.UserID == userID && !now.After(item.ExpiresAt) {
+				count++
+			}
+		}
+		shard.mu.RUnlock()
+	}
+
+	return count, nil
+}
+
+// Cleanup 清理过期的黑名单项。
+// 逐个分片加锁清理，清理某一分片时其他分片上的撤销与校验不受影响；处理每个分片前检查 ctx，取消时返回 ctx.Err()，
+// 已清理的分片保持清理后的状态
 func (bl *BlackList) Cleanup(ctx context.Context) error {
+	now := time.Now()
+	for _, shard := range bl.shards {
+		if err := ctxErr(ctx); err != nil {
+			return err
+		}
+
+		shard.mu.Lock()
+		for tokenHash, item := range shard.items {
+			if now.After(item.ExpiresAt) {
+				delete(shard.items, tokenHash)
+				bl.size.Add(-1)
+			}
+		}
+		shard.mu.Unlock()
+	}
+	if err := ctxErr(ctx); err != nil {
+		return err
+	}
+
 	bl.mu.Lock()
 	defer bl.mu.Unlock()
 
+	for familyID, expiresAt := range bl.families {
+		if now.After(expiresAt) {
+			delete(bl.families, familyID)
+		}
+	}
+	for userID, entry := range bl.userCutoffs {
+		if now.After(entry.expiresAt) {
+			delete(bl.userCutoffs, userID)
+		}
+	}
+
+	return nil
+}
+
+// Export 导出尚未过期的撤销记录快照，用于在服务关闭时持久化到磁盘或数据库：
+// 按令牌撤销的项按 ExpiresAt 升序，按用户撤销与会话族撤销的记录分别按用户 ID、会话族 ID 排序。
+func (bl *BlackList) Export() (BlackListSnapshot, error) {
 	now := time.Now()
-	for tokenHash, item := range bl.items {
+	snapshot := BlackListSnapshot{Items: make([]BlackListItem, 0, bl.size.Load())}
+	for _, shard := range bl.shards {
+		shard.mu.RLock()
+		for _, item := range shard.items {
+			if !now.After(item.ExpiresAt) {
+				snapshot.Items = append(snapshot.Items, *item)
+			}
+		}
+		shard.mu.RUnlock()
+	}
+	sort.Slice(snapshot.Items, func(i, j int) bool { return snapshot.Items[i].ExpiresAt.Before(snapshot.Items[j].ExpiresAt) })
+
+	bl.mu.RLock()
+	for userID, entry := range bl.userCutoffs {
+		if !now.After(entry.expiresAt) {
+			snapshot.UserCutoffs = append(snapshot.UserCutoffs, UserCutoff{UserID: userID, Cutoff: entry.cutoff, ExpiresAt: entry.expiresAt})
+		}
+	}
+	for familyID, expiresAt := range bl.families {
+		if !now.After(expiresAt) {
+			snapshot.Families = append(snapshot.Families, RevokedFamily{FamilyID: familyID, ExpiresAt: expiresAt})
+		}
+	}
+	bl.mu.RUnlock()
+	sort.Slice(snapshot.UserCutoffs, func(i, j int) bool { return snapshot.UserCutoffs[i].UserID < snapshot.UserCutoffs[j].UserID })
+	sort.Slice(snapshot.Families, func(i, j int) bool { return snapshot.Families[i].FamilyID < snapshot.Families[j].FamilyID })
+
+	return snapshot, nil
+}
+
+// Import 导入 Export 得到的快照，用于服务启动时恢复黑名单；已过期的记录被跳过。
+// 与已有记录冲突时按与撤销操作相同的规则合并（令牌项与会话族保留较晚的 ExpiresAt，按用户撤销的时间点与失效时间均取较晚者）；
+// 任一令牌项 TokenHash、按用户撤销记录的 UserID 或会话族记录的 FamilyID 为空时返回错误且不导入任何记录。
+// 设置了 MaxSize 时，导入后超出上限的令牌项按 ExpiresAt 最早优先淘汰。
+func (bl *BlackList) Import(snapshot BlackListSnapshot) error {
+	for i, item := range snapshot.Items {
+		if item.TokenHash == "" {
+			return fmt.Errorf("invalid blacklist item at index %d: empty token hash", i)
+		}
+	}
+	for i, c := range snapshot.UserCutoffs {
+		if c.UserID == "" {
+			return fmt.Errorf("invalid user cutoff at index %d: empty user id", i)
+		}
+	}
+	for i, f := range snapshot.Families {
+		if f.FamilyID == "" {
+			return fmt.Errorf("invalid revoked family at index %d: empty family id", i)
+		}
+	}
+
+	now := time.Now()
+	for _, item := range snapshot.Items {
 		if now.After(item.ExpiresAt) {
-			delete(bl.items, tokenHash)
+			continue
+		}
+		shard := bl.shard(item.TokenHash)
+		shard.mu.Lock()
+		prev, exists := shard.items[item.TokenHash]
+		if !exists || item.ExpiresAt.After(prev.ExpiresAt) {
+			imported := item
+			shard.items[item.TokenHash] = &imported
+			if !exists {
+				bl.size.Add(1)
+			}
+		}
+		shard.mu.Unlock()
+	}
+	bl.enforceMaxSize()
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	for _, c := range snapshot.UserCutoffs {
+		if !now.After(c.ExpiresAt) {
+			bl.mergeUserCutoffLocked(c.UserID, userCutoff{cutoff: c.Cutoff, expiresAt: c.ExpiresAt})
+		}
+	}
+	for _, f := range snapshot.Families {
+		if !now.After(f.ExpiresAt) {
+			bl.mergeFamilyLocked(f.FamilyID, f.ExpiresAt)
 		}
 	}
 
@@ -84,9 +454,15 @@ func (bl *BlackList) Cleanup(ctx context.Context) error {
 
 // Size 获取黑名单大小
 func (bl *BlackList) Size() int {
-	bl.mu.RLock()
-	defer bl.mu.RUnlock()
-	return len(bl.items)
+	return int(bl.size.Load())
+}
+
+// ctxErr 返回已取消或已超时上下文的错误；ctx 为 nil 时视为未取消
+func ctxErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
 }
 
 // hashToken 生成令牌哈希
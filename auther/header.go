@@ -0,0 +1,39 @@
+package auther
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenHeader JOSE 头中用于诊断的字段
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// TokenHeader 仅解析令牌的 JOSE 头（不验证签名，也不解析 claims），返回其声明的签名算法 alg 与密钥标识 kid；
+// 令牌未携带 kid 时 kid 为空字符串。用于多密钥、多算法场景下排查密钥轮换与互通问题，返回值可被任意伪造，不得用于鉴权。
+// 令牌不是三段式、头部无法解码或缺少 alg 时返回的错误可用 errors.Is 匹配 ErrInvalidToken。
+func TokenHeader(token string) (alg string, kid string, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("%w: token contains an invalid number of segments", ErrInvalidToken)
+	}
+
+	raw, err := jwt.NewParser().DecodeSegment(parts[0])
+	if err != nil {
+		return "", "", fmt.Errorf("%w: failed to decode token header: %w", ErrInvalidToken, err)
+	}
+	var header tokenHeader
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return "", "", fmt.Errorf("%w: failed to parse token header: %w", ErrInvalidToken, err)
+	}
+	if header.Alg == "" {
+		return "", "", fmt.Errorf("%w: token header is missing alg", ErrInvalidToken)
+	}
+
+	return header.Alg, header.Kid, nil
+}
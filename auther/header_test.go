@@ -0,0 +1,48 @@
+package auther
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestTokenHeader 验证 TokenHeader 返回令牌声明的 alg 与 kid（未携带 kid 时为空），且不校验签名；格式错误的令牌返回 ErrInvalidToken。
+func TestTokenHeader(t *testing.T) {
+	withKid := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"})
+	withKid.Header["kid"] = "key-2024"
+	signed, err := withKid.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	alg, kid, err := TokenHeader(signed)
+	if err != nil || alg != "HS256" || kid != "key-2024" {
+		t.Fatalf("TokenHeader = %q, %q, %v; want HS256, key-2024", alg, kid, err)
+	}
+
+	// 由 Auther 签发的令牌不携带 kid
+	a := newTestAuther(t, AutherConfig{SecretKey: "test-secret"})
+	pair, err := a.GenerateTokenPair(context.Background(), "u1", "alice", "admin", nil)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair failed: %v", err)
+	}
+	alg, kid, err = TokenHeader(pair.AccessToken.Token)
+	if err != nil || alg != "HS256" || kid != "" {
+		t.Fatalf("TokenHeader = %q, %q, %v; want HS256 without kid", alg, kid, err)
+	}
+
+	// 签名无效的令牌同样可以读取头部
+	tampered := signed[:len(signed)-2] + "xx"
+	if alg, _, err := TokenHeader(tampered); err != nil || alg != "HS256" {
+		t.Fatalf("TokenHeader should not verify signature, got %q, %v", alg, err)
+	}
+
+	noAlg := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","kid":"k1"}`)) + ".e30.sig"
+	for _, token := range []string{"", "abc", "a.b", "!!!.e30.sig", base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".e30.sig", noAlg} {
+		if _, _, err := TokenHeader(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("TokenHeader(%q): expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package auther
 
 import (
 	"context"
+	"crypto/ed25519"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -27,9 +28,10 @@ type TokenInfo struct {
 }
 
 // TokenPair Token 对（访问令牌 + 刷新令牌）
+// 由 GenerateAccessOnly 生成时 RefreshToken 为零值，序列化为 JSON 时省略 refresh_token 字段。
 type TokenPair struct {
 	AccessToken  TokenInfo `json:"access_token"`
-	RefreshToken TokenInfo `json:"refresh_token"`
+	RefreshToken TokenInfo `json:"refresh_token,omitzero"`
 }
 
 // TokenClaims JWT Claims
@@ -39,25 +41,74 @@ type TokenClaims struct {
 	Role     string            `json:"role"`
 	Type     TokenType         `json:"type"`
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// FamilyID 会话族 ID：同一次登录签发的令牌对及其后续轮换得到的令牌共享该值，用于检测刷新令牌重放
+	FamilyID string `json:"fid,omitempty"`
+	// IssuedAtNano 纳秒精度的签发时间（Unix 纳秒），用于按用户撤销的判定；标准 iat 仅精确到秒，
+	// 无法区分撤销前后同一秒内签发的令牌。缺少该声明的令牌按 iat 判定
+	IssuedAtNano int64 `json:"iat_ns,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// MintRequest 批量签发访问令牌时的单项请求，字段含义与 MintAccessToken 的参数相同
+type MintRequest struct {
+	UserID   string
+	Username string
+	Role     string
+	Exp      time.Duration
+	Metadata map[string]string
+}
+
 // AutherConfig Auther 配置
 type AutherConfig struct {
 	// SecretKey JWT 密钥
 	SecretKey string
+	// PreviousSecretKey 上一个 JWT 密钥（可选），等价于只含一项的 PreviousSecretKeys：
+	// 合并时排在 PreviousSecretKeys 之前，同样去除首尾空白并忽略空值与重复项，签发始终使用 SecretKey
+	//
+	// Deprecated: 使用 PreviousSecretKeys。
+	PreviousSecretKey string
+	// PreviousSecretKeys 之前使用过的 JWT 密钥列表（可选），验证时在 SecretKey、PreviousSecretKey 之后按顺序尝试，
+	// 任一密钥验证通过即接受；用于多次轮换窗口重叠的场景，签发始终使用 SecretKey
+	PreviousSecretKeys []string
+	// Ed25519PrivateKey Ed25519 私钥（可选）。设置 Ed25519PrivateKey 或 Ed25519PublicKey 后改用 EdDSA 签名与验证，
+	// 此时无需 SecretKey，HS256 等其他算法签名的令牌均被拒绝
+	Ed25519PrivateKey ed25519.PrivateKey
+	// Ed25519PublicKey Ed25519 公钥（可选），未设置时由私钥推导；仅配置公钥时认证器只能验证令牌，签发返回 ErrSigningKeyMissing
+	Ed25519PublicKey ed25519.PublicKey
 	// AccessTokenExp 访问令牌过期时间（例如：2*time.Hour）
 	AccessTokenExp time.Duration
 	// RefreshTokenExp 刷新令牌过期时间（例如：7*24*time.Hour）
 	RefreshTokenExp time.Duration
 	// Issuer 签发者
 	Issuer string
+	// Audience 令牌受众（可选，如 API 标识符）。设置后签发时写入 aud，验证时要求令牌 aud 至少包含其中一项；
+	// 未设置时沿用以 Issuer 作为受众的行为以保持兼容
+	Audience []string
+	// ClaimsValidator 自定义声明校验（可选），在签名、时间、Issuer、Audience 等标准校验通过后调用；
+	// 返回非 nil 错误时令牌被拒绝，ValidateToken 返回同时匹配 ErrInvalidToken 与该错误的包装错误。
+	// 仅在成功路径上调用：签名、过期、撤销、Issuer、Audience 任一校验失败时不会调用
+	ClaimsValidator func(*TokenClaims) error
 	// BlackListEnabled 是否启用黑名单
 	BlackListEnabled bool
 	// BlackListCleanupInterval 黑名单清理间隔（例如：1*time.Hour）
 	BlackListCleanupInterval time.Duration
+	// BlackListMaxSize 黑名单令牌项上限（可选），0 表示不限制；超出时淘汰 ExpiresAt 最早的项，
+	// 被淘汰的令牌在过期前会重新被视为有效，应按撤销量与内存预算设置足够大的值
+	BlackListMaxSize int
+	// Logger 日志记录器（可选），用于记录后台黑名单清理协程中的错误与 panic；未设置时不输出任何日志
+	Logger Logger
+}
+
+// Logger 日志接口，可由 log.Logger 的包装、zap 的 SugaredLogger 等实现
+type Logger interface {
+	Errorf(format string, args ...any)
 }
 
+// nopLogger 默认的空日志实现
+type nopLogger struct{}
+
+func (nopLogger) Errorf(string, ...any) {}
+
 // DefaultAutherConfig 默认配置
 var DefaultAutherConfig = AutherConfig{
 	AccessTokenExp:           2 * time.Hour,
@@ -76,26 +127,63 @@ type Auther interface {
 	// 注意：此方法不接受 tokenType 参数，始终生成 AccessToken；禁止生成 RefreshToken。
 	MintAccessToken(ctx context.Context, userID, username, role string, exp time.Duration, metadata map[string]string) (*TokenInfo, error)
 
+	// MintAccessTokens 批量生成访问令牌（如批量开通服务账号），返回顺序与 reqs 一致；任一项失败时返回包含下标的错误
+	MintAccessTokens(ctx context.Context, reqs []MintRequest) ([]*TokenInfo, error)
+
+	// GenerateAccessOnly 仅生成访问令牌（使用 AccessTokenExp），以 TokenPair 形式返回便于与 GenerateTokenPair 统一处理。
+	// 返回值的 RefreshToken 为零值（Token 为空字符串），不可用于 RefreshTokenRotate；适用于服务间短期令牌。
+	GenerateAccessOnly(ctx context.Context, userID, username, role string, metadata map[string]string) (*TokenPair, error)
+
 	// ValidateToken 验证令牌
 	ValidateToken(ctx context.Context, token string) (*TokenClaims, error)
 
+	// ValidateTokenOfType 执行与 ValidateToken 相同的校验，并要求令牌类型为 expected，否则返回 ErrInvalidTokenType。
+	// 需要访问令牌的接口应使用该方法，避免误接受刷新令牌。
+	ValidateTokenOfType(ctx context.Context, token string, expected TokenType) (*TokenClaims, error)
+
 	// RefreshTokenRotate 刷新令牌旋转：
 	// 每次使用刷新令牌成功后，立即撤销旧刷新令牌并签发新的访问令牌与刷新令牌对，防止刷新令牌被重放。
-	// 注意：当 BlackListEnabled=false 时无法撤销旧刷新令牌，旋转仅会生成新的令牌对。
+	// 新令牌对沿用旧令牌的会话族（FamilyID）。已撤销的刷新令牌再次出现时视为重放，整个会话族随即被撤销，
+	// 返回的错误同时匹配 ErrTokenReuseDetected 与 ErrRevokedToken。
+	// 注意：当 BlackListEnabled=false 时无法撤销旧刷新令牌，旋转仅会生成新的令牌对，也无法检测重放。
 	RefreshTokenRotate(ctx context.Context, refreshToken string) (*TokenPair, error)
 
 	// RevokeToken 撤销令牌（加入黑名单）
 	RevokeToken(ctx context.Context, token string) error
 
+	// RevokeAllForUser 撤销指定用户此前签发的全部令牌（修改密码、账号被盗等场景）；未启用黑名单时为空操作
+	RevokeAllForUser(ctx context.Context, userID string) error
+
+	// RevokeFamily 撤销整个会话族（令牌 claims 中的 FamilyID，即 fid），该登录会话轮换链上的全部令牌立即失效；
+	// 未启用黑名单时为空操作
+	RevokeFamily(ctx context.Context, familyID string) error
+
 	// IsTokenRevoked 检查令牌是否被撤销
 	IsTokenRevoked(ctx context.Context, token string) (bool, error)
 
+	// BlacklistCountForUser 统计指定用户已撤销且尚未过期的令牌数量，用于滥用排查；未启用黑名单时返回 0
+	BlacklistCountForUser(ctx context.Context, userID string) (int, error)
+
+	// ExportBlackList 导出尚未过期的撤销记录（按令牌、RevokeAllForUser 与会话族撤销），用于服务关闭前持久化；未启用黑名单时返回空结果
+	ExportBlackList(ctx context.Context) (BlackListSnapshot, error)
+
+	// ImportBlackList 导入 ExportBlackList 得到的快照（跳过已过期记录），用于服务重启后恢复撤销状态；未启用黑名单时为空操作
+	ImportBlackList(ctx context.Context, snapshot BlackListSnapshot) error
+
 	// CleanupExpiredTokens 清理过期的黑名单令牌
 	CleanupExpiredTokens(ctx context.Context) error
 
 	// GetTokenInfo 从令牌中提取信息（不验证签名）
+	// 警告：返回的 claims 可被任意伪造，不得用于鉴权；需要可信 claims 时使用 GetTokenInfoVerified。
 	GetTokenInfo(token string) (*TokenClaims, error)
 
+	// GetTokenInfoVerified 验证签名后提取令牌信息，不校验过期、Issuer、Audience 与黑名单
+	GetTokenInfoVerified(token string) (*TokenClaims, error)
+
+	// GetTokenInfoValidated 完整校验令牌（与 ValidateToken 相同）后提取令牌信息；
+	// 返回的错误可用 errors.Is 匹配 ErrInvalidToken、ErrExpiredToken 或 ErrRevokedToken
+	GetTokenInfoValidated(ctx context.Context, token string) (*TokenClaims, error)
+
 	// Close 关闭后台资源（如黑名单清理协程）。
 	// 说明：若创建时未启用黑名单或未启动协程，Close 将安全地执行空操作；重复调用不会产生 panic。
 	Close() error
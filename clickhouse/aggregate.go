@@ -0,0 +1,152 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SumByKeySeparator 为 SumBy 结果键中多个分组值之间的分隔符。
+const SumByKeySeparator = "|"
+
+// SumBy 对 sumField 按 groupFields 分组求和（SELECT g1, g2, sum(f) AS total FROM t WHERE ... GROUP BY g1, g2），
+// 适用于 SummingMergeTree 等预聚合表的查询。表名与过滤条件通过 options 指定（如 WithTable、WithIn）。
+// 返回结果以分组值（按 groupFields 顺序、以 SumByKeySeparator 连接）为键；
+// 所有字段名均须通过格式校验，且至少指定一个分组字段，校验失败时返回验证错误且不执行查询。
+func SumBy(ctx context.Context, db *DB, sumField string, groupFields []string, options ...QueryOption) (map[string]float64, error) {
+	query, err := sumByQuery(ctx, db, sumField, groupFields, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.DB.Rows()
+	if err != nil {
+		return nil, NewQueryError("failed to execute sum query", err).
+			WithContext("sum_field", sumField).
+			WithCode("SUM_QUERY_FAILED")
+	}
+	defer rows.Close()
+
+	result := make(map[string]float64)
+	groups := make([]any, len(groupFields))
+	dest := make([]any, len(groupFields)+1)
+	for i := range groups {
+		dest[i] = &groups[i]
+	}
+	var total sql.NullFloat64
+	dest[len(groupFields)] = &total
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, NewQueryError("failed to scan sum query row", err).
+				WithCode("SUM_SCAN_FAILED")
+		}
+		keys := make([]string, len(groups))
+		for i, v := range groups {
+			keys[i] = groupValueString(v)
+		}
+		result[strings.Join(keys, SumByKeySeparator)] = total.Float64
+	}
+	if err := rows.Err(); err != nil {
+		return nil, NewQueryError("failed to iterate sum query rows", err).
+			WithCode("SUM_SCAN_FAILED")
+	}
+	return result, nil
+}
+
+// ScanInto 在 ctx 下应用 options 并以 Scan（而非 Find）执行查询，将结果写入 dest。
+// 适用于选择了聚合或计算列、无法直接映射到模型的查询：dest 可为任意 DTO 结构体（或其切片），
+// 列与字段通过 gorm:"column:..." 标签或默认的 snake_case 命名对应。表名需由 WithTable 等选项指定。
+// 错误映射：ctx 超时返回超时错误（SCAN_TIMEOUT），ctx 取消返回不可重试的查询错误（SCAN_CANCELED），
+// 选项写入的验证错误等 ClickHouseError 原样返回，其余驱动错误包装为查询错误（SCAN_FAILED）。
+func ScanInto(ctx context.Context, db *DB, dest any, options ...QueryOption) error {
+	if dest == nil {
+		return NewValidationError("scan destination cannot be nil", nil).
+			WithCode("SCAN_DEST_NIL")
+	}
+
+	query, err := OptionDBContext(ctx, db, options...)
+	if err != nil {
+		return err
+	}
+
+	err = query.DB.Scan(dest).Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewTimeoutError("scan query timed out", err).
+			WithCode("SCAN_TIMEOUT")
+	case errors.Is(err, context.Canceled):
+		return NewQueryError("scan query canceled", err).
+			WithCode("SCAN_CANCELED").
+			WithRetriable(false)
+	}
+	var chErr *ClickHouseError
+	if errors.As(err, &chErr) {
+		return err
+	}
+	return NewQueryError("failed to scan query result", err).
+		WithContext("dest_type", fmt.Sprintf("%T", dest)).
+		WithCode("SCAN_FAILED")
+}
+
+// sumByQuery 校验字段并在 ctx 下构造求和查询链，供 SumBy 执行及测试预览 SQL。
+// 通过 OptionDBContext 应用上下文，会话默认设置与 WithSettings、WithFinal 等选项附加的设置随上下文保留到执行。
+func sumByQuery(ctx context.Context, db *DB, sumField string, groupFields []string, options ...QueryOption) (*DB, error) {
+	if db == nil || db.DB == nil {
+		return nil, NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+
+	sf := strings.TrimSpace(sumField)
+	if sf == "" {
+		return nil, NewValidationError("sum field cannot be empty", nil).
+			WithCode("SUM_FIELD_EMPTY")
+	}
+	if err := validateFieldName(sf, nil); err != nil {
+		return nil, err
+	}
+
+	if len(groupFields) == 0 {
+		return nil, NewValidationError("at least one group field is required", nil).
+			WithCode("GROUP_FIELDS_EMPTY")
+	}
+	cols := make([]string, 0, len(groupFields))
+	for _, g := range groupFields {
+		f := strings.TrimSpace(g)
+		if f == "" {
+			return nil, NewValidationError("group field cannot be empty", nil).
+				WithCode("GROUP_FIELD_EMPTY")
+		}
+		if err := validateFieldName(f, nil); err != nil {
+			return nil, err
+		}
+		cols = append(cols, columnName(f))
+	}
+
+	updated, err := OptionDBContext(ctx, db, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	group := strings.Join(cols, ", ")
+	updated.DB = updated.DB.Select(group + ", sum(" + columnName(sf) + ") AS total").Group(group)
+	return updated, nil
+}
+
+// groupValueString 将扫描得到的分组值转换为结果键中的字符串形式。
+func groupValueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(val)
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,187 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestSumByQuerySQL 验证 SumBy 生成的 SELECT/GROUP BY 语句与过滤条件，以及非法字段被拒绝。
+func TestSumByQuerySQL(t *testing.T) {
+	db := newTestDB(t)
+	q, err := sumByQuery(context.Background(), db, "bytes", []string{"region", "host"},
+		WithTable("traffic"), WithIn("region", []string{"cn", "us"}))
+	if err != nil {
+		t.Fatalf("sumByQuery failed: %v", err)
+	}
+	sql := execFind(t, q).Statement.SQL.String()
+	for _, want := range []string{
+		"SELECT `region`, `host`, sum(`bytes`) AS total FROM `traffic`",
+		"WHERE `region` IN (?,?)",
+		"GROUP BY `region`, `host`",
+	} {
+		if !contains(sql, want) {
+			t.Fatalf("expected %q in SQL, got: %s", want, sql)
+		}
+	}
+
+	for _, c := range []struct {
+		sum    string
+		groups []string
+	}{
+		{sum: "", groups: []string{"region"}},
+		{sum: "bytes; DROP TABLE x", groups: []string{"region"}},
+		{sum: "bytes", groups: nil},
+		{sum: "bytes", groups: []string{"region", "host)"}},
+		{sum: "bytes", groups: []string{" "}},
+	} {
+		if _, err := sumByQuery(context.Background(), newTestDB(t), c.sum, c.groups, WithTable("traffic")); !IsValidationError(err) {
+			t.Errorf("sum=%q groups=%q: expected validation error, got %v", c.sum, c.groups, err)
+		}
+	}
+	if _, err := SumBy(context.Background(), nil, "bytes", []string{"region"}); !IsQueryError(err) {
+		t.Errorf("expected query error for nil db, got %v", err)
+	}
+}
+
+// TestSumBySQLite 在内存 SQLite 中写入数据，验证分组求和结果及结果键的拼接方式。
+func TestSumBySQLite(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := gdb.Exec("CREATE TABLE traffic (region TEXT, host TEXT, bytes INTEGER)").Error; err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	seed := `INSERT INTO traffic (region, host, bytes) VALUES
+		('cn', 'a', 10), ('cn', 'a', 5), ('cn', 'b', 7), ('us', 'a', 3), ('eu', 'c', 100)`
+	if err := gdb.Exec(seed).Error; err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+
+	got, err := SumBy(context.Background(), &DB{DB: gdb}, "bytes", []string{"region", "host"},
+		WithTable("traffic"), WithIn("region", []string{"cn", "us"}))
+	if err != nil {
+		t.Fatalf("SumBy failed: %v", err)
+	}
+	want := map[string]float64{"cn|a": 15, "cn|b": 7, "us|a": 3}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("key %q: expected %v, got %v", k, v, got[k])
+		}
+	}
+
+	byRegion, err := SumBy(context.Background(), &DB{DB: gdb}, "bytes", []string{"region"}, WithTable("traffic"))
+	if err != nil {
+		t.Fatalf("SumBy failed: %v", err)
+	}
+	if byRegion["cn"] != 22 || byRegion["eu"] != 100 {
+		t.Fatalf("unexpected region sums: %v", byRegion)
+	}
+}
+
+// TestScanIntoSQLite 在内存 SQLite 中执行聚合查询，验证结果按 column 标签写入自定义结构体及错误映射。
+func TestScanIntoSQLite(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := gdb.Exec("CREATE TABLE traffic (region TEXT, host TEXT, bytes INTEGER)").Error; err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	seed := `INSERT INTO traffic (region, host, bytes) VALUES
+		('cn', 'a', 10), ('cn', 'b', 5), ('us', 'a', 3), ('eu', 'c', 100)`
+	if err := gdb.Exec(seed).Error; err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+
+	type regionStat struct {
+		Region string `gorm:"column:region"`
+		Hits   int64  `gorm:"column:hits"`
+		Total  int64  `gorm:"column:total_bytes"`
+	}
+	aggregate := func(db *DB) *DB {
+		db.DB = db.DB.Select("region, count(*) AS hits, sum(bytes) AS total_bytes").Group("region").Order("region")
+		return db
+	}
+
+	var stats []regionStat
+	err = ScanInto(context.Background(), &DB{DB: gdb}, &stats,
+		WithTable("traffic"), WithIn("region", []string{"cn", "us"}), aggregate)
+	if err != nil {
+		t.Fatalf("ScanInto failed: %v", err)
+	}
+	want := []regionStat{{Region: "cn", Hits: 2, Total: 15}, {Region: "us", Hits: 1, Total: 3}}
+	if len(stats) != len(want) {
+		t.Fatalf("expected %v, got %v", want, stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], stats[i])
+		}
+	}
+
+	if err := ScanInto(context.Background(), nil, &stats); !IsQueryError(err) {
+		t.Errorf("expected query error for nil db, got %v", err)
+	}
+	if err := ScanInto(context.Background(), &DB{DB: gdb}, nil, WithTable("traffic")); !IsValidationError(err) {
+		t.Errorf("expected validation error for nil dest, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ScanInto(ctx, &DB{DB: gdb}, &stats, WithTable("traffic"), aggregate)
+	if !IsQueryError(err) || IsRetriableError(err) {
+		t.Errorf("expected non-retriable query error for canceled context, got %v", err)
+	}
+
+	err = ScanInto(context.Background(), &DB{DB: gdb}, &stats, WithTable("missing_table"), aggregate)
+	if !IsQueryError(err) {
+		t.Errorf("expected query error for missing table, got %v", err)
+	}
+}
+
+// TestSumByKeepsSettings 验证 SumBy 执行时语句上下文保留会话默认设置与查询级 WithFinal 设置。
+func TestSumByKeepsSettings(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := gdb.Exec("CREATE TABLE traffic (region TEXT, bytes INTEGER)").Error; err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	var native map[string]string
+	err = gdb.Callback().Row().Before("gorm:row").Register("test:capture_settings", func(tx *gorm.DB) {
+		native, _ = chContextOptions(t, tx.Statement.Context)
+	})
+	if err != nil {
+		t.Fatalf("failed to register capture callback: %v", err)
+	}
+
+	root := &DB{DB: applySessionDefaults(gdb, sessionDefaults(&Config{ForceIndexByDate: true}))}
+	if _, err := SumBy(context.Background(), root, "bytes", []string{"region"}, WithTable("traffic"), WithFinal(true)); err != nil {
+		t.Fatalf("SumBy failed: %v", err)
+	}
+	if native["final"] != "1" || native["force_index_by_date"] != "1" {
+		t.Fatalf("expected final and session default in executed statement context, got %#v", native)
+	}
+}
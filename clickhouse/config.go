@@ -3,10 +3,29 @@ package clickhouse
 import (
 	"fmt"
 	"net"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// tablePrefixPattern 限定表名前缀仅包含字母、数字与下划线，且不以数字开头
+var tablePrefixPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// DefaultConfig 返回预填充文档默认值的配置（端口 9000、连接池 100/100、生命周期 300 秒），
+// 调用方只需覆盖主机、账号、数据库名等差异项即可。
+func DefaultConfig() *Config {
+	return &Config{
+		Type:         "clickhouse",
+		Host:         "localhost",
+		Port:         "9000",
+		SSLMode:      "disable",
+		MaxLifetime:  300,
+		MaxOpenConns: 100,
+		MaxIdleConns: 100,
+	}
+}
+
 // Validate 验证配置参数的有效性
 func (c *Config) Validate() error {
 	if c == nil {
@@ -15,8 +34,14 @@ func (c *Config) Validate() error {
 
 	var errs []string
 
-	// 验证主机名
-	if c.Host == "" {
+	// 验证主机名；配置了 Hosts 时以 Hosts 为准，Host 可为空
+	if len(c.Hosts) > 0 {
+		for _, addr := range c.Hosts {
+			if err := validateHostAddr(addr); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	} else if c.Host == "" {
 		errs = append(errs, "host cannot be empty")
 	} else {
 		// 检查主机名格式
@@ -76,6 +101,25 @@ func (c *Config) Validate() error {
 		errs = append(errs, "MaxIdleConns should not be greater than MaxOpenConns")
 	}
 
+	if c.MaxIdleTime < 0 {
+		errs = append(errs, "MaxIdleTime cannot be negative")
+	}
+
+	for key := range c.Settings {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, "settings key cannot be empty")
+			break
+		}
+	}
+
+	if c.DefaultQueryTimeout < 0 {
+		errs = append(errs, "DefaultQueryTimeout cannot be negative")
+	}
+
+	if c.TablePrefix != "" && !tablePrefixPattern.MatchString(c.TablePrefix) {
+		errs = append(errs, fmt.Sprintf("invalid table prefix: %s", c.TablePrefix))
+	}
+
 	if len(errs) > 0 {
 		return NewConfigError(fmt.Sprintf("config validation failed: %s", strings.Join(errs, "; ")), nil).
 			WithContext("host", c.Host).
@@ -118,6 +162,22 @@ func isValidHostname(host string) bool {
 	return true
 }
 
+// validateHostAddr 检查 Hosts 中的单个地址，须为 host:port 形式，主机名与端口均需合法
+func validateHostAddr(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid host address %q: expected host:port", addr)
+	}
+	if host == "" || (net.ParseIP(host) == nil && !isValidHostname(host)) {
+		return fmt.Errorf("invalid host address %q: invalid host", addr)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid host address %q: port must be between 1 and 65535", addr)
+	}
+	return nil
+}
+
 // containsValidMode 检查SSL模式是否有效
 func containsValidMode(mode string, validModes []string) bool {
 	for _, validMode := range validModes {
@@ -136,6 +196,7 @@ func (c *Config) GetDSNInfo() map[string]interface{} {
 
 	return map[string]interface{}{
 		"host":           c.Host,
+		"hosts":          c.Hosts,
 		"port":           c.Port,
 		"database":       c.DBName,
 		"username":       c.Username,
@@ -148,18 +209,42 @@ func (c *Config) GetDSNInfo() map[string]interface{} {
 }
 
 type Config struct {
-	Debug        bool   // 是否开启调试模式，默认 false
-	AutoMigrate  bool   // 是否自动迁移数据库结构，默认 false
-	SSLMode      string // disable, require, verify-ca, verify-full
-	Type         string // 数据库类型，默认 clickhouse
-	Host         string // 数据库主机，默认 localhost
-	Port         string // 数据库端口，默认 9000
-	Username     string // 数据库用户名
-	Password     string // 数据库密码
-	DBName       string // 数据库名称
-	MaxLifetime  int    // 连接最大生命周期，默认 5 分钟
-	MaxOpenConns int    // 最大打开连接数，默认 100
-	MaxIdleConns int    // 最大空闲连接数，默认 100
-	Database     string // 数据库名称，兼容性字段
-	OpenDB       bool   // 是否使用标准库数据库驱动，默认 false
+	Debug         bool   // 是否开启调试模式，默认 false
+	AutoMigrate   bool   // 是否自动迁移数据库结构，默认 false
+	SSLMode       string // disable, require, verify-ca, verify-full
+	Type          string // 数据库类型，默认 clickhouse
+	Host          string // 数据库主机，默认 localhost
+	Port          string // 数据库端口，默认 9000
+	Username      string // 数据库用户名
+	Password      string // 数据库密码
+	DBName        string // 数据库名称
+	MaxLifetime   int    // 连接最大生命周期，默认 5 分钟
+	MaxOpenConns  int    // 最大打开连接数，默认 100
+	MaxIdleConns  int    // 最大空闲连接数，默认 100
+	MaxIdleTime   int    // 连接最大空闲时间（秒），超时的空闲连接会被回收，默认 0 表示不限制
+	TablePrefix   string // 表名前缀（如 "app_"），默认无前缀
+	SingularTable bool   // 是否使用单数表名（user 而非 users），默认 false
+	Database      string // 数据库名称，兼容性字段
+	OpenDB        bool   // 是否使用标准库数据库驱动，默认 false
+	// PingBeforeUse 执行语句前检出语句将使用的连接并先 Ping，失败时丢弃该连接、换新检出的连接重试一次，默认 false。
+	// 代价是每条语句额外 1 次网络往返（首次 Ping 失败时为 2 次）；database/sql 在驱动返回 driver.ErrBadConn 时
+	// 本身已会换连接重试，通常仅在驱动未报告 ErrBadConn 的场景下才需要开启。事务内语句不做检查。
+	PingBeforeUse bool
+	// DefaultFinal 为会话默认开启 final 设置，等价于对所有 ReplacingMergeTree 等引擎的表查询追加 FINAL，
+	// 读取到合并后的最终数据；代价是查询时需要额外合并数据块，读放大明显，默认 false。
+	DefaultFinal bool
+	// ForceIndexByDate 为会话默认开启 force_index_by_date，查询条件无法利用日期分区键时服务端直接拒绝执行，
+	// 防止误写的条件触发全表扫描；历史上不带日期条件的查询会因此报错，默认 false。
+	ForceIndexByDate bool
+	// CreateDatabaseIfNotExists 为 true 时，NewDB 先连接 default 库执行 CREATE DATABASE IF NOT EXISTS 创建目标库，
+	// 再连接目标库；适用于首次部署。数据库名须为合法标识符，且账号需具备建库权限，默认 false
+	CreateDatabaseIfNotExists bool
+	// Hosts 集群节点地址列表，每项为 host:port；非空时取代 Host/Port，驱动在节点不可用时切换到其他地址，默认为空
+	Hosts []string
+	// Settings 连接级 ClickHouse 设置项（如 max_memory_usage），仅在 OpenDB 方式下生效；
+	// 与默认的 max_execution_time: 60 合并，同名时以此处为准，键不能为空
+	Settings map[string]any
+	// DefaultQueryTimeout 默认查询超时，大于 0 时每次执行（查询、写入、原生 SQL 等）在上下文未设置截止时间的情况下
+	// 自动派生带该超时的子上下文，防止失控查询长期占用连接；传入的上下文已设置截止时间时以其为准，默认 0 表示不限制
+	DefaultQueryTimeout time.Duration
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package clickhouse
+
+import "testing"
+
+// TestDefaultConfig 验证 DefaultConfig 的默认值与 Validate 补全的默认值一致，且补全账号与库名后可通过校验。
+func TestDefaultConfig(t *testing.T) {
+	def := DefaultConfig()
+
+	// 仅填写必填项，由 Validate 补全其余默认值
+	filled := &Config{Host: "localhost", Username: "default", DBName: "default"}
+	if err := filled.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+	if def.Port != filled.Port || def.MaxLifetime != filled.MaxLifetime ||
+		def.MaxOpenConns != filled.MaxOpenConns || def.MaxIdleConns != filled.MaxIdleConns {
+		t.Fatalf("defaults mismatch: DefaultConfig=%+v Validate=%+v", def, filled)
+	}
+
+	def.Username, def.DBName = "default", "default"
+	if err := def.Validate(); err != nil {
+		t.Fatalf("DefaultConfig with credentials should validate: %v", err)
+	}
+	if DefaultConfig() == DefaultConfig() {
+		t.Fatal("DefaultConfig should return a new instance on each call")
+	}
+}
+
+// TestValidateTablePrefix 验证表名前缀仅允许标识符字符。
+func TestValidateTablePrefix(t *testing.T) {
+	for _, prefix := range []string{"", "app_", "_tmp", "T1_"} {
+		cfg := DefaultConfig()
+		cfg.Username, cfg.DBName, cfg.TablePrefix = "default", "default", prefix
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("prefix %q should be accepted: %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"1app_", "app-", "app_; DROP", "a.b_"} {
+		cfg := DefaultConfig()
+		cfg.Username, cfg.DBName, cfg.TablePrefix = "default", "default", prefix
+		if err := cfg.Validate(); !IsConfigError(err) {
+			t.Errorf("prefix %q should be rejected, got %v", prefix, err)
+		}
+	}
+}
+
+// TestValidateHosts 验证 Hosts 各项须为合法的 host:port，配置 Hosts 时 Host 可为空。
+func TestValidateHosts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Username, cfg.DBName, cfg.Host = "default", "default", ""
+	cfg.Hosts = []string{"ch1:9000", "10.0.0.2:9000", "[::1]:9440"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid hosts should be accepted: %v", err)
+	}
+
+	for _, addr := range []string{"ch1", "ch1:", ":9000", "ch1:abc", "ch1:70000", "ch_1:9000", "ch1:9000:1"} {
+		cfg := DefaultConfig()
+		cfg.Username, cfg.DBName = "default", "default"
+		cfg.Hosts = []string{"ch1:9000", addr}
+		if err := cfg.Validate(); !IsConfigError(err) {
+			t.Errorf("host address %q should be rejected, got %v", addr, err)
+		}
+	}
+}
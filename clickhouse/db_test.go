@@ -2,10 +2,16 @@ package clickhouse
 
 import (
     "context"
+    "database/sql"
+    "database/sql/driver"
     "errors"
+    "io"
+    "strings"
+    "sync"
     "testing"
     "time"
 
+    gormclickhouse "gorm.io/driver/clickhouse"
     "gorm.io/driver/sqlite"
     "gorm.io/gorm"
 )
@@ -32,6 +38,71 @@ func TestBuildDSN(t *testing.T) {
     }
 }
 
+// TestBuildOptionsHosts 验证 OpenDB 方式的驱动选项包含 Hosts 全部地址，未配置 Hosts 时回退为 Host:Port；
+// DSN 方式同样以逗号分隔列出全部地址。
+func TestBuildOptionsHosts(t *testing.T) {
+    cfg := &Config{
+        Username: "u",
+        Password: "p",
+        Host:     "127.0.0.1",
+        Port:     "9000",
+        Hosts:    []string{"ch1:9000", "ch2:9000", "ch3:9440"},
+    }
+    opts := buildOptions(cfg, "db1", nil)
+    if strings.Join(opts.Addr, ",") != "ch1:9000,ch2:9000,ch3:9440" {
+        t.Fatalf("expected all hosts in Addr, got %v", opts.Addr)
+    }
+    if opts.Auth.Database != "db1" || opts.Auth.Username != "u" {
+        t.Fatalf("unexpected auth: %+v", opts.Auth)
+    }
+    if dsn := buildDSN(cfg, "db1"); !contains(dsn, "@ch1:9000,ch2:9000,ch3:9440/db1?") {
+        t.Fatalf("dsn should list all hosts, got: %s", dsn)
+    }
+
+    cfg.Hosts = nil
+    opts = buildOptions(cfg, "db1", nil)
+    if len(opts.Addr) != 1 || opts.Addr[0] != "127.0.0.1:9000" {
+        t.Fatalf("expected Host:Port fallback, got %v", opts.Addr)
+    }
+
+    // verify-full 在多节点时不固定 ServerName
+    tlsConf, err := buildTLSConfig(&Config{SSLMode: "verify-full", Hosts: []string{"ch1:9440", "ch2:9440"}})
+    if err != nil || tlsConf.ServerName != "" {
+        t.Fatalf("expected TLS config without ServerName for multiple hosts, got %+v, %v", tlsConf, err)
+    }
+}
+
+// TestBuildOptionsSettings 验证 Config.Settings 合并到驱动选项，同名设置覆盖默认的 max_execution_time；
+// 空键在 Validate 中被拒绝。
+func TestBuildOptionsSettings(t *testing.T) {
+    cfg := &Config{Host: "127.0.0.1", Port: "9000"}
+    if got := buildOptions(cfg, "db1", nil).Settings["max_execution_time"]; got != 60 {
+        t.Fatalf("expected default max_execution_time=60, got %v", got)
+    }
+
+    cfg.Settings = map[string]any{
+        "max_memory_usage":   10000000000,
+        "max_execution_time": 120,
+    }
+    settings := buildOptions(cfg, "db1", nil).Settings
+    if settings["max_memory_usage"] != 10000000000 {
+        t.Fatalf("expected custom setting to be present, got %v", settings)
+    }
+    if settings["max_execution_time"] != 120 {
+        t.Fatalf("expected user max_execution_time to override default, got %v", settings["max_execution_time"])
+    }
+    if len(cfg.Settings) != 2 {
+        t.Fatalf("config settings should not be modified, got %v", cfg.Settings)
+    }
+
+    bad := DefaultConfig()
+    bad.Username, bad.DBName = "default", "default"
+    bad.Settings = map[string]any{" ": 1}
+    if err := bad.Validate(); !IsConfigError(err) {
+        t.Fatalf("expected config error for empty settings key, got %v", err)
+    }
+}
+
 // TestPingWithSQLite 使用 sqlite 内存数据库构造 *DB，并调用 Ping 验证方法逻辑。
 // 说明：此测试不依赖真实 ClickHouse，仅验证 Ping 的流程与返回值。
 func TestPingWithSQLite(t *testing.T) {
@@ -323,4 +394,346 @@ func TestPingCancellation(t *testing.T) {
     } else {
         t.Errorf("Expected cancellation or connection error, got: %v", err)
     }
-}
\ No newline at end of file
+}
+// errConnReset 模拟失效连接返回的错误，不包装 driver.ErrBadConn，database/sql 不会自动换连接重试。
+var errConnReset = errors.New("connection reset by peer")
+
+// staleConnector 模拟驱动：前 stale 个建立的连接已失效（Ping 与执行均返回 errConnReset），
+// 记录各连接的关闭与执行情况，用于验证执行前存活检查。
+type staleConnector struct {
+    mu     sync.Mutex
+    stale  int
+    opened int
+    closed []int
+    used   []int
+}
+
+func (c *staleConnector) Connect(context.Context) (driver.Conn, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.opened++
+    return &staleConn{c: c, id: c.opened, stale: c.opened <= c.stale}, nil
+}
+
+func (c *staleConnector) Driver() driver.Driver { return c }
+
+func (c *staleConnector) Open(string) (driver.Conn, error) { return c.Connect(context.Background()) }
+
+// snapshot 返回已关闭与执行过语句的连接编号。
+func (c *staleConnector) snapshot() (closed, used []int) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return append([]int(nil), c.closed...), append([]int(nil), c.used...)
+}
+
+// staleConn 模拟驱动连接，支持 Ping 与不经预编译的 Exec/Query。
+type staleConn struct {
+    c     *staleConnector
+    id    int
+    stale bool
+}
+
+func (s *staleConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("prepare not supported") }
+
+func (s *staleConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+func (s *staleConn) Close() error {
+    s.c.mu.Lock()
+    defer s.c.mu.Unlock()
+    s.c.closed = append(s.c.closed, s.id)
+    return nil
+}
+
+func (s *staleConn) Ping(context.Context) error {
+    if s.stale {
+        return errConnReset
+    }
+    return nil
+}
+
+func (s *staleConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+    if err := s.use(); err != nil {
+        return nil, err
+    }
+    return driver.RowsAffected(1), nil
+}
+
+func (s *staleConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+    if err := s.use(); err != nil {
+        return nil, err
+    }
+    return &oneRow{}, nil
+}
+
+// use 记录执行语句的连接，失效连接返回 errConnReset。
+func (s *staleConn) use() error {
+    if s.stale {
+        return errConnReset
+    }
+    s.c.mu.Lock()
+    defer s.c.mu.Unlock()
+    s.c.used = append(s.c.used, s.id)
+    return nil
+}
+
+// oneRow 仅包含一行一列（n = 1）的结果集。
+type oneRow struct{ done bool }
+
+func (r *oneRow) Columns() []string { return []string{"n"} }
+
+func (r *oneRow) Close() error { return nil }
+
+func (r *oneRow) Next(dest []driver.Value) error {
+    if r.done {
+        return io.EOF
+    }
+    r.done = true
+    dest[0] = int64(1)
+    return nil
+}
+
+// openStaleDB 返回以模拟驱动为连接池的 GORM 实例，连接池中预先放入一个失效的空闲连接（模拟服务端重启）。
+func openStaleDB(t *testing.T, stale int, pingBeforeUse bool) (*gorm.DB, *sql.DB, *staleConnector) {
+    t.Helper()
+    connector := &staleConnector{stale: stale}
+    sqlDB := sql.OpenDB(connector)
+    t.Cleanup(func() { _ = sqlDB.Close() })
+    conn, err := sqlDB.Conn(context.Background())
+    if err != nil {
+        t.Fatalf("failed to open connection: %v", err)
+    }
+    _ = conn.Close()
+
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite memory: %v", err)
+    }
+    if pingBeforeUse {
+        if err := registerPingBeforeUse(gdb); err != nil {
+            t.Fatalf("failed to register callbacks: %v", err)
+        }
+    }
+    gdb.ConnPool = sqlDB
+    gdb.Statement.ConnPool = sqlDB
+    return gdb, sqlDB, connector
+}
+
+// waitIdle 等待连接池中的连接全部归还。
+func waitIdle(t *testing.T, sqlDB *sql.DB) {
+    t.Helper()
+    deadline := time.Now().Add(time.Second)
+    for sqlDB.Stats().InUse != 0 {
+        if time.Now().After(deadline) {
+            t.Fatalf("expected all connections returned to the pool, in use: %d", sqlDB.Stats().InUse)
+        }
+        time.Sleep(time.Millisecond)
+    }
+}
+
+// TestPingBeforeUseStaleConn 验证检出的空闲连接已失效时，执行前检查丢弃该连接并在新检出的连接上执行语句（Exec 与 Row/Rows 路径），
+// 未开启检查时同一语句直接命中失效连接而失败。
+func TestPingBeforeUseStaleConn(t *testing.T) {
+    gdb, _, _ := openStaleDB(t, 1, false)
+    if err := gdb.Exec("INSERT INTO t VALUES (1)").Error; !errors.Is(err, errConnReset) {
+        t.Fatalf("expected stale connection error without ping-before-use, got: %v", err)
+    }
+
+    gdb, sqlDB, connector := openStaleDB(t, 1, true)
+    if err := gdb.Exec("INSERT INTO t VALUES (1)").Error; err != nil {
+        t.Fatalf("expected statement to succeed on a fresh connection, got: %v", err)
+    }
+    closed, used := connector.snapshot()
+    if len(closed) != 1 || closed[0] != 1 || len(used) != 1 || used[0] != 2 {
+        t.Fatalf("expected stale connection 1 discarded and statement run on connection 2, closed=%v used=%v", closed, used)
+    }
+    waitIdle(t, sqlDB)
+
+    gdb, sqlDB, connector = openStaleDB(t, 1, true)
+    var n int
+    if err := gdb.Raw("SELECT 1").Scan(&n).Error; err != nil || n != 1 {
+        t.Fatalf("expected query to succeed on a fresh connection, got n=%d err=%v", n, err)
+    }
+    if _, used := connector.snapshot(); len(used) != 1 || used[0] != 2 {
+        t.Fatalf("expected query run on connection 2, used=%v", used)
+    }
+    waitIdle(t, sqlDB)
+
+    // 新检出的连接同样失效时中止执行并返回连接错误
+    gdb, sqlDB, connector = openStaleDB(t, 2, true)
+    if err := gdb.Exec("INSERT INTO t VALUES (1)").Error; err == nil || !IsConnectionError(err) {
+        t.Fatalf("expected connection error, got: %v", err)
+    }
+    if _, used := connector.snapshot(); len(used) != 0 {
+        t.Fatalf("statement must not run after failed retry, used=%v", used)
+    }
+    waitIdle(t, sqlDB)
+}
+
+// recordingPool 记录连接池参数设置调用，用于验证 MaxIdleTime 被传递。
+type recordingPool struct {
+    idle, open        int
+    lifetime, idleTTL time.Duration
+}
+
+func (p *recordingPool) SetMaxIdleConns(n int)              { p.idle = n }
+func (p *recordingPool) SetMaxOpenConns(n int)              { p.open = n }
+func (p *recordingPool) SetConnMaxLifetime(d time.Duration) { p.lifetime = d }
+func (p *recordingPool) SetConnMaxIdleTime(d time.Duration) { p.idleTTL = d }
+
+// TestConfigureConnectionPoolIdleTime 验证 MaxIdleTime 以秒为单位设置到连接池，并可作用于真实的 *sql.DB。
+func TestConfigureConnectionPoolIdleTime(t *testing.T) {
+    cfg := &Config{MaxIdleConns: 5, MaxOpenConns: 10, MaxLifetime: 300, MaxIdleTime: 60}
+    p := &recordingPool{}
+    if err := configureConnectionPool(p, cfg); err != nil {
+        t.Fatalf("configureConnectionPool failed: %v", err)
+    }
+    if p.idleTTL != time.Minute || p.lifetime != 5*time.Minute || p.open != 10 || p.idle != 5 {
+        t.Fatalf("unexpected pool settings: %+v", p)
+    }
+
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    if err := configureConnectionPool(sqlDB, cfg); err != nil {
+        t.Fatalf("configureConnectionPool failed: %v", err)
+    }
+    if stats := sqlDB.Stats(); stats.MaxOpenConnections != 10 {
+        t.Fatalf("expected MaxOpenConnections=10, got %d", stats.MaxOpenConnections)
+    }
+}
+
+// namingProbe 用于验证命名策略的测试模型
+type namingProbe struct {
+    ID   uint
+    Name string
+}
+
+// TestNewGormConfigNaming 验证 TablePrefix 与 SingularTable 通过 NamingStrategy 作用于迁移生成的表名。
+func TestNewGormConfigNaming(t *testing.T) {
+    cases := []struct {
+        cfg   *Config
+        table string
+    }{
+        {cfg: &Config{}, table: "naming_probes"},
+        {cfg: &Config{SingularTable: true}, table: "naming_probe"},
+        {cfg: &Config{TablePrefix: "app_", SingularTable: true}, table: "app_naming_probe"},
+        {cfg: &Config{TablePrefix: "app_"}, table: "app_naming_probes"},
+    }
+    for _, c := range cases {
+        gdb, err := gorm.Open(sqlite.Open(":memory:"), newGormConfig(c.cfg))
+        if err != nil {
+            t.Fatalf("failed to open sqlite: %v", err)
+        }
+        sqlDB, err := gdb.DB()
+        if err != nil {
+            t.Fatalf("failed to get sql.DB: %v", err)
+        }
+        sqlDB.SetMaxOpenConns(1)
+        if err := gdb.AutoMigrate(&namingProbe{}); err != nil {
+            t.Fatalf("auto migrate failed: %v", err)
+        }
+        if !gdb.Migrator().HasTable(c.table) {
+            t.Errorf("expected table %q for config %+v", c.table, c.cfg)
+        }
+        sqlDB.Close()
+    }
+}
+
+// 编译期断言：*DB 实现 Database 接口
+var _ Database = (*DB)(nil)
+
+// TestDatabaseInterface 验证 Database 接口方法：Gorm 返回底层实例，Ping 在关闭前成功、关闭后失败；空实例返回错误。
+func TestDatabaseInterface(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    var d Database = &DB{DB: gdb}
+    if d.Gorm() != gdb {
+        t.Fatal("Gorm should return the underlying *gorm.DB")
+    }
+    if err := d.Ping(context.Background()); err != nil {
+        t.Fatalf("Ping failed: %v", err)
+    }
+    if err := d.Close(); err != nil {
+        t.Fatalf("Close failed: %v", err)
+    }
+    if err := d.Ping(context.Background()); err == nil {
+        t.Fatal("Ping should fail after Close")
+    }
+
+    var empty *DB
+    if empty.Gorm() != nil {
+        t.Fatal("Gorm on nil DB should return nil")
+    }
+    if err := empty.Close(); !IsConnectionError(err) {
+        t.Fatalf("Close on nil DB should fail, got: %v", err)
+    }
+}
+
+// TestEnsureDatabase 使用替换的打开函数验证：引导连接指向 default 库，并执行带引号的 CREATE DATABASE 语句；非法库名不建立连接。
+func TestEnsureDatabase(t *testing.T) {
+    cfg := &Config{Host: "127.0.0.1", Port: "9000", Username: "u", DBName: "analytics", Database: "analytics"}
+
+    var dsn, executed string
+    open := func(d gorm.Dialector, opts ...gorm.Option) (*gorm.DB, error) {
+        if cd, ok := d.(*gormclickhouse.Dialector); ok {
+            dsn = cd.DSN
+        }
+        gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+        if err != nil {
+            return nil, err
+        }
+        _ = gdb.Callback().Raw().After("gorm:raw").Register("test:capture", func(tx *gorm.DB) {
+            executed = tx.Statement.SQL.String()
+        })
+        return gdb, nil
+    }
+
+    if err := ensureDatabase(cfg, open); err != nil {
+        t.Fatalf("ensureDatabase failed: %v", err)
+    }
+    if !strings.Contains(dsn, "/default?") {
+        t.Fatalf("bootstrap connection should target default database, got DSN: %s", dsn)
+    }
+    if executed != "CREATE DATABASE IF NOT EXISTS `analytics`" {
+        t.Fatalf("unexpected create statement: %q", executed)
+    }
+    if cfg.DBName != "analytics" {
+        t.Fatalf("config should not be modified, got DBName %q", cfg.DBName)
+    }
+
+    opened := false
+    bad := &Config{Host: "127.0.0.1", Port: "9000", Username: "u", DBName: "x; DROP DATABASE y"}
+    err := ensureDatabase(bad, func(gorm.Dialector, ...gorm.Option) (*gorm.DB, error) {
+        opened = true
+        return nil, errors.New("unexpected open")
+    })
+    if !IsValidationError(err) || opened {
+        t.Fatalf("expected validation error without connecting, got err=%v opened=%v", err, opened)
+    }
+
+    // 仅设置兼容字段 Database 时同样使用该库名
+    executed = ""
+    compat := &Config{Host: "127.0.0.1", Port: "9000", Username: "u", Database: "legacy"}
+    if err := ensureDatabase(compat, open); err != nil {
+        t.Fatalf("ensureDatabase with Database only failed: %v", err)
+    }
+    if executed != "CREATE DATABASE IF NOT EXISTS `legacy`" {
+        t.Fatalf("unexpected create statement for Database-only config: %q", executed)
+    }
+
+    failed := errors.New("access denied")
+    err = ensureDatabase(cfg, func(gorm.Dialector, ...gorm.Option) (*gorm.DB, error) {
+        return nil, failed
+    })
+    if !IsConnectionError(err) || !errors.Is(err, failed) {
+        t.Fatalf("expected connection error wrapping cause, got: %v", err)
+    }
+}
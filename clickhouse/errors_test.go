@@ -342,7 +342,9 @@ func TestPingErrorHandling(t *testing.T) {
 				DB: nil, // 这里会导致错误
 			},
 			ctx: func() context.Context {
-				ctx, _ := context.WithTimeout(context.Background(), 1*time.Nanosecond)
+				// 在测试结束时释放定时器：若在闭包内 defer cancel，返回的上下文会变为 Canceled 而非超时
+				ctx, cancel := context.WithTimeout(context.Background(), 1*time.Nanosecond)
+				t.Cleanup(cancel)
 				return ctx
 			}(),
 			expectError: true,
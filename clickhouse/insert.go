@@ -0,0 +1,47 @@
+package clickhouse
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// defaultInsertChunkSize BatchInsert 未指定分块大小时每批写入的行数
+const defaultInsertChunkSize = 1000
+
+// BatchInsert 将 rows（结构体切片或其指针）按 chunkSize 分块，通过 GORM 的 CreateInBatches 批量写入 table，
+// 每块生成一条多行 INSERT，远快于逐行写入。chunkSize 小于等于 0 时按 1000 处理。
+// table 须通过表名校验，rows 不是切片时返回验证错误；rows 为空时不执行任何语句。
+// 写入失败时返回查询错误（BATCH_INSERT_FAILED），此前已写入的块不会回滚。
+func (d *DB) BatchInsert(ctx context.Context, table string, rows any, chunkSize int) error {
+	t := strings.TrimSpace(table)
+	if err := validateDDLTable(t); err != nil {
+		return err
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(rows))
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return NewValidationError("rows must be a slice", nil).
+			WithContext("type", reflect.TypeOf(rows)).
+			WithCode("ROWS_NOT_SLICE")
+	}
+	if v.Len() == 0 {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultInsertChunkSize
+	}
+
+	tx, err := OptionDBContext(ctx, d, WithTable(t))
+	if err != nil {
+		return err
+	}
+	if err := tx.DB.CreateInBatches(rows, chunkSize).Error; err != nil {
+		return NewQueryError("failed to batch insert rows", err).
+			WithContext("table", t).
+			WithContext("rows", v.Len()).
+			WithContext("chunk_size", chunkSize).
+			WithCode("BATCH_INSERT_FAILED")
+	}
+	return nil
+}
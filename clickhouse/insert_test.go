@@ -0,0 +1,82 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestBatchInsert 在内存 SQLite 中批量写入数百行，验证全部写入、INSERT 语句数量与分块数一致，以及参数校验。
+func TestBatchInsert(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := gdb.Exec("CREATE TABLE events (id INTEGER, name TEXT)").Error; err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	var inserts int
+	if err := gdb.Callback().Create().After("gorm:create").Register("test:count_inserts", func(tx *gorm.DB) {
+		inserts++
+	}); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	type event struct {
+		ID   int
+		Name string
+	}
+	rows := make([]event, 350)
+	for i := range rows {
+		rows[i] = event{ID: i + 1, Name: "e"}
+	}
+
+	db := &DB{DB: gdb}
+	if err := db.BatchInsert(context.Background(), "events", rows, 100); err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	if inserts != 4 {
+		t.Fatalf("expected 4 batches, got %d", inserts)
+	}
+	var count int64
+	if err := gdb.Table("events").Count(&count).Error; err != nil || count != 350 {
+		t.Fatalf("expected 350 rows, got %d (%v)", count, err)
+	}
+
+	// chunkSize <= 0 时使用默认分块大小，一次写入
+	inserts = 0
+	if err := db.BatchInsert(context.Background(), "events", &rows, 0); err != nil {
+		t.Fatalf("BatchInsert with default chunk size failed: %v", err)
+	}
+	if inserts != 1 {
+		t.Fatalf("expected 1 batch with default chunk size, got %d", inserts)
+	}
+
+	inserts = 0
+	if err := db.BatchInsert(context.Background(), "events", []event{}, 100); err != nil || inserts != 0 {
+		t.Fatalf("empty rows should be a no-op, got inserts=%d err=%v", inserts, err)
+	}
+	for _, table := range []string{"", "events; DROP TABLE events"} {
+		if err := db.BatchInsert(context.Background(), table, rows, 100); !IsValidationError(err) {
+			t.Errorf("table %q: expected validation error, got %v", table, err)
+		}
+	}
+	if err := db.BatchInsert(context.Background(), "events", event{ID: 1}, 100); !IsValidationError(err) {
+		t.Errorf("expected validation error for non-slice rows, got %v", err)
+	}
+	if err := db.BatchInsert(context.Background(), "missing_table", rows, 100); !IsQueryError(err) {
+		t.Errorf("expected query error for missing table, got %v", err)
+	}
+	if err := (*DB)(nil).BatchInsert(context.Background(), "events", rows, 100); !IsQueryError(err) {
+		t.Errorf("expected query error for nil db, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// migrationsTable 记录已执行迁移 ID 的表名。
+const migrationsTable = "schema_migrations"
+
+// migrationsTableDDL 创建迁移记录表的语句；使用 ReplacingMergeTree 以便重复写入同一 ID 时在合并后去重。
+var migrationsTableDDL = "CREATE TABLE IF NOT EXISTS " + migrationsTable +
+	" (id String, applied_at DateTime) ENGINE = ReplacingMergeTree ORDER BY id"
+
+// Migration 描述一条以原生 SQL 表达的迁移，用于 AutoMigrate 无法覆盖的场景（表引擎、分区键、跳数索引等）。
+type Migration struct {
+	ID string // 迁移唯一标识，建议使用有序前缀（如 0001_create_events）
+	Up string // 待执行的 SQL 语句，ClickHouse 不支持多语句，每条迁移仅包含一条语句
+}
+
+// RunMigrations 按顺序执行尚未应用的迁移，并将成功执行的 ID 记录到 schema_migrations 表中（不存在时自动创建）。
+// 说明：
+// 1) 重复调用时已记录的迁移会被跳过，任一迁移失败时立即返回错误，后续迁移不再执行；
+// 2) ClickHouse 不支持事务，迁移执行成功但记录写入失败时该迁移会在下次重试，Up 语句应尽量使用 IF NOT EXISTS 等幂等写法。
+func RunMigrations(ctx context.Context, db *DB, migrations []Migration) error {
+	if db == nil || db.DB == nil {
+		return NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+	if err := validateMigrations(migrations); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx := db.DB.WithContext(ctx)
+	if err := tx.Exec(migrationsTableDDL).Error; err != nil {
+		return NewQueryError("failed to create migrations table", err).
+			WithContext("table", migrationsTable).
+			WithCode("MIGRATION_TABLE_FAILED")
+	}
+
+	var applied []string
+	if err := tx.Table(migrationsTable).Pluck("id", &applied).Error; err != nil {
+		return NewQueryError("failed to load applied migrations", err).
+			WithContext("table", migrationsTable).
+			WithCode("MIGRATION_LOAD_FAILED")
+	}
+	done := make(map[string]struct{}, len(applied))
+	for _, id := range applied {
+		done[id] = struct{}{}
+	}
+
+	for _, m := range migrations {
+		if _, ok := done[m.ID]; ok {
+			continue
+		}
+		if err := tx.Exec(m.Up).Error; err != nil {
+			return NewQueryError(fmt.Sprintf("failed to apply migration %s", m.ID), err).
+				WithContext("migration_id", m.ID).
+				WithCode("MIGRATION_FAILED")
+		}
+		if err := tx.Exec("INSERT INTO "+migrationsTable+" (id, applied_at) VALUES (?, ?)", m.ID, time.Now()).Error; err != nil {
+			return NewQueryError(fmt.Sprintf("failed to record migration %s", m.ID), err).
+				WithContext("migration_id", m.ID).
+				WithCode("MIGRATION_RECORD_FAILED")
+		}
+	}
+	return nil
+}
+
+// validateMigrations 校验迁移 ID 非空且不重复、SQL 非空。
+func validateMigrations(migrations []Migration) error {
+	seen := make(map[string]struct{}, len(migrations))
+	for i, m := range migrations {
+		if strings.TrimSpace(m.ID) == "" {
+			return NewValidationError(fmt.Sprintf("migration at index %d has empty id", i), nil).
+				WithCode("MIGRATION_ID_EMPTY")
+		}
+		if _, ok := seen[m.ID]; ok {
+			return NewValidationError(fmt.Sprintf("duplicate migration id: %s", m.ID), nil).
+				WithContext("migration_id", m.ID).
+				WithCode("MIGRATION_ID_DUPLICATE")
+		}
+		seen[m.ID] = struct{}{}
+		if strings.TrimSpace(m.Up) == "" {
+			return NewValidationError(fmt.Sprintf("migration %s has empty SQL", m.ID), nil).
+				WithContext("migration_id", m.ID).
+				WithCode("MIGRATION_SQL_EMPTY")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestRunMigrations 重复应用两条迁移，验证每条迁移只执行一次并被记录。
+// 迁移记录表使用 sqlite 兼容的建表语句替换 ClickHouse 引擎语法。
+func TestRunMigrations(t *testing.T) {
+	orig := migrationsTableDDL
+	defer func() { migrationsTableDDL = orig }()
+	migrationsTableDDL = "CREATE TABLE IF NOT EXISTS " + migrationsTable + " (id VARCHAR(255) PRIMARY KEY, applied_at TIMESTAMP NOT NULL)"
+
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	// 内存库每个连接相互独立，限制为单连接以共享同一份数据
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	db := &DB{DB: gdb}
+
+	migrations := []Migration{
+		{ID: "0001_create_users", Up: "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"},
+		{ID: "0002_seed_users", Up: "INSERT INTO users (name) VALUES ('alice')"},
+	}
+	for i := 0; i < 2; i++ {
+		if err := RunMigrations(context.Background(), db, migrations); err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	var users, recorded int64
+	if err := db.DB.Table("users").Count(&users).Error; err != nil {
+		t.Fatalf("count users failed: %v", err)
+	}
+	if err := db.DB.Table(migrationsTable).Count(&recorded).Error; err != nil {
+		t.Fatalf("count migrations failed: %v", err)
+	}
+	if users != 1 || recorded != 2 {
+		t.Fatalf("expected each migration to run once, got users=%d recorded=%d", users, recorded)
+	}
+
+	// 失败的迁移不会被记录
+	bad := append(migrations, Migration{ID: "0003_bad", Up: "CREATE TABLE"})
+	if err := RunMigrations(context.Background(), db, bad); err == nil {
+		t.Fatal("expected error for invalid migration SQL")
+	}
+	if err := RunMigrations(context.Background(), db, []Migration{{ID: "x", Up: "SELECT 1"}, {ID: "x", Up: "SELECT 1"}}); !IsValidationError(err) {
+		t.Fatal("expected error for duplicate migration ids")
+	}
+}
@@ -0,0 +1,49 @@
+package clickhouse
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Paginate 在 ctx 下应用 options 后分两步执行分页查询：先在不带 LIMIT/OFFSET 的会话上执行 Count 得到满足条件的总行数，
+// 再按 page、pageSize 设置 LIMIT/OFFSET 执行 Find 将当前页写入 dest。
+// options 中误传的 WithLimit / WithOffset / WithPage 不会影响总数，并被 page、pageSize 覆盖。
+// page 须大于等于 1、pageSize 须大于 0，dest 不能为 nil，否则返回验证错误；Count 或 Find 失败时返回查询错误。
+func Paginate(ctx context.Context, db *DB, dest any, page, pageSize int, options ...QueryOption) (total int64, err error) {
+	if page < 1 {
+		return 0, NewValidationError("page must be greater than or equal to 1", nil).
+			WithContext("page", page).
+			WithCode("PAGE_INVALID")
+	}
+	if pageSize <= 0 {
+		return 0, NewValidationError("page size must be greater than 0", nil).
+			WithContext("page_size", pageSize).
+			WithCode("PAGE_SIZE_INVALID")
+	}
+	if dest == nil {
+		return 0, NewValidationError("paginate destination cannot be nil", nil).
+			WithCode("PAGINATE_DEST_NIL")
+	}
+
+	query, err := OptionDBContext(ctx, db, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	// Limit(-1)/Offset(-1) 取消选项中可能设置的 LIMIT/OFFSET，保证总数不受分页影响
+	if err := query.DB.Session(&gorm.Session{}).Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return 0, NewQueryError("failed to count rows for pagination", err).
+			WithCode("PAGINATE_COUNT_FAILED")
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.DB.Session(&gorm.Session{}).Limit(pageSize).Offset(offset).Find(dest).Error; err != nil {
+		return 0, NewQueryError("failed to query page", err).
+			WithContext("page", page).
+			WithContext("page_size", pageSize).
+			WithCode("PAGINATE_FIND_FAILED")
+	}
+
+	return total, nil
+}
@@ -0,0 +1,58 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// TestPaginate 验证 Count 语句不含 LIMIT/OFFSET（即使选项中设置了 WithLimit），Find 语句按页码设置 LIMIT/OFFSET，
+// 两者共享过滤条件；非法页码、页大小与 dest 返回验证错误。
+func TestPaginate(t *testing.T) {
+	db := newTestDB(t)
+	var sqls []string
+	if err := db.DB.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		sqls = append(sqls, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	var rows []map[string]any
+	_, err := Paginate(context.Background(), db, &rows, 3, 10,
+		WithTable("events"), WithStatus(1), WithLimit(5), OrderDesc("id", nil))
+	if err != nil {
+		t.Fatalf("Paginate failed: %v", err)
+	}
+	if len(sqls) != 2 {
+		t.Fatalf("expected count and find queries, got %d: %v", len(sqls), sqls)
+	}
+
+	count, find := sqls[0], sqls[1]
+	if !containsAll(count, []string{"SELECT count(*) FROM `events`", "WHERE status = ?"}) {
+		t.Fatalf("unexpected count query: %s", count)
+	}
+	if contains(count, "LIMIT") || contains(count, "OFFSET") {
+		t.Fatalf("count query should omit LIMIT/OFFSET, got: %s", count)
+	}
+	if !containsAll(find, []string{"FROM `events`", "WHERE status = ?", "ORDER BY `id` DESC", "LIMIT 10 OFFSET 20"}) {
+		t.Fatalf("unexpected find query: %s", find)
+	}
+
+	for _, c := range []struct {
+		page, size int
+		dest       any
+	}{
+		{page: 0, size: 10, dest: &rows},
+		{page: 1, size: 0, dest: &rows},
+		{page: 1, size: -5, dest: &rows},
+		{page: 1, size: 10, dest: nil},
+	} {
+		if _, err := Paginate(context.Background(), db, c.dest, c.page, c.size, WithTable("events")); !IsValidationError(err) {
+			t.Errorf("page=%d size=%d: expected validation error, got %v", c.page, c.size, err)
+		}
+	}
+	if _, err := Paginate(context.Background(), nil, &rows, 1, 10); !IsQueryError(err) {
+		t.Errorf("expected query error for nil db, got %v", err)
+	}
+}
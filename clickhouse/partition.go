@@ -0,0 +1,69 @@
+package clickhouse
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// partitionPattern 限定分区 ID 的字符集，覆盖 toYYYYMM、toDate 等常见分区表达式的取值（如 202401、2024-01-01）。
+var partitionPattern = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// DropPartition 删除指定表的一个分区（ALTER TABLE t DROP PARTITION ?），用于按分区整体清理过期的时序数据。
+// table 须通过表名校验；partition 须为非空、不超过 64 字符且仅包含字母、数字、下划线、连字符的分区值，
+// 并以参数形式绑定，不直接拼接到 SQL 中。任一校验失败时返回验证错误且不执行语句。
+func DropPartition(ctx context.Context, db *DB, table, partition string) error {
+	t := strings.TrimSpace(table)
+	if err := validateDDLTable(t); err != nil {
+		return err
+	}
+
+	p := strings.TrimSpace(partition)
+	if p == "" || len(p) > 64 || !partitionPattern.MatchString(p) {
+		return NewValidationError("invalid partition value", nil).
+			WithContext("partition", partition).
+			WithCode("PARTITION_INVALID")
+	}
+
+	return execDDL(ctx, db, "ALTER TABLE "+t+" DROP PARTITION ?", p)
+}
+
+// TruncateTable 清空指定表的全部数据（TRUNCATE TABLE t），表结构保留。
+// table 须通过表名校验，校验失败时返回验证错误且不执行语句。
+func TruncateTable(ctx context.Context, db *DB, table string) error {
+	t := strings.TrimSpace(table)
+	if err := validateDDLTable(t); err != nil {
+		return err
+	}
+
+	return execDDL(ctx, db, "TRUNCATE TABLE "+t)
+}
+
+// validateDDLTable 校验 DDL 目标表名：与 WithTable 不同，空表名同样视为错误。
+func validateDDLTable(table string) error {
+	if table == "" {
+		return NewValidationError("table name cannot be empty", nil).
+			WithCode("TABLE_NAME_EMPTY")
+	}
+	return validateTableName(table)
+}
+
+// execDDL 在给定上下文中执行 DDL 语句，并将执行失败包装为查询错误。
+// 通过 OptionDBContext 应用上下文，会话上已附加的 ClickHouse 设置随上下文保留到执行。
+func execDDL(ctx context.Context, db *DB, sql string, vars ...any) error {
+	if db == nil || db.DB == nil {
+		return NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+	query, err := OptionDBContext(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if err := query.DB.Exec(sql, vars...).Error; err != nil {
+		return NewQueryError("failed to execute DDL statement", err).
+			WithContext("sql", sql).
+			WithCode("DDL_EXEC_FAILED")
+	}
+	return nil
+}
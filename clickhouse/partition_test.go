@@ -0,0 +1,92 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// captureRawSQL 注册回调记录 Exec 生成的 SQL 与绑定参数（DryRun 下不会真正执行）。
+func captureRawSQL(t *testing.T, db *DB) (*string, *[]any) {
+	t.Helper()
+	var sql string
+	var vars []any
+	err := db.DB.Callback().Raw().After("gorm:raw").Register("test:capture_raw", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+		vars = append([]any(nil), tx.Statement.Vars...)
+	})
+	if err != nil {
+		t.Fatalf("failed to register capture callback: %v", err)
+	}
+	return &sql, &vars
+}
+
+// TestDropPartition 验证生成的分区删除 DDL 与分区值参数绑定，以及非法标识符被拒绝。
+func TestDropPartition(t *testing.T) {
+	db := newTestDB(t)
+	sql, vars := captureRawSQL(t, db)
+
+	if err := DropPartition(context.Background(), db, "events", "202401"); err != nil {
+		t.Fatalf("DropPartition should not return error: %v", err)
+	}
+	if *sql != "ALTER TABLE events DROP PARTITION ?" {
+		t.Fatalf("unexpected DDL: %s", *sql)
+	}
+	if len(*vars) != 1 || (*vars)[0] != "202401" {
+		t.Fatalf("expected vars [202401], got: %#v", *vars)
+	}
+
+	for _, tc := range []struct{ table, partition string }{
+		{"events; DROP TABLE users", "202401"},
+		{"", "202401"},
+		{"events", "2024' OR 1=1"},
+		{"events", ""},
+	} {
+		if err := DropPartition(context.Background(), db, tc.table, tc.partition); !IsValidationError(err) {
+			t.Errorf("expected validation error for %q/%q, got: %v", tc.table, tc.partition, err)
+		}
+	}
+}
+
+// TestTruncateTable 验证生成的 TRUNCATE 语句以及非法表名被拒绝。
+func TestTruncateTable(t *testing.T) {
+	db := newTestDB(t)
+	sql, _ := captureRawSQL(t, db)
+
+	if err := TruncateTable(context.Background(), db, "events"); err != nil {
+		t.Fatalf("TruncateTable should not return error: %v", err)
+	}
+	if *sql != "TRUNCATE TABLE events" {
+		t.Fatalf("unexpected DDL: %s", *sql)
+	}
+
+	if err := TruncateTable(context.Background(), db, "select"); !IsValidationError(err) {
+		t.Fatalf("expected validation error for keyword table, got: %v", err)
+	}
+	if err := TruncateTable(context.Background(), nil, "events"); !IsQueryError(err) {
+		t.Fatalf("expected query error for nil db, got: %v", err)
+	}
+}
+
+// TestExecDDLKeepsSettings 验证 DDL 辅助函数执行时语句上下文保留会话上附加的 ClickHouse 设置。
+func TestExecDDLKeepsSettings(t *testing.T) {
+	db, err := OptionDB(newTestDB(t), WithSettings(map[string]any{"mutations_sync": 2}))
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+	var native map[string]string
+	err = db.DB.Callback().Raw().Before("gorm:raw").Register("test:capture_settings", func(tx *gorm.DB) {
+		native, _ = chContextOptions(t, tx.Statement.Context)
+	})
+	if err != nil {
+		t.Fatalf("failed to register capture callback: %v", err)
+	}
+
+	if err := TruncateTable(context.Background(), db, "events"); err != nil {
+		t.Fatalf("TruncateTable should not return error: %v", err)
+	}
+	if native["mutations_sync"] != "2" {
+		t.Fatalf("expected mutations_sync in executed statement context, got %#v", native)
+	}
+}
@@ -1,10 +1,15 @@
 package clickhouse
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"unicode"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+	"gorm.io/gorm"
 )
 
 // QueryOption 定义对 *DB 进行链式包装的函数类型，返回经变更后的 *DB，便于组合多个查询配置。
@@ -26,6 +31,39 @@ func OptionDB(db *DB, options ...QueryOption) (*DB, error) {
 			WithCode("GORM_DB_NIL")
 	}
 
+	return applyOptions(db, options)
+}
+
+// OptionDBContext 与 OptionDB 相同，但先将 ctx 绑定到查询会话，用于设置单次查询的超时、取消或传递链路追踪信息。
+// 说明：
+// 1) 基于 db 派生新的 *DB，不修改传入的 db，因此可直接传入长期持有的根实例而不会残留请求级上下文；
+// 2) 会话上已附加的 ClickHouse 设置（如 Config.DefaultFinal 等会话默认值）会重新注入到新上下文，不会因替换上下文而丢失；
+//    此时 ctx 中经 WithCHContext 附加的查询参数保留，但设置项以会话设置为准，查询级设置请使用 WithSettings 等选项；
+// 3) ctx 为 nil 时按 context.Background() 处理。
+// OptionDB 不替换上下文，保留调用方此前通过 db.WithContext 设置的上下文。
+func OptionDBContext(ctx context.Context, db *DB, options ...QueryOption) (*DB, error) {
+	if db == nil {
+		return nil, NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+
+	if db.DB == nil {
+		return nil, NewQueryError("gorm database instance cannot be nil", nil).
+			WithCode("GORM_DB_NIL")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if settings := QuerySettings(db); len(settings) > 0 {
+		ctx = ch.Context(ctx, ch.WithSettings(ch.Settings(settings)))
+	}
+
+	return applyOptions(&DB{DB: db.DB.WithContext(ctx), autoMigrate: db.autoMigrate}, options)
+}
+
+// applyOptions 按序应用选项，跳过 nil 选项并捕获选项中的 panic
+func applyOptions(db *DB, options []QueryOption) (*DB, error) {
 	var lastError error
 	for i, option := range options {
 		if option == nil {
@@ -73,6 +111,40 @@ func OptionDBMust(db *DB, options ...QueryOption) *DB {
 	return result
 }
 
+// PreviewSQL 以 DryRun 方式应用选项并构建 SELECT 语句，返回生成的 SQL 与绑定参数，不会访问数据库。
+// 选项应用失败（含 panic）、构建过程 panic 或 Statement 为空时返回查询错误而不是崩溃，便于日志与测试中安全使用。
+func PreviewSQL(db *DB, options ...QueryOption) (sql string, vars []any, err error) {
+	updated, err := OptionDB(db, options...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			sql, vars = "", nil
+			err = NewQueryError("panic building SQL preview", fmt.Errorf("panic: %v", r)).
+				WithCode("PREVIEW_PANIC")
+		}
+	}()
+
+	if updated.DB.Statement == nil {
+		return "", nil, NewQueryError("gorm statement is nil", nil).
+			WithCode("STATEMENT_NIL")
+	}
+
+	tx := updated.DB.Session(&gorm.Session{DryRun: true}).Find(&[]struct{}{})
+	if tx == nil || tx.Statement == nil {
+		return "", nil, NewQueryError("gorm statement is nil after building SQL", nil).
+			WithCode("STATEMENT_NIL")
+	}
+	if tx.Error != nil {
+		return "", nil, NewQueryError("failed to build SQL preview", tx.Error).
+			WithCode("PREVIEW_FAILED")
+	}
+
+	return tx.Statement.SQL.String(), append([]any(nil), tx.Statement.Vars...), nil
+}
+
 // WithTable 设置查询所使用的表名。
 // 注意：tableName 需来源于受控白名单以防止 SQL 注入，这里进行更严格的验证。
 func WithTable(tableName string) QueryOption {
@@ -90,6 +162,7 @@ func WithTable(tableName string) QueryOption {
 		}
 
 		db.DB = db.DB.Table(t)
+		recordOption(db, "WithTable", t)
 		return db
 	}
 }
@@ -162,6 +235,7 @@ func WithId(id string) QueryOption {
 		}
 
 		db.DB = db.DB.Where("id = ?", trimmedId)
+		recordOption(db, "WithId")
 		return db
 	}
 }
@@ -195,23 +269,37 @@ func containsControlCharacters(s string) bool {
 }
 
 // WithUserName 按 username 追加 WHERE 条件（username = ?）。当 username 为空或仅包含空白时忽略该条件。
+// 与 WithId 相同，超长或包含控制字符的值会被忽略。
 func WithUserName(username string) QueryOption {
 	return func(db *DB) *DB {
 		if strings.TrimSpace(username) == "" {
 			return db
 		}
+
+		// 验证用户名的安全性
+		if err := validateID(username); err != nil {
+			return db
+		}
 		db.DB = db.DB.Where("username = ?", username)
+		recordOption(db, "WithUserName")
 		return db
 	}
 }
 
 // WithName 按 name 追加 WHERE 条件（name = ?）。当 name 为空或仅包含空白时忽略该条件。
+// 与 WithId 相同，超长或包含控制字符的值会被忽略。
 func WithName(name string) QueryOption {
 	return func(db *DB) *DB {
 		if strings.TrimSpace(name) == "" {
 			return db
 		}
+
+		// 验证名称的安全性
+		if err := validateID(name); err != nil {
+			return db
+		}
 		db.DB = db.DB.Where("name = ?", name)
+		recordOption(db, "WithName")
 		return db
 	}
 }
@@ -224,6 +312,158 @@ func WithStatus(status int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("status = ?", status)
+		recordOption(db, "WithStatus")
+		return db
+	}
+}
+
+// WithTransformedEq 对绑定值应用 transform 后追加等值条件（field = ?）。
+// 用于集中处理规范化存储的列（如小写邮箱、去空白的名称），保证查询值与存储值一致。
+// field 须通过字段名校验（含白名单）；transform 为 nil 时按原值绑定。
+func WithTransformedEq(field string, value string, transform func(string) string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		v := value
+		if transform != nil {
+			v = transform(v)
+		}
+		db.DB = db.DB.Where(columnName(f)+" = ?", v)
+		recordOption(db, "WithTransformedEq", f)
+		return db
+	}
+}
+
+// WithMapValueEq 按 Map 列中指定键的值追加等值条件（field[?] = ?），如 attrs['region'] = 'cn'。
+// field 须通过字段名校验（含白名单）；key 作为参数绑定而非拼接到 SQL 中，因此即使包含引号等字符也不会造成注入，
+// 但仍会拒绝空键、超长键以及包含控制字符的键。
+func WithMapValueEq(field, key string, value any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || key == "" {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		// 验证 Map 键的安全性
+		if err := validateID(key); err != nil {
+			return db
+		}
+
+		db.DB = db.DB.Where(columnName(f)+"[?] = ?", key, value)
+		recordOption(db, "WithMapValueEq", f)
+		return db
+	}
+}
+
+// WithSelect 限定查询返回的列，避免宽表上的 SELECT * 读取全部列。
+// 每个列名须通过字段名校验（含白名单），不合法或为空白的列被丢弃而不影响其余列；没有合法列时忽略该选项。
+// 与 SQL 关键字同名的列（如 order）自动加引号。
+func WithSelect(columns []string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		validColumns := make([]string, 0, len(columns))
+		for _, column := range columns {
+			f := strings.TrimSpace(column)
+			if f == "" {
+				continue
+			}
+			if err := validateFieldName(f, whitelist); err != nil {
+				continue
+			}
+			validColumns = append(validColumns, columnName(f))
+		}
+		if len(validColumns) == 0 {
+			return db
+		}
+
+		db.DB = db.DB.Select(validColumns)
+		recordOption(db, "WithSelect", strings.Join(validColumns, ","))
+		return db
+	}
+}
+
+// timeBucketFuncs 允许的时间分桶粒度及其对应的 ClickHouse 函数。
+var timeBucketFuncs = map[string]string{
+	"minute": "toStartOfMinute",
+	"hour":   "toStartOfHour",
+	"day":    "toStartOfDay",
+}
+
+// WithTimeBucket 按时间粒度分桶聚合：SELECT toStartOf<Interval>(tsField) AS bucket ... GROUP BY toStartOf<Interval>(tsField)。
+// tsField 须通过字段名校验（含白名单）；interval 仅允许 minute、hour、day（不区分大小写），其他取值忽略该选项。
+// 注意：该选项会设置 SELECT 列表，如需同时返回聚合值，请在其后通过原生 Select 追加。
+func WithTimeBucket(tsField, interval string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(tsField)
+		if f == "" {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		fn, ok := timeBucketFuncs[strings.ToLower(strings.TrimSpace(interval))]
+		if !ok {
+			return db
+		}
+
+		expr := fn + "(" + columnName(f) + ")"
+		db.DB = db.DB.Select(expr + " AS bucket").Group(expr)
+		recordOption(db, "WithTimeBucket", f)
+		return db
+	}
+}
+
+// WithGroupBy 追加 GROUP BY 分组字段。
+// 每个字段须通过字段名校验（含白名单），不合法或为空白的字段被丢弃而不影响其余字段；没有合法字段时忽略该选项。
+func WithGroupBy(fields []string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		cols := make([]string, 0, len(fields))
+		for _, field := range fields {
+			f := strings.TrimSpace(field)
+			if f == "" {
+				continue
+			}
+			if err := validateFieldName(f, whitelist); err != nil {
+				continue
+			}
+			cols = append(cols, columnName(f))
+		}
+		if len(cols) == 0 {
+			return db
+		}
+
+		db.DB = db.DB.Group(strings.Join(cols, ", "))
+		recordOption(db, "WithGroupBy", strings.Join(cols, ","))
+		return db
+	}
+}
+
+// WithHaving 追加 HAVING 条件，condition 与 args 原样转发给 GORM 的 Having，值通过 args 参数绑定。
+// condition 会直接拼接到 SQL 中，只能使用代码中固定的表达式（如 "sum(bytes) > ?"），不得包含用户输入；为空白时忽略该选项。
+func WithHaving(condition string, args ...any) QueryOption {
+	return func(db *DB) *DB {
+		c := strings.TrimSpace(condition)
+		if c == "" {
+			return db
+		}
+
+		db.DB = db.DB.Having(c, args...)
+		recordOption(db, "WithHaving")
 		return db
 	}
 }
@@ -237,6 +477,7 @@ func WithOffset(offset int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Offset(offset)
+		recordOption(db, "WithOffset")
 		return db
 	}
 }
@@ -250,6 +491,23 @@ func WithLimit(limit int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Limit(limit)
+		recordOption(db, "WithLimit")
+		return db
+	}
+}
+
+// WithPage 按页码设置 LIMIT/OFFSET：offset = (page-1)*pageSize，LIMIT 为 pageSize。
+// page 小于 1 时按第 1 页处理；pageSize 小于等于 0 时忽略该选项。
+func WithPage(page, pageSize int) QueryOption {
+	return func(db *DB) *DB {
+		if pageSize <= 0 {
+			return db
+		}
+		if page < 1 {
+			page = 1
+		}
+		db.DB = db.DB.Limit(pageSize).Offset((page - 1) * pageSize)
+		recordOption(db, "WithPage")
 		return db
 	}
 }
@@ -269,7 +527,8 @@ func OrderAsc(field string, whitelist map[string]struct{}) QueryOption {
 			return db
 		}
 
-		db.DB = db.DB.Order(f + " ASC")
+		db.DB = db.DB.Order(columnName(f) + " ASC")
+		recordOption(db, "OrderAsc", f)
 		return db
 	}
 }
@@ -289,7 +548,8 @@ func OrderDesc(field string, whitelist map[string]struct{}) QueryOption {
 			return db
 		}
 
-		db.DB = db.DB.Order(f + " DESC")
+		db.DB = db.DB.Order(columnName(f) + " DESC")
+		recordOption(db, "OrderDesc", f)
 		return db
 	}
 }
@@ -329,6 +589,31 @@ func validateFieldName(field string, whitelist map[string]struct{}) error {
 	return nil
 }
 
+// QuoteIdentifier 校验标识符后使用 ClickHouse 方言的反引号包裹（如 `order`）。
+// 仅在通过 validateFieldName 的格式校验后才进行包裹，校验失败返回验证错误，避免借引号注入。
+func QuoteIdentifier(name string) (string, error) {
+	n := strings.TrimSpace(name)
+	if n == "" {
+		return "", NewValidationError("identifier cannot be empty", nil).
+			WithCode("IDENTIFIER_EMPTY")
+	}
+	if err := validateFieldName(n, nil); err != nil {
+		return "", err
+	}
+	return "`" + n + "`", nil
+}
+
+// columnName 返回拼接到条件中的列名：通过 validateFieldName 格式校验的列名一律加引号，
+// 无需维护关键字列表即可安全使用 order、user、default 等列名。
+// 未通过格式校验的列名（如 t.id）原样返回，由调用方的既有校验逻辑决定是否使用。
+func columnName(field string) string {
+	quoted, err := QuoteIdentifier(field)
+	if err != nil {
+		return field
+	}
+	return quoted
+}
+
 // WithIn 构建一个通用的 IN 条件（如 field IN ?），当 values 为空时忽略该条件。
 // 参数 field 必须是已知安全的列名（建议调用前进行白名单校验）。此处不对 values 做深度清洗，交由 GORM 预处理。
 func WithIn(field string, values any) QueryOption {
@@ -350,11 +635,270 @@ func WithIn(field string, values any) QueryOption {
 		default:
 			// 其他类型直接交给 GORM 处理，但一般建议限制到常用类型
 		}
-		db.DB = db.DB.Where(field+" IN ?", values)
+		db.DB = db.DB.Where(columnName(field)+" IN ?", values)
+		recordOption(db, "WithIn", field)
 		return db
 	}
 }
 
+// WithNotIn 构建一个通用的 NOT IN 条件（如 field NOT IN ?），用于排除列表；values 为 nil 或空切片时忽略该条件
+// （避免生成 NOT IN (NULL) 而匹配不到任何行）。
+// 参数 field 必须是已知安全的列名（建议调用前进行白名单校验），与 WithIn 的约定一致。
+func WithNotIn(field string, values any) QueryOption {
+	return func(db *DB) *DB {
+		// 仅在 values 为非空切片时才应用条件
+		switch v := values.(type) {
+		case nil:
+			return db
+		case []string:
+			if len(v) == 0 {
+				return db
+			}
+		case []int:
+			if len(v) == 0 {
+				return db
+			}
+		case []int64:
+			if len(v) == 0 {
+				return db
+			}
+		default:
+			// 其他类型直接交给 GORM 处理，但一般建议限制到常用类型
+		}
+		db.DB = db.DB.Where(columnName(field)+" NOT IN ?", values)
+		recordOption(db, "WithNotIn", field)
+		return db
+	}
+}
+
+// WithInAnyColumn 在多列上匹配同一组值：(f1 IN ? OR f2 IN ? ...)，用于值可能存放在任一标识列中的场景，
+// 如 (id IN (...) OR external_id IN (...))。
+// 规则：
+// - 每个字段须通过字段名校验（含白名单），不合法或为空白的字段被丢弃；没有合法字段时忽略该选项；
+// - values 为 nil 或空切片时忽略该选项；每个 IN 子句分别绑定同一组值。
+func WithInAnyColumn(fields []string, values any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		rv := reflect.ValueOf(values)
+		if !rv.IsValid() || (rv.Kind() == reflect.Slice && rv.Len() == 0) {
+			return db
+		}
+
+		conds := make([]string, 0, len(fields))
+		cols := make([]string, 0, len(fields))
+		for _, field := range fields {
+			f := strings.TrimSpace(field)
+			if f == "" {
+				continue
+			}
+			if err := validateFieldName(f, whitelist); err != nil {
+				continue
+			}
+			conds = append(conds, columnName(f)+" IN ?")
+			cols = append(cols, columnName(f))
+		}
+		if len(conds) == 0 {
+			return db
+		}
+
+		vars := make([]any, len(conds))
+		for i := range vars {
+			vars[i] = values
+		}
+		db.DB = db.DB.Where("("+strings.Join(conds, " OR ")+")", vars...)
+		recordOption(db, "WithInAnyColumn", strings.Join(cols, ","))
+		return db
+	}
+}
+
+// WithTupleIn 按行值匹配多列组合（(a, b) IN ((?, ?), (?, ?))），如 (tenant_id, user_id) IN ((1, 'x'), (2, 'y'))。
+// 规则：
+// - 每个字段须通过字段名校验（含白名单），任一字段不合法或 fields 为空时忽略该选项；
+// - tuples 为空时忽略该选项；所有值均作为参数绑定；
+// - 任一元组的元素个数与 fields 不一致时向会话写入验证错误，执行时返回该错误，避免静默放宽查询条件。
+func WithTupleIn(fields []string, tuples [][]any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		if len(fields) == 0 || len(tuples) == 0 {
+			return db
+		}
+
+		// 验证字段名安全性
+		cols := make([]string, len(fields))
+		for i, field := range fields {
+			f := strings.TrimSpace(field)
+			if f == "" {
+				return db
+			}
+			if err := validateFieldName(f, whitelist); err != nil {
+				return db
+			}
+			cols[i] = columnName(f)
+		}
+
+		placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+		groups := make([]string, len(tuples))
+		vars := make([]any, 0, len(tuples)*len(cols))
+		for i, tuple := range tuples {
+			if len(tuple) != len(cols) {
+				db.DB = db.DB.Session(&gorm.Session{})
+				_ = db.DB.AddError(NewValidationError("tuple arity does not match field count", nil).
+					WithContext("tuple_index", i).
+					WithContext("expected", len(cols)).
+					WithContext("actual", len(tuple)).
+					WithCode("TUPLE_ARITY_MISMATCH"))
+				return db
+			}
+			groups[i] = placeholder
+			vars = append(vars, tuple...)
+		}
+
+		db.DB = db.DB.Where("("+strings.Join(cols, ", ")+") IN ("+strings.Join(groups, ", ")+")", vars...)
+		recordOption(db, "WithTupleIn", strings.Join(cols, ","))
+		return db
+	}
+}
+
+// WithBetween 追加闭区间范围条件（field BETWEEN ? AND ?），适用于数值与时间列。
+// 规则：
+// - field 须通过字段名校验（含白名单），不合法或为空时忽略该选项；
+// - low 与 high 均为 nil 时忽略该选项；仅一端为 nil 时退化为单边条件（field >= ? 或 field <= ?）。
+func WithBetween(field string, low, high any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || (low == nil && high == nil) {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		col := columnName(f)
+		switch {
+		case low == nil:
+			db.DB = db.DB.Where(col+" <= ?", high)
+		case high == nil:
+			db.DB = db.DB.Where(col+" >= ?", low)
+		default:
+			db.DB = db.DB.Where(col+" BETWEEN ? AND ?", low, high)
+		}
+		recordOption(db, "WithBetween", f)
+		return db
+	}
+}
+
+// WithGt 追加大于条件（field > ?），value 为 nil 时忽略该选项；field 须通过字段名校验（含白名单）。
+func WithGt(field string, value any, whitelist map[string]struct{}) QueryOption {
+	return compareOption("WithGt", field, ">", value, whitelist)
+}
+
+// WithGte 追加大于等于条件（field >= ?），规则同 WithGt。
+func WithGte(field string, value any, whitelist map[string]struct{}) QueryOption {
+	return compareOption("WithGte", field, ">=", value, whitelist)
+}
+
+// WithLt 追加小于条件（field < ?），规则同 WithGt。
+func WithLt(field string, value any, whitelist map[string]struct{}) QueryOption {
+	return compareOption("WithLt", field, "<", value, whitelist)
+}
+
+// WithLte 追加小于等于条件（field <= ?），规则同 WithGt。
+func WithLte(field string, value any, whitelist map[string]struct{}) QueryOption {
+	return compareOption("WithLte", field, "<=", value, whitelist)
+}
+
+// compareOption 构建单值比较条件，op 由调用方固定传入，不接受外部输入
+func compareOption(name, field, op string, value any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || value == nil {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		db.DB = db.DB.Where(columnName(f)+" "+op+" ?", value)
+		recordOption(db, name, f)
+		return db
+	}
+}
+
+// WithNull 追加 field IS NULL 条件，用于筛选 Nullable 列的空值；不绑定参数。
+// field 须通过字段名校验（含白名单），为空白或校验失败时忽略该选项。
+func WithNull(field string, whitelist map[string]struct{}) QueryOption {
+	return nullOption("WithNull", field, "IS NULL", whitelist)
+}
+
+// WithNotNull 追加 field IS NOT NULL 条件，规则同 WithNull。
+func WithNotNull(field string, whitelist map[string]struct{}) QueryOption {
+	return nullOption("WithNotNull", field, "IS NOT NULL", whitelist)
+}
+
+// nullOption 构造 IS NULL / IS NOT NULL 条件选项，name 用于审计记录。
+func nullOption(name, field, predicate string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" {
+			return db
+		}
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		db.DB = db.DB.Where(columnName(f) + " " + predicate)
+		recordOption(db, name, f)
+		return db
+	}
+}
+
+// WithLike 追加包含匹配条件（field LIKE ?，绑定值为 %pattern%），用于搜索框等模糊查询。
+// pattern 中的 %、_ 与 \ 会被转义为字面量，调用方无法借此注入通配符；field 须通过字段名校验（含白名单），
+// 不合法、为空或 pattern 去除首尾空白后为空时忽略该选项。
+func WithLike(field, pattern string, whitelist map[string]struct{}) QueryOption {
+	return likeOption("WithLike", field, pattern, whitelist, func(escaped string) string {
+		return "%" + escaped + "%"
+	})
+}
+
+// WithPrefix 追加前缀匹配条件（field LIKE ?，绑定值为 prefix%），转义与忽略规则同 WithLike。
+// 前缀匹配可利用主键（排序键）的前缀索引，大表上优先于 WithLike。
+func WithPrefix(field, prefix string, whitelist map[string]struct{}) QueryOption {
+	return likeOption("WithPrefix", field, prefix, whitelist, func(escaped string) string {
+		return escaped + "%"
+	})
+}
+
+// likeOption 构建 LIKE 条件：校验字段、转义模式中的通配符后由 wrap 追加通配符
+func likeOption(name, field, pattern string, whitelist map[string]struct{}, wrap func(string) string) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		p := strings.TrimSpace(pattern)
+		if f == "" || p == "" {
+			return db
+		}
+
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
+			return db
+		}
+
+		db.DB = db.DB.Where(columnName(f)+" LIKE ?", wrap(escapeLike(p)))
+		recordOption(db, name, f)
+		return db
+	}
+}
+
+// likeEscaper 转义 LIKE 元字符，ClickHouse 以反斜杠作为 LIKE 的转义符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike 将 s 中的 %、_ 与反斜杠转义为字面量
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // WithIds 使用更安全的 IN ? 形式展开 id 列的切片。空切片时忽略该条件。
 func WithIds(ids []string) QueryOption {
 	return func(db *DB) *DB {
@@ -362,28 +906,50 @@ func WithIds(ids []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("id IN ?", ids)
+		recordOption(db, "WithIds")
 		return db
 	}
 }
 
-// WithNames 使用更安全的 IN ? 形式展开 name 列的切片。空切片时忽略该条件。
+// WithNames 使用更安全的 IN ? 形式展开 name 列的切片。
+// 空白、超长或包含控制字符的元素会被跳过，过滤后为空时忽略该条件。
 func WithNames(names []string) QueryOption {
 	return func(db *DB) *DB {
-		if len(names) == 0 {
+		valid := validValues(names)
+		if len(valid) == 0 {
 			return db
 		}
-		db.DB = db.DB.Where("name IN ?", names)
+		db.DB = db.DB.Where("name IN ?", valid)
+		recordOption(db, "WithNames")
 		return db
 	}
 }
 
-// WithUsernames 使用更安全的 IN ? 形式展开 username 列的切片。空切片时忽略该条件。
+// WithUsernames 使用更安全的 IN ? 形式展开 username 列的切片。
+// 空白、超长或包含控制字符的元素会被跳过，过滤后为空时忽略该条件。
 func WithUsernames(usernames []string) QueryOption {
 	return func(db *DB) *DB {
-		if len(usernames) == 0 {
+		valid := validValues(usernames)
+		if len(valid) == 0 {
 			return db
 		}
-		db.DB = db.DB.Where("username IN ?", usernames)
+		db.DB = db.DB.Where("username IN ?", valid)
+		recordOption(db, "WithUsernames")
 		return db
 	}
-}
\ No newline at end of file
+}
+
+// validValues 返回通过 validateID 校验的非空白元素，保持原有顺序。
+func validValues(values []string) []string {
+	valid := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		if err := validateID(v); err != nil {
+			continue
+		}
+		valid = append(valid, v)
+	}
+	return valid
+}
@@ -1,9 +1,11 @@
 package clickhouse
 
 import (
+    "context"
     "errors"
     "testing"
     "strings"
+    "time"
 
     "gorm.io/driver/sqlite"
     "gorm.io/gorm"
@@ -24,10 +26,16 @@ func newTestDB(t *testing.T) *DB {
 // 返回构建完成的 *gorm.DB（其中 Statement.SQL 为最终 SQL，Statement.Vars 为绑定参数）。
 func execFind(t *testing.T, db *DB) *gorm.DB {
     t.Helper()
+    if db == nil || db.DB == nil || db.DB.Statement == nil {
+        t.Fatal("dryrun find requires a db with a non-nil statement")
+    }
     tx := db.DB.Session(&gorm.Session{DryRun: true}).Find(&[]struct{}{})
     if tx.Error != nil {
         t.Fatalf("dryrun find failed: %v", tx.Error)
     }
+    if tx.Statement == nil {
+        t.Fatal("dryrun find returned nil statement")
+    }
     return tx
 }
 
@@ -58,6 +66,41 @@ func TestOptionDB_NilSafety(t *testing.T) {
     }
 }
 
+// TestOptionDBContext 验证上下文绑定到生成的会话且不修改传入的 db，会话默认设置在替换上下文后保留，并保持 nil 安全与 panic 捕获。
+func TestOptionDBContext(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    root := newTestDB(t)
+    root.DB = applySessionDefaults(root.DB, sessionDefaults(&Config{DefaultFinal: true}))
+    rootDB := root.DB
+
+    updated, err := OptionDBContext(ctx, root, WithTable("events"), WithId("abc"))
+    if err != nil {
+        t.Fatalf("OptionDBContext should not return error: %v", err)
+    }
+    if !errors.Is(updated.DB.Statement.Context.Err(), context.Canceled) {
+        t.Fatalf("expected cancelled context on session, got: %v", updated.DB.Statement.Context.Err())
+    }
+    if root.DB != rootDB || root.DB.Statement.Context.Err() != nil {
+        t.Fatal("OptionDBContext should not modify the passed db")
+    }
+    if native, _ := chContextOptions(t, updated.DB.Statement.Context); native["final"] != "1" {
+        t.Fatalf("expected session defaults to survive context replacement, got %#v", native)
+    }
+    if sql := execFind(t, updated).Statement.SQL.String(); !contains(sql, "WHERE id = ?") {
+        t.Fatalf("expected options to be applied, got: %s", sql)
+    }
+
+    if _, err := OptionDBContext(ctx, nil); err == nil {
+        t.Fatal("expected error for nil db")
+    }
+    panicking := func(db *DB) *DB { panic("boom") }
+    if _, err := OptionDBContext(nil, newTestDB(t), panicking); err == nil {
+        t.Fatal("expected error from panicking option")
+    }
+}
+
 // TestOptionDBErrorHandling 测试 OptionDB 的错误处理功能
 func TestOptionDBErrorHandling(t *testing.T) {
     db := newTestDB(t)
@@ -315,6 +358,46 @@ func TestLimitOffset(t *testing.T) {
     }
 }
 
+// TestWithPage 验证按页码计算的 LIMIT/OFFSET，以及页码小于 1 与非法页大小的处理。
+func TestWithPage(t *testing.T) {
+    cases := []struct {
+        page, size int
+        want       []string
+        noOffset   bool
+    }{
+        {page: 1, size: 20, want: []string{"LIMIT 20"}, noOffset: true},
+        {page: 3, size: 20, want: []string{"LIMIT 20", "OFFSET 40"}},
+        {page: 2, size: 7, want: []string{"LIMIT 7", "OFFSET 7"}},
+        {page: 0, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+        {page: -5, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+    }
+    for _, c := range cases {
+        updated, err := OptionDB(newTestDB(t), WithTable("users"), WithPage(c.page, c.size))
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        sql := execFind(t, updated).Statement.SQL.String()
+        if !containsAll(sql, c.want) {
+            t.Fatalf("page=%d size=%d: expected %v, got: %s", c.page, c.size, c.want, sql)
+        }
+        if c.noOffset && contains(sql, "OFFSET") {
+            t.Fatalf("page=%d size=%d: first page should not set OFFSET, got: %s", c.page, c.size, sql)
+        }
+    }
+
+    // pageSize <= 0 时忽略
+    for _, size := range []int{0, -1} {
+        updated, err := OptionDB(newTestDB(t), WithTable("users"), WithPage(3, size))
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        sql := execFind(t, updated).Statement.SQL.String()
+        if contains(sql, "LIMIT") || contains(sql, "OFFSET") {
+            t.Fatalf("size=%d should be ignored, got: %s", size, sql)
+        }
+    }
+}
+
 // TestOrderWhitelist 验证升降序排序在白名单内才生效的逻辑。
 func TestOrderWhitelist(t *testing.T) {
     db := newTestDB(t)
@@ -325,7 +408,7 @@ func TestOrderWhitelist(t *testing.T) {
     }
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"ORDER BY created_at ASC", "username DESC"}) {
+    if !containsAll(sql, []string{"ORDER BY `created_at` ASC", "`username` DESC"}) {
         t.Fatalf("expected order clauses, got: %s", sql)
     }
 
@@ -352,7 +435,7 @@ func TestWithIn(t *testing.T) {
     }
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"id IN"}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
+    if !containsAll(sql, []string{"`id` IN"}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
         t.Fatalf("expected IN clause, got: %s", sql)
     }
     if len(tx.Statement.Vars) != 2 {
@@ -372,6 +455,218 @@ func TestWithIn(t *testing.T) {
     }
 }
 
+// TestWithNotIn 验证 NOT IN 条件按切片展开绑定参数，nil 与空切片忽略该条件。
+func TestWithNotIn(t *testing.T) {
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("users"), WithNotIn("id", []string{"a", "b", "c"}))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, "WHERE `id` NOT IN (?,?,?)") {
+        t.Fatalf("expected NOT IN clause, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 3 {
+        t.Fatalf("expected 3 vars for NOT IN, got: %d, vars: %#v", len(tx.Statement.Vars), tx.Statement.Vars)
+    }
+
+    // nil 与空切片应忽略
+    for _, values := range []any{nil, []string{}, []int(nil), []int64{}} {
+        db2 := newTestDB(t)
+        updated2, err := OptionDB(db2, WithTable("users"), WithNotIn("id", values))
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql2 := execFind(t, updated2).Statement.SQL.String(); contains(sql2, "NOT IN") {
+            t.Fatalf("empty values %#v should skip NOT IN clause, got: %s", values, sql2)
+        }
+    }
+}
+
+// TestWithTupleIn 验证行值 IN 条件：SQL 形如 (a, b) IN ((?, ?), (?, ?))，所有值均绑定；
+// 空元组列表与非法字段忽略该选项，元组元素个数不一致时返回验证错误。
+func TestWithTupleIn(t *testing.T) {
+    wl := map[string]struct{}{"tenant_id": {}, "user_id": {}}
+    tuples := [][]any{{1, "x"}, {2, "y"}, {3, "z"}}
+
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("events"), WithTupleIn([]string{"tenant_id", "user_id"}, tuples, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, "(`tenant_id`, `user_id`) IN ((?, ?), (?, ?), (?, ?))") {
+        t.Fatalf("expected tuple IN clause, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 6 || tx.Statement.Vars[0] != 1 || tx.Statement.Vars[5] != "z" {
+        t.Fatalf("expected 6 bound vars in tuple order, got: %#v", tx.Statement.Vars)
+    }
+
+    // 空元组列表、未在白名单中的字段与非法字段均忽略该选项
+    for _, opt := range []QueryOption{
+        WithTupleIn([]string{"tenant_id", "user_id"}, nil, wl),
+        WithTupleIn([]string{"tenant_id", "name"}, tuples, wl),
+        WithTupleIn([]string{"tenant_id", "user_id; DROP"}, tuples, nil),
+        WithTupleIn(nil, tuples, wl),
+    } {
+        skipped, err := OptionDB(newTestDB(t), WithTable("events"), opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, skipped).Statement.SQL.String(); contains(sql, " IN ") {
+            t.Fatalf("expected tuple IN to be skipped, got: %s", sql)
+        }
+    }
+
+    // 元组元素个数不一致时执行返回验证错误，且不影响原 gorm 会话
+    root := newTestDB(t)
+    base := root.DB
+    bad, err := OptionDB(root, WithTable("events"), WithTupleIn([]string{"tenant_id", "user_id"}, [][]any{{1, "x"}, {2}}, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    if tx := bad.DB.Session(&gorm.Session{DryRun: true}).Find(&[]struct{}{}); !IsValidationError(tx.Error) {
+        t.Fatalf("expected validation error for arity mismatch, got: %v", tx.Error)
+    }
+    if base.Error != nil {
+        t.Fatalf("base session should not carry the error, got: %v", base.Error)
+    }
+}
+
+// TestWithBetween 验证范围条件的 BETWEEN 构造、单边退化、两端均为 nil 与非法字段时忽略。
+func TestWithBetween(t *testing.T) {
+    wl := map[string]struct{}{"ts": {}, "amount": {}}
+    from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    to := from.Add(24 * time.Hour)
+
+    updated, err := OptionDB(newTestDB(t), WithTable("events"), WithBetween("ts", from, to, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    if sql := tx.Statement.SQL.String(); !contains(sql, "`ts` BETWEEN ? AND ?") {
+        t.Fatalf("expected BETWEEN clause, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 2 || tx.Statement.Vars[0] != from || tx.Statement.Vars[1] != to {
+        t.Fatalf("expected 2 bound vars [from, to], got: %#v", tx.Statement.Vars)
+    }
+
+    for _, tc := range []struct {
+        opt  QueryOption
+        want string
+    }{
+        {WithBetween("amount", 10, nil, wl), "`amount` >= ?"},
+        {WithBetween("amount", nil, 100, wl), "`amount` <= ?"},
+    } {
+        updated, err := OptionDB(newTestDB(t), WithTable("events"), tc.opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, updated).Statement.SQL.String(); !contains(sql, tc.want) {
+            t.Fatalf("expected %q, got: %s", tc.want, sql)
+        }
+    }
+
+    for _, opt := range []QueryOption{
+        WithBetween("amount", nil, nil, wl),
+        WithBetween("name", 1, 2, wl),
+        WithBetween("ts; DROP", 1, 2, nil),
+    } {
+        skipped, err := OptionDB(newTestDB(t), WithTable("events"), opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, skipped).Statement.SQL.String(); contains(sql, "WHERE") {
+            t.Fatalf("expected range condition to be skipped, got: %s", sql)
+        }
+    }
+}
+
+// TestWithComparison 验证 Gt/Gte/Lt/Lte 的运算符与单个绑定参数，以及 nil 值与非法字段时忽略。
+func TestWithComparison(t *testing.T) {
+    wl := map[string]struct{}{"created_at": {}}
+    ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+    for _, tc := range []struct {
+        opt  QueryOption
+        want string
+    }{
+        {WithGt("created_at", ts, wl), "`created_at` > ?"},
+        {WithGte("created_at", ts, wl), "`created_at` >= ?"},
+        {WithLt("created_at", ts, wl), "`created_at` < ?"},
+        {WithLte("created_at", ts, wl), "`created_at` <= ?"},
+    } {
+        updated, err := OptionDB(newTestDB(t), WithTable("events"), tc.opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        tx := execFind(t, updated)
+        if sql := tx.Statement.SQL.String(); !contains(sql, tc.want) {
+            t.Fatalf("expected %q, got: %s", tc.want, sql)
+        }
+        if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != ts {
+            t.Fatalf("expected single bound var, got: %#v", tx.Statement.Vars)
+        }
+    }
+
+    for _, opt := range []QueryOption{
+        WithGt("created_at", nil, wl),
+        WithLte("updated_at", ts, wl),
+        WithGte("created_at > 0 OR 1", ts, nil),
+    } {
+        skipped, err := OptionDB(newTestDB(t), WithTable("events"), opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, skipped).Statement.SQL.String(); contains(sql, "WHERE") {
+            t.Fatalf("expected comparison to be skipped, got: %s", sql)
+        }
+    }
+}
+
+// TestWithLikePrefix 验证 LIKE 条件的构造、通配符转义，以及空模式与非法字段时忽略。
+func TestWithLikePrefix(t *testing.T) {
+    wl := map[string]struct{}{"name": {}}
+
+    for _, tc := range []struct {
+        opt  QueryOption
+        want string
+    }{
+        {WithLike("name", " 50%_off ", wl), `%50\%\_off%`},
+        {WithLike("name", `a\b`, wl), `%a\\b%`},
+        {WithPrefix("name", "user_", wl), `user\_%`},
+    } {
+        updated, err := OptionDB(newTestDB(t), WithTable("users"), tc.opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        tx := execFind(t, updated)
+        if sql := tx.Statement.SQL.String(); !contains(sql, "`name` LIKE ?") {
+            t.Fatalf("expected LIKE clause, got: %s", sql)
+        }
+        if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != tc.want {
+            t.Fatalf("expected bound pattern %q, got: %#v", tc.want, tx.Statement.Vars)
+        }
+    }
+
+    for _, opt := range []QueryOption{
+        WithLike("name", "   ", wl),
+        WithPrefix("name", "", wl),
+        WithLike("email", "x", wl),
+        WithPrefix("name; DROP", "x", nil),
+    } {
+        skipped, err := OptionDB(newTestDB(t), WithTable("users"), opt)
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, skipped).Statement.SQL.String(); contains(sql, "LIKE") {
+            t.Fatalf("expected LIKE to be skipped, got: %s", sql)
+        }
+    }
+}
+
 // TestWithIdsNamesUsernames 验证针对固定列名的 IN 条件构造与空切片忽略。
 func TestWithIdsNamesUsernames(t *testing.T) {
     // WithIds
@@ -454,4 +749,367 @@ func Index(s, sub string) int {
 
 // indexImpl 最终委托标准库 strings.Index。
 // 将此函数独立出来以满足“函数级注释”的要求，同时保持可测试性。
-func indexImpl(s, sub string) int { return strings.Index(s, sub) }
\ No newline at end of file
+func indexImpl(s, sub string) int { return strings.Index(s, sub) }
+
+// TestReservedWordQuoting 验证列名在条件与排序中一律使用反引号包裹，与 SQL 保留字同名的列（如 order、user、default）同样安全。
+func TestReservedWordQuoting(t *testing.T) {
+    db := newTestDB(t)
+    wl := map[string]struct{}{"order": {}}
+    updated, err := OptionDB(db, WithTable("orders"), WithIn("order", []int{1, 2}), OrderDesc("order", wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"`order` IN", "ORDER BY `order` DESC"}) {
+        t.Fatalf("expected reserved-word column to be quoted, got: %s", sql)
+    }
+
+    // 不在关键字列表中的保留字（user、default）同样加引号
+    updated, err = OptionDB(newTestDB(t), WithTable("orders"), WithIn("user", []string{"u1"}), OrderAsc("default", map[string]struct{}{"default": {}}))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    sql = execFind(t, updated).Statement.SQL.String()
+    if !containsAll(sql, []string{"WHERE `user` IN", "ORDER BY `default` ASC"}) {
+        t.Fatalf("expected user and default columns to be quoted, got: %s", sql)
+    }
+
+    // 校验失败的标识符不应被包裹
+    if _, err := QuoteIdentifier("order`; DROP TABLE x"); err == nil || !IsValidationError(err) {
+        t.Fatalf("expected validation error for unsafe identifier, got: %v", err)
+    }
+    if q, err := QuoteIdentifier("created_at"); err != nil || q != "`created_at`" {
+        t.Fatalf("expected `created_at`, got: %q, %v", q, err)
+    }
+}
+
+// TestWithTransformedEq 验证 WithTransformedEq 绑定的是经过 transform 规范化后的值，且非白名单字段被忽略。
+func TestWithTransformedEq(t *testing.T) {
+    normalize := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+    wl := map[string]struct{}{"email": {}}
+
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("users"), WithTransformedEq("email", "  Alice@Example.COM ", normalize, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"WHERE `email` = ?"}) {
+        t.Fatalf("expected email condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != "alice@example.com" {
+        t.Fatalf("expected transformed var [alice@example.com], got: %#v", tx.Statement.Vars)
+    }
+
+    // 非白名单字段应被忽略
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("users"), WithTransformedEq("password", "x", normalize, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); containsAll(sql2, []string{"password = ?"}) {
+        t.Fatalf("non-whitelist field should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithMapValueEq 验证 Map 列访问语法与键/值均作为参数绑定。
+func TestWithMapValueEq(t *testing.T) {
+    wl := map[string]struct{}{"attrs": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("events"), WithMapValueEq("attrs", "region'--", "cn", wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"WHERE `attrs`[?] = ?"}) {
+        t.Fatalf("expected map access condition, got: %s", sql)
+    }
+    if contains(sql, "region") {
+        t.Fatalf("map key should be bound, not inlined, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 2 || tx.Statement.Vars[0] != "region'--" || tx.Statement.Vars[1] != "cn" {
+        t.Fatalf("expected vars [region'-- cn], got: %#v", tx.Statement.Vars)
+    }
+
+    // 非白名单字段与非法键应被忽略
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("events"),
+        WithMapValueEq("labels", "k", "v", wl),
+        WithMapValueEq("attrs", "k\x00", "v", wl),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); contains(sql2, "[?]") {
+        t.Fatalf("invalid map predicates should be ignored, got: %s", sql2)
+    }
+}
+
+// TestOptionAudit 验证开启审计后按顺序记录已应用选项名称，且不包含绑定值。
+func TestOptionAudit(t *testing.T) {
+    wl := map[string]struct{}{"created_at": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db,
+        WithOptionAudit(),
+        WithTable("users"),
+        WithId("secret-id"),
+        WithName(" "),
+        OrderAsc("created_at", wl),
+        WithLimit(10),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    got := AppliedOptions(updated)
+    want := []string{"WithTable:users", "WithId", "OrderAsc:created_at", "WithLimit"}
+    if len(got) != len(want) {
+        t.Fatalf("expected %v, got %v", want, got)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("expected %v, got %v", want, got)
+        }
+    }
+
+    // 未开启审计时不记录
+    plain, _ := OptionDB(newTestDB(t), WithId("x"))
+    if names := AppliedOptions(plain); names != nil {
+        t.Fatalf("expected nil without audit, got %v", names)
+    }
+}
+
+// TestNameValidation 验证超长用户名与包含控制字符的名称会被忽略，切片中的非法元素会被跳过。
+func TestNameValidation(t *testing.T) {
+    db := newTestDB(t)
+    updated, err := OptionDB(db,
+        WithTable("users"),
+        WithUserName(strings.Repeat("a", 256)),
+        WithName("bob\x00"),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if contains(sql, "username = ?") || contains(sql, "name = ?") {
+        t.Fatalf("invalid username/name should be ignored, got: %s", sql)
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2,
+        WithTable("users"),
+        WithUsernames([]string{"alice", "bad\x01", " "}),
+        WithNames([]string{strings.Repeat("n", 300)}),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx2 := execFind(t, updated2)
+    sql2 := tx2.Statement.SQL.String()
+    if !contains(sql2, "username IN (?)") {
+        t.Fatalf("expected only valid usernames to be bound, got: %s", sql2)
+    }
+    if contains(sql2, " name IN") {
+        t.Fatalf("all-invalid names should be ignored, got: %s", sql2)
+    }
+    if len(tx2.Statement.Vars) != 1 || tx2.Statement.Vars[0] != "alice" {
+        t.Fatalf("expected vars [alice], got: %#v", tx2.Statement.Vars)
+    }
+}
+
+// TestPreviewSQL 验证 PreviewSQL 返回 SQL 与绑定参数，并在选项 panic 或 Statement 为空时返回错误而非崩溃。
+func TestPreviewSQL(t *testing.T) {
+    sql, vars, err := PreviewSQL(newTestDB(t), WithTable("users"), WithId("42"))
+    if err != nil {
+        t.Fatalf("PreviewSQL should not return error: %v", err)
+    }
+    if !containsAll(sql, []string{"FROM `users`", "WHERE id = ?"}) {
+        t.Fatalf("unexpected preview SQL: %s", sql)
+    }
+    if len(vars) != 1 || vars[0] != "42" {
+        t.Fatalf("expected vars [42], got: %#v", vars)
+    }
+
+    panicking := func(db *DB) *DB {
+        db.DB.Statement = nil
+        panic("broken option")
+    }
+    if _, _, err := PreviewSQL(newTestDB(t), panicking); !IsQueryError(err) {
+        t.Fatalf("expected query error for panicking option, got: %v", err)
+    }
+
+    if _, _, err := PreviewSQL(&DB{DB: &gorm.DB{}}); !IsQueryError(err) {
+        t.Fatalf("expected query error for nil statement, got: %v", err)
+    }
+    if _, _, err := PreviewSQL(nil); !IsQueryError(err) {
+        t.Fatalf("expected query error for nil db, got: %v", err)
+    }
+}
+
+// TestWithTimeBucket 验证分桶函数同时出现在 SELECT 与 GROUP BY 中，且未知粒度被忽略。
+func TestWithTimeBucket(t *testing.T) {
+    wl := map[string]struct{}{"ts": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("events"), WithTimeBucket("ts", "Hour", wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"SELECT toStartOfHour(`ts`) AS bucket", "GROUP BY toStartOfHour(`ts`)"}) {
+        t.Fatalf("expected time bucket in SELECT and GROUP BY, got: %s", sql)
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("events"),
+        WithTimeBucket("ts", "week", wl),
+        WithTimeBucket("created_at", "day", wl),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); contains(sql2, "toStartOf") || contains(sql2, "GROUP BY") {
+        t.Fatalf("invalid time bucket should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithSelect 验证仅白名单内的合法列出现在 SELECT 列表中，全部不合法时回退为 SELECT *。
+func TestWithSelect(t *testing.T) {
+    wl := map[string]struct{}{"id": {}, "ts": {}, "order": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("events"),
+        WithSelect([]string{"id", " ts ", "payload", "id; DROP TABLE events", "", "order"}, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    sql := execFind(t, updated).Statement.SQL.String()
+    if !contains(sql, "SELECT `id`,`ts`,`order` FROM `events`") {
+        t.Fatalf("expected only whitelisted columns in SELECT, got: %s", sql)
+    }
+    if contains(sql, "payload") || contains(sql, "DROP") {
+        t.Fatalf("invalid columns should be dropped, got: %s", sql)
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("events"), WithSelect([]string{"payload", " "}, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    if sql2 := execFind(t, updated2).Statement.SQL.String(); !contains(sql2, "SELECT * FROM `events`") {
+        t.Fatalf("WithSelect without valid columns should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithGroupByHaving 验证 GROUP BY 仅包含白名单内的合法字段，HAVING 条件的值作为参数绑定。
+func TestWithGroupByHaving(t *testing.T) {
+    wl := map[string]struct{}{"region": {}, "host": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("traffic"),
+        WithSelect([]string{"region", "host"}, wl),
+        WithGroupBy([]string{"region", "payload", "host); DROP TABLE traffic", " host "}, wl),
+        WithHaving("sum(bytes) > ?", 100),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"GROUP BY `region`, `host`", "HAVING sum(bytes) > ?"}) {
+        t.Fatalf("expected GROUP BY and HAVING clauses, got: %s", sql)
+    }
+    if contains(sql, "payload") || contains(sql, "DROP") {
+        t.Fatalf("invalid group fields should be dropped, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != 100 {
+        t.Fatalf("expected HAVING arg to be bound, got %v", tx.Statement.Vars)
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("traffic"),
+        WithGroupBy([]string{"payload", " "}, wl),
+        WithHaving("  "),
+    )
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    if sql2 := execFind(t, updated2).Statement.SQL.String(); contains(sql2, "GROUP BY") || contains(sql2, "HAVING") {
+        t.Fatalf("WithGroupBy without valid fields and empty WithHaving should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithInAnyColumn 验证多列 IN 条件以括号包裹的 OR 组合生成，每列分别绑定同一组值，非法字段与空值被忽略。
+func TestWithInAnyColumn(t *testing.T) {
+    wl := map[string]struct{}{"id": {}, "external_id": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("users"), WithStatus(1),
+        WithInAnyColumn([]string{"id", "payload", "external_id"}, []string{"a", "b", "c"}, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, "(`id` IN (?,?,?) OR `external_id` IN (?,?,?))") {
+        t.Fatalf("expected parenthesized OR of IN clauses, got: %s", sql)
+    }
+    if contains(sql, "payload") {
+        t.Fatalf("invalid field should be dropped, got: %s", sql)
+    }
+    // status 1 个参数 + 两列各 3 个参数
+    if len(tx.Statement.Vars) != 7 {
+        t.Fatalf("expected 7 bound vars, got %d: %v", len(tx.Statement.Vars), tx.Statement.Vars)
+    }
+
+    for _, values := range []any{nil, []string{}, []int64(nil)} {
+        db := newTestDB(t)
+        updated, err := OptionDB(db, WithTable("users"), WithInAnyColumn([]string{"id", "external_id"}, values, wl))
+        if err != nil {
+            t.Fatalf("OptionDB should not return error: %v", err)
+        }
+        if sql := execFind(t, updated).Statement.SQL.String(); contains(sql, "IN") {
+            t.Fatalf("empty values %#v should be ignored, got: %s", values, sql)
+        }
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("users"), WithInAnyColumn([]string{"payload", " "}, []int{1}, wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    if sql2 := execFind(t, updated2).Statement.SQL.String(); contains(sql2, "WHERE") {
+        t.Fatalf("WithInAnyColumn without valid fields should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithNullNotNull 验证 IS NULL / IS NOT NULL 条件的 SQL 片段且不绑定参数，空白或非白名单字段被忽略。
+func TestWithNullNotNull(t *testing.T) {
+    wl := map[string]struct{}{"deleted_at": {}, "email": {}}
+    db := newTestDB(t)
+    updated, err := OptionDB(db, WithTable("users"), WithNull("deleted_at", wl), WithNotNull("email", wl))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, "WHERE `deleted_at` IS NULL AND `email` IS NOT NULL") {
+        t.Fatalf("expected IS NULL and IS NOT NULL conditions, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 0 {
+        t.Fatalf("expected no bound vars, got: %#v", tx.Statement.Vars)
+    }
+
+    db2 := newTestDB(t)
+    updated2, err := OptionDB(db2, WithTable("users"),
+        WithNull(" ", wl), WithNotNull("phone", wl), WithNull("email IS NULL OR 1=1", nil))
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    if sql2 := execFind(t, updated2).Statement.SQL.String(); contains(sql2, "WHERE") {
+        t.Fatalf("blank or invalid fields should be ignored, got: %s", sql2)
+    }
+}
@@ -0,0 +1,133 @@
+package clickhouse
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// maxRetryBackoff 为单次重试等待时间的上限，避免指数退避增长过快。
+const maxRetryBackoff = 30 * time.Second
+
+// NewDBWithRetry 创建数据库实例并在连接失败时按带抖动的指数退避重试。
+// 适用于容器编排场景中数据库启动晚于服务的情况，等价于使用 context.Background() 调用 NewDBWithRetryContext。
+func NewDBWithRetry(config *Config, maxAttempts int, backoff time.Duration) (*DB, error) {
+	return NewDBWithRetryContext(context.Background(), config, maxAttempts, backoff)
+}
+
+// NewDBWithRetryContext 与 NewDBWithRetry 相同，但允许通过 ctx 取消等待或限制总耗时。
+// 说明：
+// 1) 每次尝试都会执行 NewDB 并 Ping 数据库，Ping 失败时关闭本次打开的连接池；
+// 2) 仅对可重试的错误（连接、超时）重试，配置错误、TLS 错误等立即返回；
+// 3) 第 n 次重试前等待 backoff*2^(n-1) 的 [1/2, 1] 倍随机时长，单次等待不超过 30 秒；
+// 4) maxAttempts 小于 1 时按 1 处理。
+func NewDBWithRetryContext(ctx context.Context, config *Config, maxAttempts int, backoff time.Duration) (*DB, error) {
+	return connectWithRetry(ctx, maxAttempts, backoff, func(ctx context.Context) (*DB, error) {
+		return openAndPing(ctx, config)
+	})
+}
+
+// openAndPing 打开数据库并 Ping 确认连通性。
+func openAndPing(ctx context.Context, config *Config) (*DB, error) {
+	db, err := NewDB(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(ctx); err != nil {
+		if sqlDB, e := db.DB.DB(); e == nil {
+			_ = sqlDB.Close()
+		}
+		return nil, err
+	}
+	return db, nil
+}
+
+// connectWithRetry 执行 open 并在可重试错误时按退避策略重试，open 由调用方注入以便测试模拟连接失败。
+func connectWithRetry(ctx context.Context, maxAttempts int, backoff time.Duration, open func(context.Context) (*DB, error)) (*DB, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		db, err := open(ctx)
+		if err == nil {
+			return db, nil
+		}
+		lastErr = err
+		if !IsRetriableError(err) || attempt == maxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(retryDelay(backoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, NewConnectionError("database connection retry canceled", ctx.Err()).
+				WithContext("attempts", attempt).
+				WithCode("CONN_RETRY_CANCELED").
+				WithRetriable(false)
+		case <-timer.C:
+		}
+	}
+
+	if !IsRetriableError(lastErr) {
+		return nil, lastErr
+	}
+	return nil, WrapError(lastErr, ErrorTypeConnection, "failed to connect to database after retries").
+		WithContext("max_attempts", maxAttempts)
+}
+
+// RetryQuery 执行 fn，并在返回可重试错误（IsRetriableError，如连接中断、超时）时按带抖动的指数退避重试。
+// 说明：
+// 1) 不可重试的错误（如验证错误、语法错误）立即返回，不再重试；
+// 2) 重试次数用尽后原样返回最后一次的错误；
+// 3) 退避策略与 NewDBWithRetryContext 相同，等待期间 ctx 被取消时返回包装了 ctx.Err() 的查询错误；
+// 4) attempts 小于 1 时按 1 处理；fn 应可安全重复执行（如只读查询或幂等写入）。
+func RetryQuery(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		lastErr = fn()
+		if lastErr == nil || !IsRetriableError(lastErr) || attempt == attempts {
+			return lastErr
+		}
+
+		timer := time.NewTimer(retryDelay(backoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return NewQueryError("query retry canceled", ctx.Err()).
+				WithContext("attempts", attempt).
+				WithCode("QUERY_RETRY_CANCELED").
+				WithRetriable(false)
+		case <-timer.C:
+		}
+	}
+	return lastErr
+}
+
+// retryDelay 计算第 attempt 次失败后的等待时长（带抖动的指数退避）。
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	delay := backoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
@@ -0,0 +1,129 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestConnectWithRetry 使用前两次失败、第三次成功的模拟拨号函数验证重试流程。
+func TestConnectWithRetry(t *testing.T) {
+	calls := 0
+	want := &DB{}
+	dialer := func(ctx context.Context) (*DB, error) {
+		calls++
+		if calls <= 2 {
+			return nil, NewConnectionError("dial failed", errors.New("connection refused"))
+		}
+		return want, nil
+	}
+
+	got, err := connectWithRetry(context.Background(), 5, time.Millisecond, dialer)
+	if err != nil {
+		t.Fatalf("expected success after retries, got: %v", err)
+	}
+	if got != want || calls != 3 {
+		t.Fatalf("expected 3 attempts returning the dialed db, got calls=%d", calls)
+	}
+
+	// 尝试次数用尽时返回连接错误
+	calls = 0
+	if _, err := connectWithRetry(context.Background(), 2, time.Millisecond, dialer); !IsConnectionError(err) || calls != 2 {
+		t.Fatalf("expected connection error after 2 attempts, got calls=%d err=%v", calls, err)
+	}
+}
+
+// TestConnectWithRetryConfigError 验证配置错误不会触发重试。
+func TestConnectWithRetryConfigError(t *testing.T) {
+	calls := 0
+	_, err := connectWithRetry(context.Background(), 5, time.Millisecond, func(ctx context.Context) (*DB, error) {
+		calls++
+		return nil, NewConfigError("bad config", nil)
+	})
+	if !IsConfigError(err) || calls != 1 {
+		t.Fatalf("expected config error without retry, got calls=%d err=%v", calls, err)
+	}
+
+	// 无效配置经由 NewDBWithRetry 也应立即返回
+	if _, err := NewDBWithRetry(&Config{}, 3, time.Millisecond); !IsConfigError(err) {
+		t.Fatalf("expected config error from NewDBWithRetry, got: %v", err)
+	}
+}
+
+// TestConnectWithRetryCanceled 验证在退避等待期间取消 ctx 会立即返回。
+func TestConnectWithRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := connectWithRetry(ctx, 5, time.Hour, func(ctx context.Context) (*DB, error) {
+		calls++
+		cancel()
+		return nil, NewConnectionError("dial failed", nil)
+	})
+	if err == nil || calls != 1 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error after 1 attempt, got calls=%d err=%v", calls, err)
+	}
+}
+
+// TestRetryDelay 验证退避时长在 [delay/2, delay] 区间内且不超过上限。
+func TestRetryDelay(t *testing.T) {
+	for attempt := 1; attempt <= 4; attempt++ {
+		full := 100 * time.Millisecond << (attempt - 1)
+		d := retryDelay(100*time.Millisecond, attempt)
+		if d < full/2 || d > full {
+			t.Fatalf("attempt %d: delay %v out of range [%v, %v]", attempt, d, full/2, full)
+		}
+	}
+	if d := retryDelay(time.Second, 20); d > maxRetryBackoff {
+		t.Fatalf("delay should be capped at %v, got %v", maxRetryBackoff, d)
+	}
+	if d := retryDelay(0, 3); d != 0 {
+		t.Fatalf("zero backoff should yield zero delay, got %v", d)
+	}
+}
+
+// TestRetryQuery 验证连接错误在第二次尝试成功、验证错误立即返回、次数用尽返回最后一次错误以及等待期间取消。
+func TestRetryQuery(t *testing.T) {
+	calls := 0
+	err := RetryQuery(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		if calls == 1 {
+			return NewConnectionError("connection reset", errors.New("EOF"))
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("expected success on second attempt, got calls=%d err=%v", calls, err)
+	}
+
+	calls = 0
+	invalid := NewValidationError("invalid field", nil)
+	if err := RetryQuery(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		return invalid
+	}); err != invalid || calls != 1 {
+		t.Fatalf("expected validation error without retry, got calls=%d err=%v", calls, err)
+	}
+
+	calls = 0
+	var last error
+	err = RetryQuery(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		last = NewTimeoutError("query timeout", nil)
+		return last
+	})
+	if err != last || calls != 3 {
+		t.Fatalf("expected last error after 3 attempts, got calls=%d err=%v", calls, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = RetryQuery(ctx, 5, time.Hour, func() error {
+		calls++
+		cancel()
+		return NewConnectionError("connection reset", nil)
+	})
+	if calls != 1 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error after 1 attempt, got calls=%d err=%v", calls, err)
+	}
+}
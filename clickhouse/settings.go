@@ -0,0 +1,180 @@
+package clickhouse
+
+import (
+	"context"
+	"strings"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+	"gorm.io/gorm"
+)
+
+// settingsKey 为 GORM Statement.Settings 中保存查询级 ClickHouse 设置的键名。
+const settingsKey = "clickhouse:settings"
+
+// WithSettings 为当前查询会话附加 ClickHouse 设置项（如 max_threads、insert_deduplicate）。
+// 说明：
+// 1) 设置项会与已附加的设置合并，同名键以后设置的值为准；
+// 2) 键名会去除首尾空白，空键名被忽略；settings 为空时忽略该选项；
+// 3) 设置通过 clickhouse.Context 注入到会话上下文，由 clickhouse-go 驱动在执行时发送给服务端。
+func WithSettings(settings map[string]any) QueryOption {
+	return func(db *DB) *DB {
+		if len(settings) == 0 {
+			return db
+		}
+
+		merged := make(ch.Settings)
+		for k, v := range QuerySettings(db) {
+			merged[k] = v
+		}
+		applied := false
+		for k, v := range settings {
+			k = strings.TrimSpace(k)
+			if k == "" {
+				continue
+			}
+			merged[k] = v
+			applied = true
+		}
+		if !applied {
+			return db
+		}
+
+		tx := db.DB.Set(settingsKey, map[string]any(merged))
+		db.DB = tx.WithContext(ch.Context(tx.Statement.Context, ch.WithSettings(merged)))
+		recordOption(db, "WithSettings")
+		return db
+	}
+}
+
+// QuerySettings 返回当前查询会话已附加的 ClickHouse 设置项副本；未设置时返回空 map。
+func QuerySettings(db *DB) map[string]any {
+	result := make(map[string]any)
+	if db == nil || db.DB == nil || db.DB.Statement == nil {
+		return result
+	}
+	if v, ok := db.DB.Get(settingsKey); ok {
+		if settings, ok := v.(map[string]any); ok {
+			for k, val := range settings {
+				result[k] = val
+			}
+		}
+	}
+	return result
+}
+
+// WithCHContext 返回附加了 ClickHouse 原生设置项与查询参数的上下文（clickhouse.Context），
+// 可传给 SumBy、RunMigrations、DropPartition 等接收 context 的执行辅助函数，对该次执行生效。
+// 说明：
+// 1) 仅在使用 clickhouse-go 原生驱动（OpenDB=false）时生效，标准库 database/sql 模式不会读取这些设置；
+// 2) params 对应 SQL 中的服务端参数占位符（如 {region:String}）；键名去除首尾空白，空键名被忽略；
+// 3) 会整体覆盖 ctx 中已通过 clickhouse.Context 附加的设置或参数（原生驱动不合并），需要时请一次性传入；
+// 4) SumBy、DropPartition、TruncateTable 经 OptionDBContext 应用上下文：会话默认设置或 WithSettings 等选项附加了设置时，
+//    设置项以其为准（覆盖此处的设置，查询参数保留）；RunMigrations 直接以该上下文替换语句上下文，以此处设置为准。
+func WithCHContext(ctx context.Context, settings map[string]any, params map[string]string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var opts []ch.QueryOption
+	if len(settings) > 0 {
+		s := make(ch.Settings, len(settings))
+		for k, v := range settings {
+			if k = strings.TrimSpace(k); k != "" {
+				s[k] = v
+			}
+		}
+		if len(s) > 0 {
+			opts = append(opts, ch.WithSettings(s))
+		}
+	}
+	if len(params) > 0 {
+		p := make(ch.Parameters, len(params))
+		for k, v := range params {
+			if k = strings.TrimSpace(k); k != "" {
+				p[k] = v
+			}
+		}
+		if len(p) > 0 {
+			opts = append(opts, ch.WithParameters(p))
+		}
+	}
+
+	return ch.Context(ctx, opts...)
+}
+
+// WithInsertDeduplicate 设置插入去重开关（insert_deduplicate）。
+// 向带有物化视图的表插入重复数据块时，可传入 false 关闭去重，避免下游视图丢数据。
+func WithInsertDeduplicate(enabled bool) QueryOption {
+	return WithSettings(map[string]any{"insert_deduplicate": boolSetting(enabled)})
+}
+
+// WithParallelViewProcessing 设置是否并行推送数据到关联的物化视图（parallel_view_processing）。
+// 当一张表挂载多个物化视图时，开启后可缩短插入耗时，但会增加服务端并发资源占用。
+func WithParallelViewProcessing(enabled bool) QueryOption {
+	return WithSettings(map[string]any{"parallel_view_processing": boolSetting(enabled)})
+}
+
+// WithMaxInsertBlockSize 设置插入时服务端拆分数据块的最大行数（max_insert_block_size，默认约 100 万行）。
+// 大批量或流式插入耗尽服务端内存时可调小该值，代价是生成更多数据块、增加后台合并压力。n 小于等于 0 时忽略该选项。
+func WithMaxInsertBlockSize(n int) QueryOption {
+	if n <= 0 {
+		return func(db *DB) *DB { return db }
+	}
+	return WithSettings(map[string]any{"max_insert_block_size": n})
+}
+
+// WithMinInsertBlockSizeRows 设置插入时小数据块合并为大块的最小行数（min_insert_block_size_rows），
+// 行数不足该值的块会在内存中攒批后再写入，调小可降低攒批占用的内存。n 小于等于 0 时忽略该选项。
+func WithMinInsertBlockSizeRows(n int) QueryOption {
+	if n <= 0 {
+		return func(db *DB) *DB { return db }
+	}
+	return WithSettings(map[string]any{"min_insert_block_size_rows": n})
+}
+
+// WithFinal 设置是否对查询涉及的表自动应用 FINAL（final），可覆盖 Config.DefaultFinal 的会话默认值。
+// 开启后读取合并后的最终数据（如 ReplacingMergeTree 去重结果），但查询时需要额外合并，耗时与资源占用更高。
+func WithFinal(enabled bool) QueryOption {
+	return WithSettings(map[string]any{"final": boolSetting(enabled)})
+}
+
+// WithForceIndexByDate 设置查询必须能利用日期分区键（force_index_by_date），可覆盖 Config.ForceIndexByDate 的会话默认值。
+// 需要执行不带日期条件的维护或统计查询时，可传入 false 临时关闭。
+func WithForceIndexByDate(enabled bool) QueryOption {
+	return WithSettings(map[string]any{"force_index_by_date": boolSetting(enabled)})
+}
+
+// sessionDefaults 根据配置返回会话级默认设置项；均未开启时返回 nil。
+func sessionDefaults(cfg *Config) map[string]any {
+	settings := make(map[string]any)
+	if cfg.DefaultFinal {
+		settings["final"] = boolSetting(true)
+	}
+	if cfg.ForceIndexByDate {
+		settings["force_index_by_date"] = boolSetting(true)
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+	return settings
+}
+
+// applySessionDefaults 将默认设置附加到根会话，基于该实例构建的查询都会携带这些设置；
+// 查询级的 WithSettings、WithFinal 等选项在默认值基础上合并，同名键以查询级为准。
+// 注意：WithCHContext 返回的上下文会整体替换语句上下文，此时默认设置需一并传入。
+func applySessionDefaults(db *gorm.DB, settings map[string]any) *gorm.DB {
+	if len(settings) == 0 {
+		return db
+	}
+	tx := db.Set(settingsKey, settings)
+	// WithContext 返回新的可复用会话，避免后续链式调用污染根实例
+	return tx.WithContext(ch.Context(tx.Statement.Context, ch.WithSettings(ch.Settings(settings))))
+}
+
+// boolSetting 将布尔值转换为 ClickHouse 设置项使用的 0/1。
+func boolSetting(enabled bool) int {
+	if enabled {
+		return 1
+	}
+	return 0
+}
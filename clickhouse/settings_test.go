@@ -0,0 +1,170 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// TestWithSettingsMerge 验证 WithSettings 的合并与空键忽略逻辑。
+func TestWithSettingsMerge(t *testing.T) {
+	db := newTestDB(t)
+	updated, err := OptionDB(db,
+		WithTable("events"),
+		WithSettings(map[string]any{"max_threads": 4, "  ": 1}),
+		WithSettings(map[string]any{"max_threads": 8, "max_block_size": 1024}),
+	)
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+
+	settings := QuerySettings(updated)
+	if len(settings) != 2 {
+		t.Fatalf("expected 2 settings, got: %#v", settings)
+	}
+	if settings["max_threads"] != 8 || settings["max_block_size"] != 1024 {
+		t.Fatalf("unexpected settings: %#v", settings)
+	}
+
+	// 设置项不应影响 SQL 构造
+	tx := execFind(t, updated)
+	sql := tx.Statement.SQL.String()
+	if !containsAll(sql, []string{"FROM `events`"}) {
+		t.Fatalf("expected SQL to use table events, got: %s", sql)
+	}
+}
+
+// TestInsertSettingsOptions 验证物化视图相关的插入设置项被正确附加到会话。
+func TestInsertSettingsOptions(t *testing.T) {
+	db := newTestDB(t)
+	updated, err := OptionDB(db, WithInsertDeduplicate(false), WithParallelViewProcessing(true))
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+
+	settings := QuerySettings(updated)
+	if settings["insert_deduplicate"] != 0 {
+		t.Errorf("expected insert_deduplicate=0, got: %v", settings["insert_deduplicate"])
+	}
+	if settings["parallel_view_processing"] != 1 {
+		t.Errorf("expected parallel_view_processing=1, got: %v", settings["parallel_view_processing"])
+	}
+
+	// 插入块大小设置，非正数被忽略
+	blocks, err := OptionDB(newTestDB(t), WithMaxInsertBlockSize(65536), WithMinInsertBlockSizeRows(1024), WithMaxInsertBlockSize(0), WithMinInsertBlockSizeRows(-1))
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+	settings = QuerySettings(blocks)
+	if settings["max_insert_block_size"] != 65536 || settings["min_insert_block_size_rows"] != 1024 {
+		t.Errorf("expected insert block size settings, got: %#v", settings)
+	}
+	if native, _ := chContextOptions(t, blocks.DB.Statement.Context); native["max_insert_block_size"] != "65536" {
+		t.Errorf("expected max_insert_block_size on insert session context, got: %#v", native)
+	}
+
+	// 未附加设置时返回空 map
+	if got := QuerySettings(newTestDB(t)); len(got) != 0 {
+		t.Errorf("expected empty settings, got: %#v", got)
+	}
+	if got := QuerySettings(nil); len(got) != 0 {
+		t.Errorf("expected empty settings for nil db, got: %#v", got)
+	}
+}
+
+// chContextOptions 通过追加一个探针 QueryOption 读取上下文中已附加的原生查询选项。
+// clickhouse-go 未导出设置与参数的读取接口，这里借助反射读取其 settings/parameters 字段。
+func chContextOptions(t *testing.T, ctx context.Context) (settings, params map[string]string) {
+	t.Helper()
+	settings, params = map[string]string{}, map[string]string{}
+	ch.Context(ctx, func(o *ch.QueryOptions) error {
+		v := reflect.ValueOf(o).Elem()
+		for name, dst := range map[string]map[string]string{"settings": settings, "parameters": params} {
+			f := v.FieldByName(name)
+			if !f.IsValid() {
+				t.Fatalf("clickhouse-go QueryOptions has no %s field", name)
+			}
+			iter := f.MapRange()
+			for iter.Next() {
+				dst[iter.Key().String()] = fmt.Sprint(iter.Value())
+			}
+		}
+		return nil
+	})
+	return settings, params
+}
+
+// TestWithCHContext 验证返回的上下文携带原生设置项与查询参数，空键被忽略，且父上下文不受影响。
+func TestWithCHContext(t *testing.T) {
+	parent := context.Background()
+	ctx := WithCHContext(parent,
+		map[string]any{"max_threads": 4, " ": 1},
+		map[string]string{"region": "cn", "": "x"},
+	)
+
+	settings, params := chContextOptions(t, ctx)
+	if len(settings) != 1 || settings["max_threads"] != "4" {
+		t.Fatalf("unexpected settings: %#v", settings)
+	}
+	if len(params) != 1 || params["region"] != "cn" {
+		t.Fatalf("unexpected parameters: %#v", params)
+	}
+
+	if s, p := chContextOptions(t, parent); len(s) != 0 || len(p) != 0 {
+		t.Fatalf("parent context should be untouched, got settings=%#v params=%#v", s, p)
+	}
+
+	// nil 上下文与空输入不应 panic
+	var nilCtx context.Context
+	if s, _ := chContextOptions(t, WithCHContext(nilCtx, nil, nil)); len(s) != 0 {
+		t.Fatalf("expected no settings, got %#v", s)
+	}
+}
+
+// TestSessionDefaults 验证 DefaultFinal 与 ForceIndexByDate 作为会话默认设置生效，
+// 查询级选项可覆盖默认值，且覆盖不会影响根会话。
+func TestSessionDefaults(t *testing.T) {
+	if got := sessionDefaults(&Config{}); got != nil {
+		t.Fatalf("expected no defaults, got %#v", got)
+	}
+
+	root := newTestDB(t)
+	root.DB = applySessionDefaults(root.DB, sessionDefaults(&Config{DefaultFinal: true, ForceIndexByDate: true}))
+
+	settings := QuerySettings(root)
+	if settings["final"] != 1 || settings["force_index_by_date"] != 1 {
+		t.Fatalf("expected session defaults, got %#v", settings)
+	}
+	native, _ := chContextOptions(t, root.DB.Statement.Context)
+	if native["final"] != "1" || native["force_index_by_date"] != "1" {
+		t.Fatalf("expected defaults on session context, got %#v", native)
+	}
+
+	updated, err := OptionDB(&DB{DB: root.DB}, WithTable("events"), WithFinal(false))
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+	settings = QuerySettings(updated)
+	if settings["final"] != 0 || settings["force_index_by_date"] != 1 {
+		t.Fatalf("expected final overridden and force_index_by_date kept, got %#v", settings)
+	}
+	native, _ = chContextOptions(t, updated.DB.Statement.Context)
+	if native["final"] != "0" || native["force_index_by_date"] != "1" {
+		t.Fatalf("expected override on query context, got %#v", native)
+	}
+
+	// 根会话保持默认值，可继续复用
+	if got := QuerySettings(root); got["final"] != 1 {
+		t.Fatalf("root session should keep defaults, got %#v", got)
+	}
+	again, err := OptionDB(&DB{DB: root.DB}, WithForceIndexByDate(false))
+	if err != nil {
+		t.Fatalf("OptionDB should not return error: %v", err)
+	}
+	if got := QuerySettings(again); got["final"] != 1 || got["force_index_by_date"] != 0 {
+		t.Fatalf("unexpected settings on second query: %#v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package clickhouse
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// queryTimeoutCallback 默认查询超时的回调注册名（执行前派生超时上下文）
+	queryTimeoutCallback = "clickhouse:query_timeout"
+	// queryTimeoutDoneCallback 默认查询超时的回调注册名（执行后释放超时上下文）
+	queryTimeoutDoneCallback = "clickhouse:query_timeout_done"
+	// queryTimeoutKey 在语句实例上保存超时状态的键
+	queryTimeoutKey = "clickhouse:query_timeout_state"
+)
+
+// queryTimeoutState 记录派生超时上下文前的原上下文及其取消函数，执行结束后据此恢复与释放。
+type queryTimeoutState struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// registerQueryTimeout 为所有执行回调注册默认查询超时：最先执行的回调为未设置截止时间的上下文派生带 timeout 的子上下文，
+// 最后执行的回调释放该子上下文并恢复原上下文。上下文已设置截止时间时以其为准，不做处理。
+func registerQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	start := func(tx *gorm.DB) { startQueryTimeout(tx, timeout) }
+	startRows := func(tx *gorm.DB) { startRowsTimeout(tx, timeout) }
+	if err := db.Callback().Create().Before("*").Register(queryTimeoutCallback, start); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("*").Register(queryTimeoutDoneCallback, finishQueryTimeout); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("*").Register(queryTimeoutCallback, start); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("*").Register(queryTimeoutDoneCallback, finishQueryTimeout); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("*").Register(queryTimeoutCallback, start); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("*").Register(queryTimeoutDoneCallback, finishQueryTimeout); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("*").Register(queryTimeoutCallback, start); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("*").Register(queryTimeoutDoneCallback, finishQueryTimeout); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("*").Register(queryTimeoutCallback, start); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("*").Register(queryTimeoutDoneCallback, finishQueryTimeout); err != nil {
+		return err
+	}
+	// Row/Rows 返回后调用方仍需通过该上下文读取结果，因此执行后只恢复原上下文，子上下文在结果集关闭、
+	// 固定连接归还后释放
+	if err := db.Callback().Row().Before("*").Register(queryTimeoutCallback, startRows); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("*").Register(queryTimeoutDoneCallback, restoreQueryContext); err != nil {
+		return err
+	}
+	return registerReleaseConn(db)
+}
+
+// startQueryTimeout 为 GORM 回调：语句上下文未设置截止时间时替换为带 timeout 的子上下文。
+// 返回记录的超时状态，未派生时返回 nil。
+func startQueryTimeout(tx *gorm.DB, timeout time.Duration) *queryTimeoutState {
+	parent := tx.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if _, ok := parent.Deadline(); ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	tx.Statement.Context = ctx
+	state := &queryTimeoutState{parent: parent, cancel: cancel}
+	tx.InstanceSet(queryTimeoutKey, state)
+	return state
+}
+
+// startRowsTimeout 为 GORM 回调（Row/Rows）：派生超时子上下文后为语句固定一个连接，
+// 并将子上下文的取消挂到连接归还上，使结果集关闭后即释放子上下文。
+// 事务等无法检出单个连接的场景下子上下文仍在超时到达时才释放；已出错或 DryRun 时不会执行查询，不做处理。
+func startRowsTimeout(tx *gorm.DB, timeout time.Duration) {
+	if tx.Error != nil || tx.DryRun {
+		return
+	}
+	state := startQueryTimeout(tx, timeout)
+	if state == nil {
+		return
+	}
+	p, err := pinConn(tx)
+	if err != nil {
+		_ = tx.AddError(err)
+		return
+	}
+	if p != nil {
+		p.onRelease = append(p.onRelease, state.cancel)
+	}
+}
+
+// finishQueryTimeout 为 GORM 回调：释放 startQueryTimeout 派生的子上下文并恢复原上下文。
+func finishQueryTimeout(tx *gorm.DB) {
+	if state := takeQueryTimeoutState(tx); state != nil {
+		state.cancel()
+		tx.Statement.Context = state.parent
+	}
+}
+
+// restoreQueryContext 为 GORM 回调（Row/Rows）：恢复原上下文；调用方仍在读取结果，因此不取消子上下文，
+// 取消由 startRowsTimeout 挂到连接归还上。执行出错时没有结果集，直接释放子上下文。
+func restoreQueryContext(tx *gorm.DB) {
+	if state := takeQueryTimeoutState(tx); state != nil {
+		tx.Statement.Context = state.parent
+		if tx.Error != nil {
+			state.cancel()
+		}
+	}
+}
+
+// takeQueryTimeoutState 取出并清除语句实例上的超时状态，避免复用同一语句时重复处理。
+func takeQueryTimeoutState(tx *gorm.DB) *queryTimeoutState {
+	v, ok := tx.InstanceGet(queryTimeoutKey)
+	if !ok {
+		return nil
+	}
+	state, _ := v.(*queryTimeoutState)
+	if state != nil {
+		tx.InstanceSet(queryTimeoutKey, (*queryTimeoutState)(nil))
+	}
+	return state
+}
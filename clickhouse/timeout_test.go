@@ -0,0 +1,104 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestDefaultQueryTimeout 使用回调模拟慢查询，验证未设置截止时间的执行在默认超时到达时被取消、
+// 显式截止时间优先，且执行结束后恢复原上下文。
+func TestDefaultQueryTimeout(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := registerQueryTimeout(gdb, 50*time.Millisecond); err != nil {
+		t.Fatalf("registerQueryTimeout failed: %v", err)
+	}
+
+	// 模拟慢查询：阻塞直到语句上下文结束，最长 1 秒
+	var deadline time.Time
+	slow := func(tx *gorm.DB) {
+		deadline, _ = tx.Statement.Context.Deadline()
+		select {
+		case <-tx.Statement.Context.Done():
+			_ = tx.AddError(tx.Statement.Context.Err())
+		case <-time.After(time.Second):
+		}
+	}
+	if err := gdb.Callback().Query().Before("gorm:query").After(queryTimeoutCallback).Register("test:slow_query", slow); err != nil {
+		t.Fatalf("failed to register slow query callback: %v", err)
+	}
+
+	start := time.Now()
+	tx := gdb.WithContext(context.Background()).Table("sqlite_master").Find(&[]map[string]any{})
+	if !errors.Is(tx.Error, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", tx.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("slow query should be cancelled at the default timeout, took %v", elapsed)
+	}
+	if _, ok := tx.Statement.Context.Deadline(); ok {
+		t.Fatal("statement context should be restored after execution")
+	}
+
+	// 显式截止时间优先于默认超时
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	tx = gdb.WithContext(ctx).Table("sqlite_master").Find(&[]map[string]any{})
+	if !errors.Is(tx.Error, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", tx.Error)
+	}
+	if !deadline.Equal(want) {
+		t.Fatalf("explicit deadline should take precedence, expected %v, got %v", want, deadline)
+	}
+}
+
+// TestRowsTimeoutReleasedOnClose 验证 Row/Rows 派生的超时子上下文在结果集读取期间保持有效，
+// 并在结果集关闭后立即释放，而不是等到超时到达。
+func TestRowsTimeoutReleasedOnClose(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := registerQueryTimeout(gdb, time.Minute); err != nil {
+		t.Fatalf("registerQueryTimeout failed: %v", err)
+	}
+
+	var ctx context.Context
+	capture := func(tx *gorm.DB) { ctx = tx.Statement.Context }
+	if err := gdb.Callback().Row().Before("gorm:row").After(queryTimeoutCallback).Register("test:capture_context", capture); err != nil {
+		t.Fatalf("failed to register capture callback: %v", err)
+	}
+
+	rows, err := gdb.WithContext(context.Background()).Table("sqlite_master").Rows()
+	if err != nil {
+		t.Fatalf("Rows failed: %v", err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("rows query should run with the default timeout")
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("timeout context should stay alive while rows are open, got %v", ctx.Err())
+	}
+	for rows.Next() {
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("rows.Close failed: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timeout context should be released after rows are closed")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", ctx.Err())
+	}
+}
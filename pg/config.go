@@ -1,17 +1,121 @@
 package pg
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Config struct {
-	Debug        bool   // 是否开启调试模式，默认 false
-	AutoMigrate  bool   // 是否自动迁移数据库结构，默认 false
-	SSLMode      string // disable, require, verify-ca, verify-full
-	Type         string // 数据库类型，默认 postgres
-	Host         string // 数据库主机，默认 localhost
-	Port         string // 数据库端口，默认 5432
-	Username     string // 数据库用户名
-	Password     string // 数据库密码
-	DBName       string // 数据库名称
-	MaxLifetime  int    // 连接最大生命周期，默认 5 分钟
-	MaxOpenConns int    // 最大打开连接数，默认 100
-	MaxIdleConns int    // 最大空闲连接数，默认 100
-	TimeZone     string // 时区，默认 Asia/Shanghai
+	Debug         bool   // 是否开启调试模式，默认 false
+	AutoMigrate   bool   // 是否自动迁移数据库结构，默认 false
+	SSLMode       string // disable, require, verify-ca, verify-full
+	Type          string // 数据库类型，默认 postgres
+	Host          string // 数据库主机，默认 localhost
+	Port          string // 数据库端口，默认 5432
+	Username      string // 数据库用户名
+	Password      string // 数据库密码
+	DBName        string // 数据库名称
+	MaxLifetime   int    // 连接最大生命周期，默认 5 分钟
+	MaxOpenConns  int    // 最大打开连接数，默认 100
+	MaxIdleConns  int    // 最大空闲连接数，默认 100
+	MaxIdleTime   int    // 连接最大空闲时间（秒），超时的空闲连接会被回收，默认 0 表示不限制
+	TablePrefix   string // 表名前缀（如 "app_"），默认无前缀
+	SingularTable bool   // 是否使用单数表名（user 而非 users），默认 false
+	TimeZone      string // 时区，默认 Asia/Shanghai
+	// DefaultQueryTimeout 默认查询超时，大于 0 时每次执行（查询、写入、原生 SQL 等）在上下文未设置截止时间的情况下
+	// 自动派生带该超时的子上下文，防止失控查询长期占用连接；传入的上下文已设置截止时间时以其为准，默认 0 表示不限制
+	DefaultQueryTimeout time.Duration
+}
+
+// DefaultConfig 返回预填充文档默认值的配置（端口 5432、时区 Asia/Shanghai、连接池 100/100、生命周期 300 秒），
+// 调用方只需覆盖主机、账号、数据库名等差异项即可。
+func DefaultConfig() *Config {
+	return &Config{
+		Type:         "postgres",
+		Host:         "localhost",
+		Port:         "5432",
+		SSLMode:      "disable",
+		MaxLifetime:  300,
+		MaxOpenConns: 100,
+		MaxIdleConns: 100,
+		TimeZone:     "Asia/Shanghai",
+	}
+}
+
+// validSSLModes libpq 支持的 sslmode 取值，空字符串表示使用驱动默认值
+var validSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Validate 验证配置参数的有效性，NewDB 在建立连接前调用。
+// 规则：主机非空；端口为 1-65535 的数字（为空时补全为 5432）；用户名与数据库名必填；SSLMode 为空或属于 libpq 支持的取值；
+// 连接池参数小于等于 0 时补全为默认值（MaxLifetime 300 秒、MaxOpenConns 100、MaxIdleConns 100 且不超过 MaxOpenConns），显式设置的 MaxIdleConns 不得大于 MaxOpenConns。
+// 全部问题汇总到一个配置错误中返回，可用 IsConfigError 判断。
+func (c *Config) Validate() error {
+	if c == nil {
+		return NewConfigError("config cannot be nil", nil)
+	}
+
+	var errs []string
+
+	if strings.TrimSpace(c.Host) == "" {
+		errs = append(errs, "host cannot be empty")
+	}
+
+	if c.Port == "" {
+		c.Port = "5432" // 设置默认值
+	} else if port, err := strconv.Atoi(c.Port); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid port format: %q", c.Port))
+	} else if port < 1 || port > 65535 {
+		errs = append(errs, fmt.Sprintf("port must be between 1 and 65535, got: %d", port))
+	}
+
+	if strings.TrimSpace(c.Username) == "" {
+		errs = append(errs, "username cannot be empty")
+	}
+	if strings.TrimSpace(c.DBName) == "" {
+		errs = append(errs, "database name cannot be empty")
+	}
+
+	if c.SSLMode != "" && !containsString(validSSLModes, c.SSLMode) {
+		errs = append(errs, fmt.Sprintf("invalid SSL mode: %s, valid modes: %v", c.SSLMode, validSSLModes))
+	}
+
+	// 验证连接池参数：零值补全为文档默认值，避免 MaxOpenConns=0 意外成为不限制、MaxLifetime=0 关闭连接回收
+	if c.MaxLifetime <= 0 {
+		c.MaxLifetime = 300 // 默认5分钟
+	}
+
+	if c.MaxOpenConns <= 0 {
+		c.MaxOpenConns = 100 // 默认值
+	}
+
+	if c.MaxIdleConns <= 0 {
+		c.MaxIdleConns = min(100, c.MaxOpenConns) // 默认值，不超过 MaxOpenConns
+	} else if c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, "MaxIdleConns should not be greater than MaxOpenConns")
+	}
+
+	if c.MaxIdleTime < 0 {
+		errs = append(errs, "MaxIdleTime cannot be negative")
+	}
+
+	if len(errs) > 0 {
+		return NewConfigError(fmt.Sprintf("config validation failed: %s", strings.Join(errs, "; ")), nil).
+			WithContext("host", c.Host).
+			WithContext("port", c.Port).
+			WithContext("database", c.DBName)
+	}
+
+	return nil
+}
+
+// containsString 检查 s 是否在 list 中
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
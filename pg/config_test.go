@@ -0,0 +1,76 @@
+package pg
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDefaultConfig 验证 DefaultConfig 返回文档约定的默认值。
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Host != "localhost" || cfg.Port != "5432" || cfg.TimeZone != "Asia/Shanghai" || cfg.SSLMode != "disable" {
+		t.Fatalf("unexpected connection defaults: %+v", cfg)
+	}
+	if cfg.MaxLifetime != 300 || cfg.MaxOpenConns != 100 || cfg.MaxIdleConns != 100 {
+		t.Fatalf("unexpected pool defaults: %+v", cfg)
+	}
+	if DefaultConfig() == cfg {
+		t.Fatal("DefaultConfig should return a new instance on each call")
+	}
+}
+
+// TestConfigValidate 验证 Validate 对必填项、端口范围与 SSLMode 的校验，以及 NewDB 在连接前拒绝无效配置。
+func TestConfigValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := DefaultConfig()
+		cfg.Username = "app"
+		cfg.DBName = "appdb"
+		return cfg
+	}
+
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg := valid()
+	cfg.Port = ""
+	if err := cfg.Validate(); err != nil || cfg.Port != "5432" {
+		t.Fatalf("expected empty port to default to 5432, got port=%q err=%v", cfg.Port, err)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(*Config)
+		want   string
+	}{
+		{"empty host", func(c *Config) { c.Host = " " }, "host cannot be empty"},
+		{"non-numeric port", func(c *Config) { c.Port = "54x2" }, "invalid port format"},
+		{"port zero", func(c *Config) { c.Port = "0" }, "port must be between 1 and 65535"},
+		{"port too large", func(c *Config) { c.Port = "65536" }, "port must be between 1 and 65535"},
+		{"empty username", func(c *Config) { c.Username = "" }, "username cannot be empty"},
+		{"empty dbname", func(c *Config) { c.DBName = "" }, "database name cannot be empty"},
+		{"invalid sslmode", func(c *Config) { c.SSLMode = "on" }, "invalid SSL mode"},
+	}
+	for _, tc := range cases {
+		cfg := valid()
+		tc.mutate(cfg)
+		err := cfg.Validate()
+		if !IsConfigError(err) {
+			t.Fatalf("%s: expected config error, got %v", tc.name, err)
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected %q in error, got %v", tc.name, tc.want, err)
+		}
+		if _, err := NewDB(cfg); !IsConfigError(err) {
+			t.Fatalf("%s: expected NewDB to return config error, got %v", tc.name, err)
+		}
+	}
+
+	var nilCfg *Config
+	if err := nilCfg.Validate(); !IsConfigError(err) {
+		t.Fatalf("expected config error for nil config, got %v", err)
+	}
+	if _, err := NewDB(nil); !IsConfigError(err) {
+		t.Fatalf("expected NewDB(nil) to return config error, got %v", err)
+	}
+}
@@ -1,22 +1,43 @@
 package pg
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
+// Database 抽象 clickhouse、pg、sqlite 三个驱动包中 *DB 的公共能力。
+// 三个包定义的 Database 方法集完全一致，应用可依赖该接口（或自行声明同样的接口）编写与驱动无关的代码并按需切换驱动。
+type Database interface {
+	// Ping 检查数据库连通性
+	Ping(ctx context.Context) error
+	// Close 关闭底层连接池
+	Close() error
+	// Gorm 返回底层 *gorm.DB，用于构造查询
+	Gorm() *gorm.DB
+	// AutoMigrate 迁移模型结构（pg 包的实现仅在 Config.AutoMigrate 开启时执行）
+	AutoMigrate(models ...any) error
+}
+
 type DB struct {
     *gorm.DB
     autoMigrate bool
 }
 
 func NewDB(config *Config) (*DB, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
 	dial := dial(config)
-	db, err := gorm.Open(dial, &gorm.Config{})
+	db, err := gorm.Open(dial, newGormConfig(config))
 	if err != nil {
 		return nil, err
 	}
@@ -30,30 +51,86 @@ func NewDB(config *Config) (*DB, error) {
 		return nil, err
 	}
 
-	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
-	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(config.MaxLifetime))
+	configureConnectionPool(sqlDB, config)
+
+	if config.DefaultQueryTimeout > 0 {
+		if err := registerQueryTimeout(db, config.DefaultQueryTimeout); err != nil {
+			return nil, err
+		}
+	}
 
     return &DB{DB: db, autoMigrate: config.AutoMigrate}, nil
 }
 
+// connPool 抽象连接池参数设置方法（由 *sql.DB 实现），便于测试中替换。
+type connPool interface {
+	SetMaxIdleConns(n int)
+	SetMaxOpenConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+	SetConnMaxIdleTime(d time.Duration)
+}
+
+// configureConnectionPool 按配置设置连接池参数：最大空闲/打开连接数、连接生命周期与最大空闲时间。
+func configureConnectionPool(p connPool, cfg *Config) {
+	p.SetMaxIdleConns(cfg.MaxIdleConns)
+	p.SetMaxOpenConns(cfg.MaxOpenConns)
+	p.SetConnMaxLifetime(time.Second * time.Duration(cfg.MaxLifetime))
+	p.SetConnMaxIdleTime(time.Second * time.Duration(cfg.MaxIdleTime))
+}
+
+// newGormConfig 根据配置构建 gorm.Config，通过 NamingStrategy 应用表名前缀与单数表名设置。
+// 两项均未配置时与 GORM 默认命名（复数 snake_case）一致。
+func newGormConfig(cfg *Config) *gorm.Config {
+	return &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{
+			TablePrefix:         cfg.TablePrefix,
+			SingularTable:       cfg.SingularTable,
+			IdentifierMaxLength: 64,
+		},
+	}
+}
+
 func dial(cfg *Config) gorm.Dialector {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s TimeZone=%s",
-		cfg.Host,
-		cfg.Port,
-		cfg.Username,
-		cfg.DBName,
-		cfg.Password,
-		cfg.SSLMode,
-		cfg.TimeZone,
-	)
 	dialector := postgres.New(postgres.Config{
-		DSN:                  dsn,
+		DSN:                  buildDSN(cfg),
 		PreferSimpleProtocol: true,
 	})
     return dialector
 }
 
+// buildDSN 构建 key=value 形式的 DSN，各值按 libpq 规则转义，密码等含空格或引号的值不会破坏 DSN 结构。
+// 未设置的 sslmode 与 TimeZone 不写入 DSN，由驱动使用默认值。
+func buildDSN(cfg *Config) string {
+	pairs := []struct{ key, value string }{
+		{"host", cfg.Host},
+		{"port", cfg.Port},
+		{"user", cfg.Username},
+		{"dbname", cfg.DBName},
+		{"password", cfg.Password},
+		{"sslmode", cfg.SSLMode},
+		{"TimeZone", cfg.TimeZone},
+	}
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if p.value == "" && (p.key == "sslmode" || p.key == "TimeZone") {
+			continue
+		}
+		parts = append(parts, p.key+"="+dsnValue(p.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// dsnQuoter 转义 DSN 值中的反斜杠与单引号
+var dsnQuoter = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// dsnValue 按 libpq key=value 规则处理值：空值或包含空白、单引号、反斜杠的值用单引号包裹并转义，其余原样返回。
+func dsnValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, " \t\n\r'\\") {
+		return v
+	}
+	return "'" + dsnQuoter.Replace(v) + "'"
+}
+
 // AutoMigrate 在开启了自动迁移标志时执行模型结构迁移；
 // 当未开启或未提供模型时直接返回 nil，避免误迁移与不必要的操作。
 func (d *DB) AutoMigrate(models ...any) error {
@@ -67,3 +144,68 @@ func (d *DB) AutoMigrate(models ...any) error {
     }
     return d.DB.AutoMigrate(models...)
 }
+
+// Ping 检查数据库连通性，调用方可通过带超时的 ctx 控制最长等待时间。
+// 失败时返回 PGError：ctx 超时为 PING_TIMEOUT 超时错误，ctx 取消为 PING_CANCELED 连接错误，
+// 其余为 PING_FAILED 连接错误；d 为空时返回 DB_NIL 连接错误。
+func (d *DB) Ping(ctx context.Context) error {
+	if d == nil || d.DB == nil {
+		return NewConnectionError("database instance is nil", nil).
+			WithCode("DB_NIL")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return NewConnectionError("failed to get underlying SQL database connection", err).
+			WithCode("SQLDB_GET_FAILED")
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		// 检查是否为上下文超时错误
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return NewTimeoutError("database ping timed out", err).
+				WithCode("PING_TIMEOUT")
+		}
+
+		// 检查是否为上下文取消错误
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return NewConnectionError("database ping was canceled", err).
+				WithContext("context", "canceled").
+				WithCode("PING_CANCELED")
+		}
+
+		// 其他连接错误
+		return NewConnectionError("database ping failed", err).
+			WithCode("PING_FAILED")
+	}
+
+	return nil
+}
+
+// Close 关闭底层连接池，关闭后该实例不可再使用。
+func (d *DB) Close() error {
+	sqlDB, err := d.sqlDB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Gorm 返回底层 *gorm.DB；d 为空时返回 nil。
+func (d *DB) Gorm() *gorm.DB {
+	if d == nil {
+		return nil
+	}
+	return d.DB
+}
+
+// sqlDB 返回底层 *sql.DB 连接池。
+func (d *DB) sqlDB() (*sql.DB, error) {
+	if d == nil || d.DB == nil {
+		return nil, errors.New("database instance is nil")
+	}
+	return d.DB.DB()
+}
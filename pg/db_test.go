@@ -1,8 +1,12 @@
 package pg
 
 import (
+    "context"
+    "errors"
     "testing"
+    "time"
 
+    "github.com/jackc/pgx/v5"
     "gorm.io/driver/sqlite"
     "gorm.io/gorm"
 )
@@ -35,4 +39,218 @@ func TestAutoMigrateDisabled(t *testing.T) {
     if err := d.AutoMigrate(&UserM{}); err != nil {
         t.Fatalf("expected nil when autoMigrate disabled, got: %v", err)
     }
-}
\ No newline at end of file
+}
+// recordingPool 记录连接池参数设置调用，用于验证 MaxIdleTime 被传递。
+type recordingPool struct {
+    idle, open        int
+    lifetime, idleTTL time.Duration
+}
+
+func (p *recordingPool) SetMaxIdleConns(n int)              { p.idle = n }
+func (p *recordingPool) SetMaxOpenConns(n int)              { p.open = n }
+func (p *recordingPool) SetConnMaxLifetime(d time.Duration) { p.lifetime = d }
+func (p *recordingPool) SetConnMaxIdleTime(d time.Duration) { p.idleTTL = d }
+
+// TestConfigureConnectionPoolIdleTime 验证 MaxIdleTime 以秒为单位设置到连接池，并可作用于真实的 *sql.DB。
+func TestConfigureConnectionPoolIdleTime(t *testing.T) {
+    cfg := &Config{MaxIdleConns: 5, MaxOpenConns: 10, MaxLifetime: 300, MaxIdleTime: 60}
+    p := &recordingPool{}
+    configureConnectionPool(p, cfg)
+    if p.idleTTL != time.Minute || p.lifetime != 5*time.Minute || p.open != 10 || p.idle != 5 {
+        t.Fatalf("unexpected pool settings: %+v", p)
+    }
+
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    configureConnectionPool(sqlDB, cfg)
+    if stats := sqlDB.Stats(); stats.MaxOpenConnections != 10 {
+        t.Fatalf("expected MaxOpenConnections=10, got %d", stats.MaxOpenConnections)
+    }
+}
+
+// TestConnectionPoolDefaults 验证零值连接池参数经 Validate 补全为文档默认值后作用于 *sql.DB，以及 MaxIdleConns 大于 MaxOpenConns 时返回配置错误。
+func TestConnectionPoolDefaults(t *testing.T) {
+    cfg := &Config{Host: "localhost", Username: "app", DBName: "appdb"}
+    if err := cfg.Validate(); err != nil {
+        t.Fatalf("validate failed: %v", err)
+    }
+    p := &recordingPool{}
+    configureConnectionPool(p, cfg)
+    if p.open != 100 || p.idle != 100 || p.lifetime != 300*time.Second || p.idleTTL != 0 {
+        t.Fatalf("unexpected pool settings: %+v", p)
+    }
+
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    configureConnectionPool(sqlDB, cfg)
+    if stats := sqlDB.Stats(); stats.MaxOpenConnections != 100 {
+        t.Fatalf("expected MaxOpenConnections=100, got %d", stats.MaxOpenConnections)
+    }
+
+    // 仅设置较小的 MaxOpenConns 时，默认空闲连接数不超过它
+    cfg = &Config{Host: "localhost", Username: "app", DBName: "appdb", MaxOpenConns: 10}
+    if err := cfg.Validate(); err != nil || cfg.MaxIdleConns != 10 {
+        t.Fatalf("expected MaxIdleConns=10, got %d (err=%v)", cfg.MaxIdleConns, err)
+    }
+
+    cfg = &Config{Host: "localhost", Username: "app", DBName: "appdb", MaxOpenConns: 10, MaxIdleConns: 20}
+    if err := cfg.Validate(); !IsConfigError(err) {
+        t.Fatalf("expected config error for MaxIdleConns > MaxOpenConns, got %v", err)
+    }
+    cfg = &Config{Host: "localhost", Username: "app", DBName: "appdb", MaxIdleTime: -1}
+    if err := cfg.Validate(); !IsConfigError(err) {
+        t.Fatalf("expected config error for negative MaxIdleTime, got %v", err)
+    }
+}
+
+// namingProbe 用于验证命名策略的测试模型
+type namingProbe struct {
+    ID   uint
+    Name string
+}
+
+// TestNewGormConfigNaming 验证 TablePrefix 与 SingularTable 通过 NamingStrategy 作用于迁移生成的表名。
+func TestNewGormConfigNaming(t *testing.T) {
+    cases := []struct {
+        cfg   *Config
+        table string
+    }{
+        {cfg: &Config{}, table: "naming_probes"},
+        {cfg: &Config{SingularTable: true}, table: "naming_probe"},
+        {cfg: &Config{TablePrefix: "app_", SingularTable: true}, table: "app_naming_probe"},
+        {cfg: &Config{TablePrefix: "app_"}, table: "app_naming_probes"},
+    }
+    for _, c := range cases {
+        gdb, err := gorm.Open(sqlite.Open(":memory:"), newGormConfig(c.cfg))
+        if err != nil {
+            t.Fatalf("failed to open sqlite: %v", err)
+        }
+        sqlDB, err := gdb.DB()
+        if err != nil {
+            t.Fatalf("failed to get sql.DB: %v", err)
+        }
+        sqlDB.SetMaxOpenConns(1)
+        if err := gdb.AutoMigrate(&namingProbe{}); err != nil {
+            t.Fatalf("auto migrate failed: %v", err)
+        }
+        if !gdb.Migrator().HasTable(c.table) {
+            t.Errorf("expected table %q for config %+v", c.table, c.cfg)
+        }
+        sqlDB.Close()
+    }
+}
+
+// 编译期断言：*DB 实现 Database 接口
+var _ Database = (*DB)(nil)
+
+// TestDatabaseInterface 验证 Database 接口方法：Gorm 返回底层实例，Ping 在关闭前成功、关闭后失败；空实例返回错误。
+func TestDatabaseInterface(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    var d Database = &DB{DB: gdb}
+    if d.Gorm() != gdb {
+        t.Fatal("Gorm should return the underlying *gorm.DB")
+    }
+    if err := d.Ping(context.Background()); err != nil {
+        t.Fatalf("Ping failed: %v", err)
+    }
+    if err := d.Close(); err != nil {
+        t.Fatalf("Close failed: %v", err)
+    }
+    if err := d.Ping(context.Background()); !IsConnectionError(err) {
+        t.Fatalf("Ping after Close should return connection error, got: %v", err)
+    }
+
+    var empty *DB
+    if empty.Gorm() != nil {
+        t.Fatal("Gorm on nil DB should return nil")
+    }
+    if err := empty.Close(); err == nil {
+        t.Fatalf("Close on nil DB should fail, got: %v", err)
+    }
+    if err := empty.Ping(context.Background()); !IsConnectionError(err) {
+        t.Fatalf("Ping on nil DB should return connection error, got: %v", err)
+    }
+}
+
+// TestPingContextErrors 验证 Ping 按上下文状态返回带错误代码的错误：取消为 PING_CANCELED，超时为 PING_TIMEOUT。
+func TestPingContextErrors(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    d := &DB{DB: gdb}
+    defer d.Close()
+
+    if err := d.Ping(context.Background()); err != nil {
+        t.Fatalf("Ping failed: %v", err)
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    err = d.Ping(ctx)
+    if !IsConnectionError(err) || !errors.Is(err, &PGError{Type: ErrorTypeConnection, Code: "PING_CANCELED"}) {
+        t.Fatalf("expected PING_CANCELED connection error, got: %v", err)
+    }
+    if !errors.Is(err, context.Canceled) {
+        t.Fatalf("expected error to wrap context.Canceled, got: %v", err)
+    }
+
+    ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+    defer cancel()
+    err = d.Ping(ctx)
+    if !IsTimeoutError(err) || !errors.Is(err, &PGError{Type: ErrorTypeTimeout, Code: "PING_TIMEOUT"}) {
+        t.Fatalf("expected PING_TIMEOUT timeout error, got: %v", err)
+    }
+}
+
+// TestBuildDSNQuoting 验证 DSN 中含空格、单引号、反斜杠的值被单引号包裹并转义，空的 sslmode/TimeZone 不写入 DSN。
+func TestBuildDSNQuoting(t *testing.T) {
+    cfg := &Config{
+        Host:     "127.0.0.1",
+        Port:     "5432",
+        Username: "app",
+        DBName:   "appdb",
+        Password: `p@ss word's\x`,
+        SSLMode:  "disable",
+        TimeZone: "Asia/Shanghai",
+    }
+    want := `host=127.0.0.1 port=5432 user=app dbname=appdb password='p@ss word\'s\\x' sslmode=disable TimeZone=Asia/Shanghai`
+    if got := buildDSN(cfg); got != want {
+        t.Fatalf("unexpected dsn:\n got: %s\nwant: %s", got, want)
+    }
+
+    cfg.Password = ""
+    cfg.SSLMode = ""
+    cfg.TimeZone = ""
+    want = `host=127.0.0.1 port=5432 user=app dbname=appdb password=''`
+    if got := buildDSN(cfg); got != want {
+        t.Fatalf("unexpected dsn:\n got: %s\nwant: %s", got, want)
+    }
+
+    // pgx 按 libpq 规则解析后应还原原始密码
+    cfg.Password = `p@ss word's\x`
+    pgxCfg, err := pgx.ParseConfig(buildDSN(cfg))
+    if err != nil {
+        t.Fatalf("failed to parse dsn: %v", err)
+    }
+    if pgxCfg.Password != cfg.Password {
+        t.Fatalf("expected password %q after parsing, got %q", cfg.Password, pgxCfg.Password)
+    }
+}
@@ -0,0 +1,198 @@
+package pg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrorType 定义错误的分类类型
+type ErrorType string
+
+const (
+	// ErrorTypeQuery 查询相关错误
+	ErrorTypeQuery ErrorType = "query"
+	// ErrorTypeValidation 参数验证错误
+	ErrorTypeValidation ErrorType = "validation"
+	// ErrorTypeConfig 配置相关错误
+	ErrorTypeConfig ErrorType = "config"
+	// ErrorTypeConnection 连接相关错误
+	ErrorTypeConnection ErrorType = "connection"
+	// ErrorTypeTimeout 超时错误
+	ErrorTypeTimeout ErrorType = "timeout"
+)
+
+// PGError 自定义错误结构，与 clickhouse 包的 ClickHouseError 保持一致的分类方式
+type PGError struct {
+	Type    ErrorType
+	Message string
+	Cause   error
+	Context map[string]interface{}
+	Code    string // 可选的错误代码
+}
+
+// Error 实现 error 接口，上下文按键名排序输出
+func (e *PGError) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("[%s] %s", e.Type, e.Message))
+
+	if e.Code != "" {
+		builder.WriteString(fmt.Sprintf(" (code: %s)", e.Code))
+	}
+
+	if e.Cause != nil {
+		builder.WriteString(fmt.Sprintf(": %v", e.Cause))
+	}
+
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		builder.WriteString(" | context: ")
+		for i, k := range keys {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(fmt.Sprintf("%s=%v", k, e.Context[k]))
+		}
+	}
+
+	return builder.String()
+}
+
+// Unwrap 支持 errors.Unwrap
+func (e *PGError) Unwrap() error {
+	return e.Cause
+}
+
+// Is 支持 errors.Is：类型与错误代码均相同时视为匹配
+func (e *PGError) Is(target error) bool {
+	if pgErr, ok := target.(*PGError); ok {
+		return e.Type == pgErr.Type && e.Code == pgErr.Code
+	}
+	return false
+}
+
+// NewPGError 创建新的 pg 错误
+func NewPGError(errType ErrorType, message string, cause error) *PGError {
+	return &PGError{
+		Type:    errType,
+		Message: message,
+		Cause:   cause,
+		Context: make(map[string]interface{}),
+	}
+}
+
+// WithContext 添加上下文信息
+func (e *PGError) WithContext(key string, value interface{}) *PGError {
+	if e.Context == nil {
+		e.Context = make(map[string]interface{})
+	}
+	e.Context[key] = value
+	return e
+}
+
+// WithCode 设置错误代码
+func (e *PGError) WithCode(code string) *PGError {
+	e.Code = code
+	return e
+}
+
+// IsQueryError 判断是否为查询错误
+func IsQueryError(err error) bool {
+	var pgErr *PGError
+	if errors.As(err, &pgErr) {
+		return pgErr.Type == ErrorTypeQuery
+	}
+	return false
+}
+
+// IsValidationError 判断是否为验证错误
+func IsValidationError(err error) bool {
+	var pgErr *PGError
+	if errors.As(err, &pgErr) {
+		return pgErr.Type == ErrorTypeValidation
+	}
+	return false
+}
+
+// IsConfigError 判断是否为配置错误
+func IsConfigError(err error) bool {
+	var pgErr *PGError
+	if errors.As(err, &pgErr) {
+		return pgErr.Type == ErrorTypeConfig
+	}
+	return false
+}
+
+// IsConnectionError 判断是否为连接错误
+func IsConnectionError(err error) bool {
+	var pgErr *PGError
+	if errors.As(err, &pgErr) {
+		return pgErr.Type == ErrorTypeConnection
+	}
+	return false
+}
+
+// IsTimeoutError 判断是否为超时错误
+func IsTimeoutError(err error) bool {
+	var pgErr *PGError
+	if errors.As(err, &pgErr) {
+		return pgErr.Type == ErrorTypeTimeout
+	}
+	return false
+}
+
+// WrapError 包装现有错误为 PGError；err 已是 PGError 时保留其类型与代码，只在消息前追加说明
+func WrapError(err error, errType ErrorType, message string) *PGError {
+	if err == nil {
+		return NewPGError(errType, message, nil)
+	}
+
+	if pgErr, ok := err.(*PGError); ok {
+		return &PGError{
+			Type:    pgErr.Type,
+			Message: message + ": " + pgErr.Message,
+			Cause:   pgErr.Cause,
+			Context: pgErr.Context,
+			Code:    pgErr.Code,
+		}
+	}
+
+	return NewPGError(errType, message, err)
+}
+
+// NewQueryError 创建查询错误
+func NewQueryError(message string, cause error) *PGError {
+	return NewPGError(ErrorTypeQuery, message, cause).
+		WithCode("QUERY_ERROR")
+}
+
+// NewValidationError 创建验证错误
+func NewValidationError(message string, cause error) *PGError {
+	return NewPGError(ErrorTypeValidation, message, cause).
+		WithCode("VALIDATION_ERROR")
+}
+
+// NewConfigError 创建配置错误
+func NewConfigError(message string, cause error) *PGError {
+	return NewPGError(ErrorTypeConfig, message, cause).
+		WithCode("CONFIG_ERROR")
+}
+
+// NewConnectionError 创建连接错误
+func NewConnectionError(message string, cause error) *PGError {
+	return NewPGError(ErrorTypeConnection, message, cause).
+		WithCode("CONN_ERROR")
+}
+
+// NewTimeoutError 创建超时错误
+func NewTimeoutError(message string, cause error) *PGError {
+	return NewPGError(ErrorTypeTimeout, message, cause).
+		WithCode("TIMEOUT_ERROR")
+}
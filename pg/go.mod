@@ -2,12 +2,14 @@ module github.com/amuluze/conan/pg
 
 go 1.24
 
-require gorm.io/gorm v1.31.1
+require (
+	github.com/jackc/pgx/v5 v5.6.0
+	gorm.io/gorm v1.31.1
+)
 
 require (
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/jackc/pgx/v5 v5.6.0 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	golang.org/x/crypto v0.31.0 // indirect
@@ -0,0 +1,82 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationsTable 记录已执行迁移 ID 的表名。
+const migrationsTable = "schema_migrations"
+
+// Migration 描述一条以原生 SQL 表达的迁移，用于 AutoMigrate 无法覆盖的场景（带选项的索引、CHECK 约束等）。
+type Migration struct {
+	ID string // 迁移唯一标识，建议使用有序前缀（如 0001_create_users）
+	Up string // 待执行的 SQL 语句
+}
+
+// RunMigrations 按顺序执行尚未应用的迁移，并将成功执行的 ID 记录到 schema_migrations 表中（不存在时自动创建）。
+// 每条迁移与其记录在同一事务中完成，重复调用时已记录的迁移会被跳过；任一迁移失败时立即返回错误，后续迁移不再执行。
+func RunMigrations(ctx context.Context, db *DB, migrations []Migration) error {
+	if db == nil || db.DB == nil {
+		return fmt.Errorf("database instance cannot be nil")
+	}
+	if err := validateMigrations(migrations); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx := db.DB.WithContext(ctx)
+	if err := tx.Exec("CREATE TABLE IF NOT EXISTS " + migrationsTable +
+		" (id VARCHAR(255) PRIMARY KEY, applied_at TIMESTAMP NOT NULL)").Error; err != nil {
+		return fmt.Errorf("failed to create %s table: %w", migrationsTable, err)
+	}
+
+	var applied []string
+	if err := tx.Table(migrationsTable).Pluck("id", &applied).Error; err != nil {
+		return fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+	done := make(map[string]struct{}, len(applied))
+	for _, id := range applied {
+		done[id] = struct{}{}
+	}
+
+	for _, m := range migrations {
+		if _, ok := done[m.ID]; ok {
+			continue
+		}
+		err := tx.Transaction(func(t *gorm.DB) error {
+			if err := t.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return t.Exec("INSERT INTO "+migrationsTable+" (id, applied_at) VALUES (?, ?)", m.ID, time.Now()).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.ID, err)
+		}
+	}
+	return nil
+}
+
+// validateMigrations 校验迁移 ID 非空且不重复、SQL 非空。
+func validateMigrations(migrations []Migration) error {
+	seen := make(map[string]struct{}, len(migrations))
+	for i, m := range migrations {
+		if strings.TrimSpace(m.ID) == "" {
+			return fmt.Errorf("migration at index %d has empty id", i)
+		}
+		if _, ok := seen[m.ID]; ok {
+			return fmt.Errorf("duplicate migration id: %s", m.ID)
+		}
+		seen[m.ID] = struct{}{}
+		if strings.TrimSpace(m.Up) == "" {
+			return fmt.Errorf("migration %s has empty SQL", m.ID)
+		}
+	}
+	return nil
+}
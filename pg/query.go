@@ -1,8 +1,17 @@
 package pg
 
 import (
+    "fmt"
     "reflect"
+    "regexp"
+    "strconv"
     "strings"
+    "time"
+    "unicode"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "gorm.io/gorm/schema"
 )
 
 // QueryOption 定义对 *DB 进行链式包装的函数类型，返回经变更后的 *DB，便于组合多个查询配置。
@@ -11,19 +20,61 @@ type QueryOption func(db *DB) *DB
 
 // OptionDB 按序应用一组 QueryOption 到传入的 *DB 并返回更新后的 *DB。
 // 为提升健壮性：
-// 1) 当 db 为 nil 时直接返回 nil，避免后续调用产生 panic；
-// 2) 跳过为 nil 的选项，保证调用安全。
-func OptionDB(db *DB, options ...QueryOption) *DB {
+// 1) 当 db 为 nil 时返回查询错误；
+// 2) 跳过为 nil 的选项，保证调用安全；
+// 3) 捕获选项中的 panic 并以查询错误返回，避免崩溃调用方；选项返回 nil 时同样返回错误。
+func OptionDB(db *DB, options ...QueryOption) (*DB, error) {
 	if db == nil {
-		return nil
+		return nil, NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+
+	if db.DB == nil {
+		return nil, NewQueryError("gorm database instance cannot be nil", nil).
+			WithCode("GORM_DB_NIL")
 	}
-	for _, option := range options {
+
+	var lastError error
+	for i, option := range options {
 		if option == nil {
 			continue
 		}
-		db = option(db)
+
+		// 在应用选项时进行错误捕获
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					lastError = NewQueryError(fmt.Sprintf("panic applying query option at index %d", i),
+						fmt.Errorf("panic: %v", r)).
+						WithCode("QUERY_OPTION_PANIC").
+						WithContext("option_index", i)
+				}
+			}()
+			db = option(db)
+		}()
+
+		if db == nil {
+			return nil, NewQueryError(fmt.Sprintf("query option at index %d returned nil database", i), lastError).
+				WithCode("QUERY_OPTION_NIL_RESULT").
+				WithContext("option_index", i)
+		}
+	}
+
+	if lastError != nil {
+		return db, WrapError(lastError, ErrorTypeQuery, "some query options failed to apply")
 	}
-	return db
+
+	return db, nil
+}
+
+// OptionDBMust 与 OptionDB 功能相同，但不返回错误，用于向后兼容旧版 OptionDB 的单返回值签名：
+// 出错时返回传入的 db（db 为 nil 时即返回 nil）。需要感知选项 panic 等错误时请使用 OptionDB。
+func OptionDBMust(db *DB, options ...QueryOption) *DB {
+	result, err := OptionDB(db, options...)
+	if err != nil {
+		return db
+	}
+	return result
 }
 
 // 注意：从当前版本起移除旧接口 WithTable，请使用 WithTableSafe 强制白名单校验以防止 SQL 注入。
@@ -32,7 +83,8 @@ func OptionDB(db *DB, options ...QueryOption) *DB {
 // 规则：
 // - 空白表名会被忽略；
 // - 不在白名单中的表名会被忽略；
-// - 仅当 tableName 存在于 whitelist 时才会应用。
+// - 超过 64 字符、格式不合法（须以字母开头，仅含字母、数字、下划线）或为 SQL 保留字的表名会被忽略；
+// - 仅当 tableName 存在于 whitelist 且通过校验时才会应用。
 // 使用建议：优先使用该函数替代 WithTable。
 func WithTableSafe(tableName string, whitelist map[string]struct{}) QueryOption {
     return func(db *DB) *DB {
@@ -44,18 +96,30 @@ func WithTableSafe(tableName string, whitelist map[string]struct{}) QueryOption
             // 非白名单表名直接忽略
             return db
         }
+        // 白名单之外再校验表名格式，防止白名单配置失误放入畸形表名
+        if err := validateTableName(t); err != nil {
+            return db
+        }
         db.DB = db.DB.Table(t)
+        recordOption(db, "WithTableSafe", t)
         return db
     }
 }
 
-// WithId 按主键 id 追加 WHERE 条件（id = ?）。当 id 为空或仅包含空白时忽略该条件。
+// WithId 按主键 id 追加 WHERE 条件（id = ?）。当 id 为空或仅包含空白时忽略该条件；
+// 超过 255 字符或包含控制字符的 id 同样被忽略。
 func WithId(id string) QueryOption {
 	return func(db *DB) *DB {
 		if strings.TrimSpace(id) == "" {
 			return db
 		}
+
+		// 验证 ID 的安全性
+		if err := validateID(id); err != nil {
+			return db
+		}
 		db.DB = db.DB.Where("id = ?", id)
+		recordOption(db, "WithId")
 		return db
 	}
 }
@@ -67,6 +131,7 @@ func WithUserName(username string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("username = ?", username)
+		recordOption(db, "WithUserName")
 		return db
 	}
 }
@@ -78,6 +143,7 @@ func WithName(name string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("name = ?", name)
+		recordOption(db, "WithName")
 		return db
 	}
 }
@@ -90,10 +156,251 @@ func WithStatus(status int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("status = ?", status)
+		recordOption(db, "WithStatus")
+		return db
+	}
+}
+
+// WithTransformedEq 对绑定值应用 transform 后追加等值条件（field = ?）。
+// 用于集中处理规范化存储的列（如小写邮箱、去空白的名称），保证查询值与存储值一致。
+// field 必须出现在白名单中；transform 为 nil 时按原值绑定。
+func WithTransformedEq(field string, value string, transform func(string) string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || !identifierPattern.MatchString(f) {
+			return db
+		}
+		if _, ok := whitelist[f]; !ok {
+			return db
+		}
+		v := value
+		if transform != nil {
+			v = transform(v)
+		}
+		db.DB = db.DB.Where(columnName(f)+" = ?", v)
+		recordOption(db, "WithTransformedEq", f)
+		return db
+	}
+}
+
+// WithEqFold 追加忽略大小写的等值条件，用于面向用户的搜索（如按用户名查找 "Bob" 与 "bob"）。
+// 生成 lower(field) = lower(?)，按数据库的 lower 函数（随 LC_CTYPE 支持 Unicode）折叠大小写。
+// 注意：lower(field) 无法使用 field 上的普通索引，高频查询应建立表达式索引 CREATE INDEX ... ON t (lower(field))。
+// field 必须出现在白名单中；value 去除首尾空白后为空时忽略该条件，否则按原值（不去除空白）参数绑定。
+func WithEqFold(field, value string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || !identifierPattern.MatchString(f) {
+			return db
+		}
+		if _, ok := whitelist[f]; !ok {
+			return db
+		}
+		if strings.TrimSpace(value) == "" {
+			return db
+		}
+		db.DB = db.DB.Where("lower("+columnName(f)+") = lower(?)", value)
+		recordOption(db, "WithEqFold", f)
+		return db
+	}
+}
+
+// WithJSONFieldEq 按 JSONB 列中指定键的文本值追加等值条件（column->>? = ?），如 profile->>'city' = 'Shanghai'。
+// 说明：
+// 1) -> 返回 jsonb 类型（适合继续取下级字段或与 jsonb 比较），->> 返回 text 类型，此处使用 ->> 以便直接与字符串值比较；
+// 2) column 必须通过格式校验且出现在白名单中；path 作为参数绑定而非拼接，拒绝空值、超长值与包含控制字符的值；
+// 3) 非字符串的 value 会由数据库按 text 比较，如需数值比较请在业务侧转换后再调用。
+func WithJSONFieldEq(column, path string, value any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		c := strings.TrimSpace(column)
+		if c == "" || !identifierPattern.MatchString(c) {
+			return db
+		}
+		if _, ok := whitelist[c]; !ok {
+			return db
+		}
+		if path == "" || len(path) > 255 || strings.IndexFunc(path, unicode.IsControl) >= 0 {
+			return db
+		}
+		db.DB = db.DB.Where(columnName(c)+"->>? = ?", path, value)
+		recordOption(db, "WithJSONFieldEq", c)
+		return db
+	}
+}
+
+// aggFunctions 允许在 WithAggSelect 中使用的聚合函数。
+var aggFunctions = map[string]struct{}{
+	"array_agg": {}, "string_agg": {}, "json_agg": {},
+	"count": {}, "sum": {}, "avg": {}, "min": {}, "max": {},
+}
+
+// aggExprPattern 匹配 fn(col) 或 string_agg(col, 'sep') 形式的聚合表达式。
+var aggExprPattern = regexp.MustCompile(`^([a-zA-Z_]+)\(\s*(\*|[a-zA-Z_][a-zA-Z0-9_]*)\s*(?:,\s*'([^'\\]*)'\s*)?\)$`)
+
+// aggSelectKey 为 GORM Statement.Settings 中保存已追加聚合列的键名。
+const aggSelectKey = "pg:agg_select"
+
+// aggSelect 记录已追加的聚合列表达式及其绑定参数。
+type aggSelect struct {
+	exprs []string
+	vars  []any
+}
+
+// WithAggSelect 追加一个聚合查询列（fn(col) AS alias），如 array_agg(name) AS names、string_agg(name, ',') AS names。
+// 规则：
+// - 仅允许 array_agg、string_agg、json_agg、count、sum、avg、min、max，函数名不区分大小写；
+// - col 须为合法标识符，仅 count 允许使用 *；string_agg 必须提供单引号包裹的分隔符，分隔符作为参数绑定；
+// - alias 须为合法标识符；任一校验失败时忽略该选项。
+// 多次调用会依次追加到 SELECT 列表中。
+func WithAggSelect(expr, alias string) QueryOption {
+	return func(db *DB) *DB {
+		m := aggExprPattern.FindStringSubmatch(strings.TrimSpace(expr))
+		a := strings.TrimSpace(alias)
+		if m == nil || !identifierPattern.MatchString(a) {
+			return db
+		}
+		fn, col, sep := strings.ToLower(m[1]), m[2], m[3]
+		if _, ok := aggFunctions[fn]; !ok {
+			return db
+		}
+		if col == "*" && fn != "count" {
+			return db
+		}
+		hasSep := strings.Contains(m[0], ",")
+		if (fn == "string_agg") != hasSep {
+			return db
+		}
+
+		if col != "*" {
+			col = columnName(col)
+		}
+		sel := aggSelect{}
+		if v, ok := db.DB.Get(aggSelectKey); ok {
+			sel, _ = v.(aggSelect)
+		}
+		exprs := append(sel.exprs[:len(sel.exprs):len(sel.exprs)], "")
+		vars := sel.vars[:len(sel.vars):len(sel.vars)]
+		if hasSep {
+			exprs[len(exprs)-1] = fn + "(" + col + ", ?) AS " + columnName(a)
+			vars = append(vars, sep)
+		} else {
+			exprs[len(exprs)-1] = fn + "(" + col + ") AS " + columnName(a)
+		}
+
+		db.DB = db.DB.Set(aggSelectKey, aggSelect{exprs: exprs, vars: vars})
+		db.DB = db.DB.Select(strings.Join(exprs, ", "), vars...)
+		recordOption(db, "WithAggSelect", fn)
+		return db
+	}
+}
+
+// lockStrengths 允许的行锁强度。
+var lockStrengths = map[string]struct{}{
+	"UPDATE": {}, "SHARE": {}, "NO KEY UPDATE": {}, "KEY SHARE": {},
+}
+
+// lockOptions 允许的行锁等待选项。
+var lockOptions = map[string]struct{}{
+	"": {}, "SKIP LOCKED": {}, "NOWAIT": {},
+}
+
+// WithLock 为查询追加行级锁（SELECT ... FOR <strength> [option]），用于悲观并发控制。
+// 说明：
+// 1) strength 仅允许 UPDATE、SHARE、NO KEY UPDATE、KEY SHARE；option 可为空、SKIP LOCKED 或 NOWAIT，均不区分大小写；
+// 2) 未知取值会忽略该选项；
+// 3) 行锁在事务结束时释放，仅在事务（db.Transaction）中使用才有意义，事务外执行时语句结束即释放锁。
+func WithLock(strength, option string) QueryOption {
+	return func(db *DB) *DB {
+		st := strings.ToUpper(strings.Join(strings.Fields(strength), " "))
+		op := strings.ToUpper(strings.Join(strings.Fields(option), " "))
+		if _, ok := lockStrengths[st]; !ok {
+			return db
+		}
+		if _, ok := lockOptions[op]; !ok {
+			return db
+		}
+		db.DB = db.DB.Clauses(clause.Locking{Strength: st, Options: op})
+		recordOption(db, "WithLock", st)
+		return db
+	}
+}
+
+// WithStatementTimeout 限制当前查询的最长执行时间：执行前在同一连接上发出 SET LOCAL statement_timeout = <毫秒>，
+// 超时后服务端取消语句并返回错误（SQLSTATE 57014）。
+// 说明：
+// 1) SET LOCAL 仅在当前事务内生效，必须在事务（db.Transaction）中使用；事务外执行时服务端仅给出警告，设置不会作用于后续查询；
+// 2) 设置持续到事务结束，同一事务中之后的语句同样受该超时约束；
+// 3) d 小于等于 0 时忽略该选项，不足 1 毫秒按 1 毫秒处理（0 在 PostgreSQL 中表示不限制）。
+func WithStatementTimeout(d time.Duration) QueryOption {
+	return func(db *DB) *DB {
+		if d <= 0 {
+			return db
+		}
+		ms := d.Milliseconds()
+		if ms < 1 {
+			ms = 1
+		}
+		db.DB = db.DB.Scopes(func(tx *gorm.DB) *gorm.DB {
+			// SET 不支持参数绑定，ms 为整数，直接格式化不存在注入风险
+			if err := tx.Session(&gorm.Session{NewDB: true}).Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)).Error; err != nil {
+				_ = tx.AddError(fmt.Errorf("WithStatementTimeout: %w", err))
+			}
+			return tx
+		})
+		recordOption(db, "WithStatementTimeout", strconv.FormatInt(ms, 10))
 		return db
 	}
 }
 
+// WithDeletedOnly 仅查询已软删除的记录：取消默认的软删除过滤（Unscoped），并追加 `<deleted_at 列> IS NOT NULL` 条件。
+// 软删除列在查询执行时根据模型（Model 或 Find 的目标）的 gorm.DeletedAt 字段确定，支持自定义列名。
+// 若无法确定模型或模型没有软删除字段，查询将返回错误，而不是退化为返回全部记录。
+func WithDeletedOnly() QueryOption {
+	return func(db *DB) *DB {
+		db.DB = db.DB.Scopes(deletedOnlyScope)
+		recordOption(db, "WithDeletedOnly")
+		return db
+	}
+}
+
+// deletedOnlyScope 在查询执行前解析模型并定位软删除列
+func deletedOnlyScope(tx *gorm.DB) *gorm.DB {
+	model := tx.Statement.Model
+	if model == nil {
+		model = tx.Statement.Dest
+	}
+	if model == nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly requires a model"))
+		return tx
+	}
+	if err := tx.Statement.Parse(model); err != nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly: %w", err))
+		return tx
+	}
+	field := softDeleteField(tx.Statement.Schema)
+	if field == nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly: model %s has no soft-delete field", tx.Statement.Schema.Name))
+		return tx
+	}
+	return tx.Unscoped().Where(clause.Expr{
+		SQL:  "? IS NOT NULL",
+		Vars: []any{clause.Column{Table: clause.CurrentTable, Name: field.DBName}},
+	})
+}
+
+// softDeleteField 返回模型中以 gorm.DeletedAt 实现的软删除字段，不存在时返回 nil
+func softDeleteField(s *schema.Schema) *schema.Field {
+	if s == nil {
+		return nil
+	}
+	for _, qc := range s.QueryClauses {
+		if sd, ok := qc.(gorm.SoftDeleteQueryClause); ok {
+			return sd.Field
+		}
+	}
+	return nil
+}
+
 // WithOffset 设置查询的 OFFSET（偏移量）。当 offset 为负数时忽略该选项。
 func WithOffset(offset int) QueryOption {
 	// WithOffset 返回一个合法的 QueryOption。
@@ -103,6 +410,7 @@ func WithOffset(offset int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Offset(offset)
+		recordOption(db, "WithOffset")
 		return db
 	}
 }
@@ -116,11 +424,28 @@ func WithLimit(limit int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Limit(limit)
+		recordOption(db, "WithLimit")
 		return db
 	}
 }
 
-// OrderAsc 对指定字段进行升序排序，字段必须出现在白名单中以防止 SQL 注入。
+// WithPage 按页码设置 LIMIT/OFFSET：offset = (page-1)*pageSize，LIMIT 为 pageSize。
+// page 小于 1 时按第 1 页处理；pageSize 小于等于 0 时忽略该选项。
+func WithPage(page, pageSize int) QueryOption {
+	return func(db *DB) *DB {
+		if pageSize <= 0 {
+			return db
+		}
+		if page < 1 {
+			page = 1
+		}
+		db.DB = db.DB.Limit(pageSize).Offset((page - 1) * pageSize)
+		recordOption(db, "WithPage")
+		return db
+	}
+}
+
+// OrderAsc 对指定字段进行升序排序，字段须通过格式校验且出现在白名单中以防止 SQL 注入。
 // whitelist 参数由上层按业务整理（如 {"id","created_at","username"}），此处会忽略空白字段。
 func OrderAsc(field string, whitelist map[string]struct{}) QueryOption {
 	return func(db *DB) *DB {
@@ -128,16 +453,17 @@ func OrderAsc(field string, whitelist map[string]struct{}) QueryOption {
 		if f == "" {
 			return db
 		}
-		if _, ok := whitelist[f]; !ok {
-			// 非白名单字段直接忽略或记录告警
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
 			return db
 		}
-		db.DB = db.DB.Order(f + " ASC")
+		db.DB = db.DB.Order(columnName(f) + " ASC")
+		recordOption(db, "OrderAsc", f)
 		return db
 	}
 }
 
-// OrderDesc 对指定字段进行降序排序，字段必须出现在白名单中以防止 SQL 注入。
+// OrderDesc 对指定字段进行降序排序，字段须通过格式校验且出现在白名单中以防止 SQL 注入。
 // whitelist 参数由上层按业务整理，忽略空白字段。
 func OrderDesc(field string, whitelist map[string]struct{}) QueryOption {
 	return func(db *DB) *DB {
@@ -145,14 +471,145 @@ func OrderDesc(field string, whitelist map[string]struct{}) QueryOption {
 		if f == "" {
 			return db
 		}
-		if _, ok := whitelist[f]; !ok {
+		// 验证字段名安全性
+		if err := validateFieldName(f, whitelist); err != nil {
 			return db
 		}
-		db.DB = db.DB.Order(f + " DESC")
+		db.DB = db.DB.Order(columnName(f) + " DESC")
+		recordOption(db, "OrderDesc", f)
 		return db
 	}
 }
 
+// identifierPattern 合法标识符格式：字母或下划线开头，仅包含字母、数字、下划线。
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// tableNamePattern 合法表名格式：字母开头，仅包含字母、数字、下划线。
+var tableNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// reservedWords 常见 SQL 保留字，validateTableName 拒绝与之同名的表名。
+var reservedWords = map[string]struct{}{
+	"SELECT": {}, "FROM": {}, "WHERE": {}, "INSERT": {}, "UPDATE": {}, "DELETE": {},
+	"CREATE": {}, "DROP": {}, "ALTER": {}, "INDEX": {}, "TABLE": {}, "ORDER": {},
+	"BY": {}, "LIMIT": {}, "OFFSET": {}, "JOIN": {}, "GROUP": {}, "HAVING": {},
+	"IN": {}, "AND": {}, "OR": {}, "NOT": {}, "NULL": {}, "AS": {}, "ON": {},
+	"DISTINCT": {}, "BETWEEN": {}, "LIKE": {}, "IS": {}, "CASE": {}, "WHEN": {},
+	"THEN": {}, "ELSE": {}, "END": {}, "USER": {}, "DEFAULT": {}, "CHECK": {},
+	"KEY": {}, "PRIMARY": {}, "REFERENCES": {}, "UNION": {}, "VALUES": {},
+}
+
+// validateTableName 验证表名是否安全，防止 SQL 注入
+func validateTableName(tableName string) error {
+	if tableName == "" {
+		return nil // 空表名不报错，会由调用方处理
+	}
+
+	// 检查表名长度
+	if len(tableName) > 64 {
+		return NewValidationError("table name too long (max 64 characters)", nil).
+			WithContext("table_name", tableName).
+			WithContext("length", len(tableName)).
+			WithCode("TABLE_NAME_TOO_LONG")
+	}
+
+	// 检查表名格式：只允许字母、数字、下划线，且必须以字母开头
+	if !tableNamePattern.MatchString(tableName) {
+		return NewValidationError("invalid table name format", nil).
+			WithContext("table_name", tableName).
+			WithCode("TABLE_NAME_INVALID_FORMAT")
+	}
+
+	// 检查是否为 SQL 保留字
+	if _, reserved := reservedWords[strings.ToUpper(tableName)]; reserved {
+		return NewValidationError(fmt.Sprintf("table name '%s' cannot be SQL keyword", tableName), nil).
+			WithContext("table_name", tableName).
+			WithCode("TABLE_NAME_SQL_KEYWORD")
+	}
+
+	return nil
+}
+
+// validateID 验证 ID 参数的安全性
+func validateID(id string) error {
+	if len(id) > 255 {
+		return NewValidationError("id too long (max 255 characters)", nil).
+			WithContext("id_length", len(id)).
+			WithCode("ID_TOO_LONG")
+	}
+
+	// 检查是否包含危险字符
+	if containsControlCharacters(id) {
+		return NewValidationError("id contains invalid control characters", nil).
+			WithContext("id", id).
+			WithCode("ID_INVALID_CHARS")
+	}
+
+	return nil
+}
+
+// containsControlCharacters 检查字符串是否包含控制字符
+func containsControlCharacters(s string) bool {
+	for _, r := range s {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFieldName 验证字段名是否安全，防止 SQL 注入。
+// 与 clickhouse 包不同，pg 包的字段必须出现在白名单中，whitelist 为 nil 时拒绝所有字段。
+func validateFieldName(field string, whitelist map[string]struct{}) error {
+	if field == "" {
+		return nil // 空字段名不报错，会由调用方处理
+	}
+
+	// 检查字段名长度
+	if len(field) > 64 {
+		return NewValidationError("field name too long (max 64 characters)", nil).
+			WithContext("field_name", field).
+			WithContext("length", len(field)).
+			WithCode("FIELD_NAME_TOO_LONG")
+	}
+
+	// 检查字段名格式
+	if !identifierPattern.MatchString(field) {
+		return NewValidationError("invalid field name format", nil).
+			WithContext("field_name", field).
+			WithCode("FIELD_NAME_INVALID_FORMAT")
+	}
+
+	// 检查是否在白名单中
+	if _, exists := whitelist[field]; !exists {
+		return NewValidationError(fmt.Sprintf("field '%s' not in whitelist", field), nil).
+			WithContext("field_name", field).
+			WithCode("FIELD_NOT_WHITELISTED")
+	}
+
+	return nil
+}
+
+// QuoteIdentifier 校验标识符后使用 PostgreSQL 方言的双引号包裹（如 "order"）。
+// 仅在通过格式校验后才进行包裹，校验失败返回错误，避免借引号注入。
+func QuoteIdentifier(name string) (string, error) {
+	n := strings.TrimSpace(name)
+	if n == "" || len(n) > 64 || !identifierPattern.MatchString(n) {
+		return "", fmt.Errorf("invalid identifier: %q", name)
+	}
+	return "\"" + n + "\"", nil
+}
+
+// columnName 返回拼接到条件中的列名：通过格式校验的列名一律加引号，无需维护保留字列表即可安全使用 order、user 等列名；
+// 加引号后 PostgreSQL 按原样区分大小写，列名须与表结构一致（GORM 默认生成小写 snake_case）。
+// 未通过格式校验的列名（如 t.id）原样返回，由调用方的既有校验逻辑决定是否使用。
+func columnName(field string) string {
+	quoted, err := QuoteIdentifier(field)
+	if err != nil {
+		return field
+	}
+	return quoted
+}
+
 // WithIn 构建一个通用的 IN 条件（如 field IN ?），当 values 为空时忽略该条件。
 // 参数 field 必须是已知安全的列名（建议调用前进行白名单校验）。此处不对 values 做深度清洗，交由 GORM 预处理。
 func WithIn(field string, values any) QueryOption {
@@ -171,11 +628,54 @@ func WithIn(field string, values any) QueryOption {
                 return db
             }
         }
-        db.DB = db.DB.Where(field+" IN ?", values)
+        db.DB = db.DB.Where(columnName(field)+" IN ?", values)
+        recordOption(db, "WithIn", field)
         return db
     }
 }
 
+// WithTupleIn 按行值匹配多列组合（(a, b) IN ((?, ?), (?, ?))），如 (tenant_id, user_id) IN ((1, 'x'), (2, 'y'))。
+// 规则：
+// - 每个字段须通过格式校验且出现在白名单中，任一字段不合法或 fields 为空时忽略该选项；
+// - tuples 为空时忽略该选项；所有值均作为参数绑定；
+// - 任一元组的元素个数与 fields 不一致时向会话写入错误，执行时返回该错误，避免静默放宽查询条件。
+func WithTupleIn(fields []string, tuples [][]any, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		if len(fields) == 0 || len(tuples) == 0 {
+			return db
+		}
+
+		cols := make([]string, len(fields))
+		for i, field := range fields {
+			f := strings.TrimSpace(field)
+			if f == "" || !identifierPattern.MatchString(f) {
+				return db
+			}
+			if _, ok := whitelist[f]; !ok {
+				return db
+			}
+			cols[i] = columnName(f)
+		}
+
+		placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+		groups := make([]string, len(tuples))
+		vars := make([]any, 0, len(tuples)*len(cols))
+		for i, tuple := range tuples {
+			if len(tuple) != len(cols) {
+				db.DB = db.DB.Session(&gorm.Session{})
+				_ = db.DB.AddError(fmt.Errorf("WithTupleIn: tuple %d has %d values, expected %d", i, len(tuple), len(cols)))
+				return db
+			}
+			groups[i] = placeholder
+			vars = append(vars, tuple...)
+		}
+
+		db.DB = db.DB.Where("("+strings.Join(cols, ", ")+") IN ("+strings.Join(groups, ", ")+")", vars...)
+		recordOption(db, "WithTupleIn", strings.Join(cols, ","))
+		return db
+	}
+}
+
 // WithIds 使用更安全的 IN ? 形式展开 id 列的切片。空切片时忽略该条件。
 func WithIds(ids []string) QueryOption {
 	return func(db *DB) *DB {
@@ -183,6 +683,7 @@ func WithIds(ids []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("id IN ?", ids)
+		recordOption(db, "WithIds")
 		return db
 	}
 }
@@ -194,6 +695,7 @@ func WithNames(names []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("name IN ?", names)
+		recordOption(db, "WithNames")
 		return db
 	}
 }
@@ -205,6 +707,7 @@ func WithUsernames(usernames []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("username IN ?", usernames)
+		recordOption(db, "WithUsernames")
 		return db
 	}
 }
@@ -1,11 +1,15 @@
 package pg
 
 import (
+    "context"
     "testing"
     "strings"
+    "time"
 
+    "gorm.io/driver/postgres"
     "gorm.io/driver/sqlite"
     "gorm.io/gorm"
+    "gorm.io/gorm/logger"
 )
 
 // newTestDB 返回一个启用 DryRun 的测试用 *DB，使用 sqlite 内存驱动以便无真实数据库也可生成 SQL。
@@ -19,6 +23,18 @@ func newTestDB(t *testing.T) *DB {
     return &DB{DB: gdb}
 }
 
+// newPostgresTestDB 返回一个使用 PostgreSQL 方言的 DryRun *DB，不会建立真实连接。
+// 用于验证 sqlite 方言不会渲染的子句（如 FOR UPDATE 行锁）。
+func newPostgresTestDB(t *testing.T) *DB {
+    t.Helper()
+    dial := postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=5432 user=test dbname=test sslmode=disable"})
+    gdb, err := gorm.Open(dial, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+    if err != nil {
+        t.Fatalf("failed to open dryrun postgres: %v", err)
+    }
+    return &DB{DB: gdb}
+}
+
 // execFind 触发一次干运行查询以让 GORM 构建 SQL；
 // 返回构建完成的 *gorm.DB（其中 Statement.SQL 为最终 SQL，Statement.Vars 为绑定参数）。
 func execFind(t *testing.T, db *DB) *gorm.DB {
@@ -30,16 +46,29 @@ func execFind(t *testing.T, db *DB) *gorm.DB {
     return tx
 }
 
+// mustOptionDB 应用选项并在 OptionDB 返回错误时终止测试。
+func mustOptionDB(t *testing.T, db *DB, options ...QueryOption) *DB {
+    t.Helper()
+    updated, err := OptionDB(db, options...)
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    return updated
+}
+
 // TestOptionDB_NilSafety 验证 OptionDB 对 nil 的安全处理，以及忽略 nil 选项的行为。
 func TestOptionDB_NilSafety(t *testing.T) {
-    // db 为 nil 时应直接返回 nil
-    if OptionDB(nil, WithId("1")) != nil {
-        t.Fatalf("OptionDB should return nil when input db is nil")
+    // db 为 nil 时应返回查询错误
+    if updated, err := OptionDB(nil, WithId("1")); updated != nil || !IsQueryError(err) {
+        t.Fatalf("OptionDB should return query error when input db is nil, got %v, %v", updated, err)
+    }
+    if _, err := OptionDB(&DB{}, WithId("1")); !IsQueryError(err) {
+        t.Fatalf("OptionDB should return query error when gorm db is nil, got %v", err)
     }
 
     // 忽略 nil 的 QueryOption
     db := newTestDB(t)
-    updated := OptionDB(db, nil, WithId("abc"))
+    updated := mustOptionDB(t, db, nil, WithId("abc"))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"WHERE id = ?"}) {
@@ -50,6 +79,35 @@ func TestOptionDB_NilSafety(t *testing.T) {
     }
 }
 
+// TestOptionDB_ErrorHandling 验证选项 panic 被捕获为查询错误且后续选项继续应用、选项返回 nil 时报错，
+// 以及 OptionDBMust 的向后兼容行为。
+func TestOptionDB_ErrorHandling(t *testing.T) {
+    panicking := func(db *DB) *DB { panic("boom") }
+    updated, err := OptionDB(newTestDB(t), WithId("1"), panicking, WithName("alice"))
+    if !IsQueryError(err) || !strings.Contains(err.Error(), "QUERY_OPTION_PANIC") {
+        t.Fatalf("expected QUERY_OPTION_PANIC query error, got %v", err)
+    }
+    if sql := execFind(t, updated).Statement.SQL.String(); !containsAll(sql, []string{"id = ?", "name = ?"}) {
+        t.Fatalf("options around the panicking one should still apply, got: %s", sql)
+    }
+
+    nilResult := func(db *DB) *DB { return nil }
+    if updated, err := OptionDB(newTestDB(t), nilResult); updated != nil || !IsQueryError(err) {
+        t.Fatalf("expected query error for nil option result, got %v, %v", updated, err)
+    }
+
+    if OptionDBMust(nil, WithId("1")) != nil {
+        t.Fatal("OptionDBMust should return nil when input db is nil")
+    }
+    db := newTestDB(t)
+    if got := OptionDBMust(db, nilResult); got != db {
+        t.Fatal("OptionDBMust should return the input db on error")
+    }
+    if sql := execFind(t, OptionDBMust(newTestDB(t), WithId("x"))).Statement.SQL.String(); !contains(sql, "id = ?") {
+        t.Fatalf("OptionDBMust should apply options, got: %s", sql)
+    }
+}
+
 // 已移除 WithTable，相关行为由 WithTableSafe 覆盖。
 
 // TestWithTableWhitelist 验证 WithTableSafe 的白名单校验逻辑：
@@ -60,7 +118,7 @@ func TestWithTableWhitelist(t *testing.T) {
     wl := map[string]struct{}{"users": {}, "accounts": {}}
 
     // 允许的表名
-    updated := OptionDB(db, WithTableSafe("users", wl))
+    updated := mustOptionDB(t, db, WithTableSafe("users", wl))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"FROM `users`"}) {
@@ -69,7 +127,7 @@ func TestWithTableWhitelist(t *testing.T) {
 
     // 非白名单表名应被忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("not_allowed", wl))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("not_allowed", wl))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"FROM `not_allowed`"}) {
@@ -79,7 +137,7 @@ func TestWithTableWhitelist(t *testing.T) {
     // 空白表名应被忽略
     db3 := newTestDB(t)
     db3.DB = db3.DB.Table("accounts")
-    updated3 := OptionDB(db3, WithTableSafe("   ", wl))
+    updated3 := mustOptionDB(t, db3, WithTableSafe("   ", wl))
     tx3 := execFind(t, updated3)
     sql3 := tx3.Statement.SQL.String()
     if !containsAll(sql3, []string{"FROM `accounts`"}) {
@@ -87,11 +145,93 @@ func TestWithTableWhitelist(t *testing.T) {
     }
 }
 
+// TestTableValidation 验证 WithTableSafe 在白名单之外的表名校验：超长、格式不合法与 SQL 保留字的表名
+// 即使出现在白名单中也会被忽略，保持原有表名。
+func TestTableValidation(t *testing.T) {
+    tests := []struct {
+        name      string
+        tableName string
+        valid     bool
+    }{
+        {name: "valid table name", tableName: "valid_table", valid: true},
+        {name: "too long table name", tableName: "t" + strings.Repeat("x", 64), valid: false},
+        {name: "invalid characters", tableName: "invalid-table", valid: false},
+        {name: "leading digit", tableName: "1users", valid: false},
+        {name: "leading underscore", tableName: "_users", valid: false},
+        {name: "injection", tableName: "users; DROP TABLE x", valid: false},
+        {name: "SQL keyword", tableName: "SELECT", valid: false},
+        {name: "reserved word", tableName: "user", valid: false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            db := newTestDB(t)
+            // 设置一个默认表名
+            db.DB = db.DB.Table("test_table")
+            wl := map[string]struct{}{tt.tableName: {}}
+
+            sql := execFind(t, mustOptionDB(t, db, WithTableSafe(tt.tableName, wl))).Statement.SQL.String()
+            if tt.valid && !contains(sql, "FROM `"+tt.tableName+"`") {
+                t.Errorf("expected table %q to be applied, got: %s", tt.tableName, sql)
+            }
+            if !tt.valid && !contains(sql, "FROM `test_table`") {
+                t.Errorf("invalid table name %q should be ignored, got: %s", tt.tableName, sql)
+            }
+        })
+    }
+}
+
+// TestIDValidation 验证 WithId 忽略空白、超长与包含控制字符的 id。
+func TestIDValidation(t *testing.T) {
+    tests := []struct {
+        name          string
+        id            string
+        shouldContain bool
+    }{
+        {name: "valid ID", id: "valid_id_123", shouldContain: true},
+        {name: "empty ID", id: "", shouldContain: false},
+        {name: "whitespace ID", id: "   ", shouldContain: false},
+        {name: "too long ID", id: strings.Repeat("a", 256), shouldContain: false},
+        {name: "ID with control characters", id: "id\x00", shouldContain: false},
+    }
+
+    twl := map[string]struct{}{"users": {}}
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            tx := execFind(t, mustOptionDB(t, newTestDB(t), WithTableSafe("users", twl), WithId(tt.id)))
+            hasWhereID := contains(tx.Statement.SQL.String(), "WHERE id = ?")
+            if tt.shouldContain != hasWhereID {
+                t.Errorf("id %q: expected WHERE clause present=%v, got SQL: %s", tt.id, tt.shouldContain, tx.Statement.SQL.String())
+            }
+        })
+    }
+}
+
+// TestOrderFieldValidation 验证排序字段在白名单之外还需通过格式校验，whitelist 为 nil 时拒绝所有字段。
+func TestOrderFieldValidation(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    wl := map[string]struct{}{"created at": {}, "name;drop": {}, "id": {}}
+    tx := execFind(t, mustOptionDB(t, newTestDB(t), WithTableSafe("users", twl),
+        OrderAsc("created at", wl), OrderDesc("name;drop", wl), OrderAsc("id", nil)))
+    if sql := tx.Statement.SQL.String(); contains(sql, "ORDER BY") {
+        t.Fatalf("malformed or non-whitelisted order fields should be ignored, got: %s", sql)
+    }
+
+    if err := validateFieldName("id", wl); err != nil {
+        t.Fatalf("expected valid field, got %v", err)
+    }
+    for _, field := range []string{"created at", strings.Repeat("a", 65), "missing"} {
+        if err := validateFieldName(field, wl); !IsValidationError(err) {
+            t.Errorf("field %q: expected validation error, got %v", field, err)
+        }
+    }
+}
+
 // TestWhereBasic 验证 WithId/WithUserName/WithName/WithStatus 的 WHERE 条件拼接与 0 值忽略逻辑。
 func TestWhereBasic(t *testing.T) {
     db := newTestDB(t)
     twl := map[string]struct{}{"users": {}, "accounts": {}}
-    updated := OptionDB(db,
+    updated := mustOptionDB(t, db,
         WithTableSafe("users", twl),
         WithId("id123"),
         WithUserName("u1"),
@@ -117,7 +257,7 @@ func TestWhereBasic(t *testing.T) {
 
     // status == 0 应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("users", twl), WithStatus(0))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl), WithStatus(0))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"status = ?"}) {
@@ -129,7 +269,7 @@ func TestWhereBasic(t *testing.T) {
 func TestLimitOffset(t *testing.T) {
     db := newTestDB(t)
     twl := map[string]struct{}{"users": {}}
-    updated := OptionDB(db, WithTableSafe("users", twl), WithLimit(10), WithOffset(5))
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), WithLimit(10), WithOffset(5))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"LIMIT 10", "OFFSET 5"}) {
@@ -138,7 +278,7 @@ func TestLimitOffset(t *testing.T) {
 
     // 非法值应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("users", twl), WithLimit(0), WithOffset(-1))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl), WithLimit(0), WithOffset(-1))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"LIMIT", "OFFSET"}) {
@@ -146,21 +286,55 @@ func TestLimitOffset(t *testing.T) {
     }
 }
 
+// TestWithPage 验证按页码计算的 LIMIT/OFFSET，以及页码小于 1 与非法页大小的处理。
+func TestWithPage(t *testing.T) {
+    cases := []struct {
+        page, size int
+        want       []string
+        noOffset   bool
+    }{
+        {page: 1, size: 20, want: []string{"LIMIT 20"}, noOffset: true},
+        {page: 3, size: 20, want: []string{"LIMIT 20", "OFFSET 40"}},
+        {page: 2, size: 7, want: []string{"LIMIT 7", "OFFSET 7"}},
+        {page: 0, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+        {page: -5, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+    }
+    for _, c := range cases {
+        updated := mustOptionDB(t, newTestDB(t), WithTableSafe("users", map[string]struct{}{"users": {}}), WithPage(c.page, c.size))
+        sql := execFind(t, updated).Statement.SQL.String()
+        if !containsAll(sql, c.want) {
+            t.Fatalf("page=%d size=%d: expected %v, got: %s", c.page, c.size, c.want, sql)
+        }
+        if c.noOffset && contains(sql, "OFFSET") {
+            t.Fatalf("page=%d size=%d: first page should not set OFFSET, got: %s", c.page, c.size, sql)
+        }
+    }
+
+    // pageSize <= 0 时忽略
+    for _, size := range []int{0, -1} {
+        updated := mustOptionDB(t, newTestDB(t), WithTableSafe("users", map[string]struct{}{"users": {}}), WithPage(3, size))
+        sql := execFind(t, updated).Statement.SQL.String()
+        if contains(sql, "LIMIT") || contains(sql, "OFFSET") {
+            t.Fatalf("size=%d should be ignored, got: %s", size, sql)
+        }
+    }
+}
+
 // TestOrderWhitelist 验证升降序排序在白名单内才生效的逻辑。
 func TestOrderWhitelist(t *testing.T) {
     db := newTestDB(t)
     twl := map[string]struct{}{"users": {}}
     wl := map[string]struct{}{"created_at": {}, "username": {}}
-    updated := OptionDB(db, WithTableSafe("users", twl), OrderAsc("created_at", wl), OrderDesc("username", wl))
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), OrderAsc("created_at", wl), OrderDesc("username", wl))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"ORDER BY created_at ASC", "username DESC"}) {
+    if !containsAll(sql, []string{`ORDER BY "created_at" ASC`, `"username" DESC`}) {
         t.Fatalf("expected order clauses, got: %s", sql)
     }
 
     // 非白名单字段应被忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("users", twl), OrderAsc("not_allowed", wl))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl), OrderAsc("not_allowed", wl))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"ORDER BY not_allowed ASC"}) {
@@ -173,10 +347,10 @@ func TestWithIn(t *testing.T) {
     twl := map[string]struct{}{"users": {}}
     // string 切片
     db := newTestDB(t)
-    updated := OptionDB(db, WithTableSafe("users", twl), WithIn("id", []string{"a", "b"}))
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), WithIn("id", []string{"a", "b"}))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"id IN"}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
+    if !containsAll(sql, []string{`"id" IN`}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
         t.Fatalf("expected IN clause, got: %s", sql)
     }
     if len(tx.Statement.Vars) != 2 {
@@ -185,7 +359,7 @@ func TestWithIn(t *testing.T) {
 
     // 空切片应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("users", twl), WithIn("id", []string{}))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl), WithIn("id", []string{}))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{" IN "}) {
@@ -194,16 +368,16 @@ func TestWithIn(t *testing.T) {
 
     // int 切片
     db3 := newTestDB(t)
-    updated3 := OptionDB(db3, WithTableSafe("users", twl), WithIn("id", []int{1, 2, 3}))
+    updated3 := mustOptionDB(t, db3, WithTableSafe("users", twl), WithIn("id", []int{1, 2, 3}))
     tx3 := execFind(t, updated3)
     sql3 := tx3.Statement.SQL.String()
-    if !containsAll(sql3, []string{"id IN"}) || len(tx3.Statement.Vars) != 3 {
+    if !containsAll(sql3, []string{`"id" IN`}) || len(tx3.Statement.Vars) != 3 {
         t.Fatalf("expected IN clause with 3 int vars, got SQL: %s, vars: %#v", sql3, tx3.Statement.Vars)
     }
 
     // 空 int 切片应忽略
     db4 := newTestDB(t)
-    updated4 := OptionDB(db4, WithTableSafe("users", twl), WithIn("id", []int{}))
+    updated4 := mustOptionDB(t, db4, WithTableSafe("users", twl), WithIn("id", []int{}))
     tx4 := execFind(t, updated4)
     sql4 := tx4.Statement.SQL.String()
     if containsAll(sql4, []string{" IN "}) {
@@ -212,17 +386,17 @@ func TestWithIn(t *testing.T) {
 
     // int64 切片
     db5 := newTestDB(t)
-    updated5 := OptionDB(db5, WithTableSafe("users", twl), WithIn("id", []int64{9}))
+    updated5 := mustOptionDB(t, db5, WithTableSafe("users", twl), WithIn("id", []int64{9}))
     tx5 := execFind(t, updated5)
     sql5 := tx5.Statement.SQL.String()
-    if !containsAll(sql5, []string{"id IN"}) || len(tx5.Statement.Vars) != 1 {
+    if !containsAll(sql5, []string{`"id" IN`}) || len(tx5.Statement.Vars) != 1 {
         t.Fatalf("expected IN clause with 1 int64 var, got SQL: %s, vars: %#v", sql5, tx5.Statement.Vars)
     }
 
     // nil string 切片应忽略
     var nilStrs []string
     db6 := newTestDB(t)
-    updated6 := OptionDB(db6, WithTableSafe("users", twl), WithIn("id", nilStrs))
+    updated6 := mustOptionDB(t, db6, WithTableSafe("users", twl), WithIn("id", nilStrs))
     tx6 := execFind(t, updated6)
     sql6 := tx6.Statement.SQL.String()
     if containsAll(sql6, []string{" IN "}) {
@@ -230,12 +404,49 @@ func TestWithIn(t *testing.T) {
     }
 }
 
+// TestWithTupleIn 验证行值 IN 条件：SQL 形如 (a, b) IN ((?, ?), (?, ?))，所有值均绑定；
+// 空元组列表与非法字段忽略该选项，元组元素个数不一致时执行返回错误。
+func TestWithTupleIn(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    wl := map[string]struct{}{"tenant_id": {}, "user_id": {}}
+    tuples := [][]any{{1, "x"}, {2, "y"}}
+
+    updated := mustOptionDB(t, newTestDB(t), WithTableSafe("users", twl), WithTupleIn([]string{"tenant_id", "user_id"}, tuples, wl))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, `("tenant_id", "user_id") IN ((?, ?), (?, ?))`) {
+        t.Fatalf("expected tuple IN clause, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 4 || tx.Statement.Vars[0] != 1 || tx.Statement.Vars[3] != "y" {
+        t.Fatalf("expected 4 bound vars in tuple order, got: %#v", tx.Statement.Vars)
+    }
+
+    // 空元组列表、未在白名单中的字段与非法字段均忽略该选项
+    for _, opt := range []QueryOption{
+        WithTupleIn([]string{"tenant_id", "user_id"}, [][]any{}, wl),
+        WithTupleIn([]string{"tenant_id", "name"}, tuples, wl),
+        WithTupleIn([]string{"tenant_id", "user_id"}, tuples, nil),
+        WithTupleIn([]string{"tenant_id", "user_id)"}, tuples, wl),
+    } {
+        skipped := mustOptionDB(t, newTestDB(t), WithTableSafe("users", twl), opt)
+        if sql := execFind(t, skipped).Statement.SQL.String(); contains(sql, " IN ") {
+            t.Fatalf("expected tuple IN to be skipped, got: %s", sql)
+        }
+    }
+
+    // 元组元素个数不一致时执行返回错误
+    bad := mustOptionDB(t, newTestDB(t), WithTableSafe("users", twl), WithTupleIn([]string{"tenant_id", "user_id"}, [][]any{{1, "x", "extra"}}, wl))
+    if tx := bad.DB.Session(&gorm.Session{DryRun: true}).Find(&[]struct{}{}); tx.Error == nil || !contains(tx.Error.Error(), "WithTupleIn") {
+        t.Fatalf("expected arity mismatch error, got: %v", tx.Error)
+    }
+}
+
 // TestWithIdsNamesUsernames 验证针对固定列名的 IN 条件构造与空切片忽略。
 func TestWithIdsNamesUsernames(t *testing.T) {
     // WithIds
     db := newTestDB(t)
     twl := map[string]struct{}{"users": {}}
-    updated := OptionDB(db, WithTableSafe("users", twl), WithIds([]string{"i1", "i2"}))
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), WithIds([]string{"i1", "i2"}))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"id IN"}) || len(tx.Statement.Vars) != 2 {
@@ -244,7 +455,7 @@ func TestWithIdsNamesUsernames(t *testing.T) {
 
     // WithNames
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTableSafe("users", twl), WithNames([]string{"n1"}))
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl), WithNames([]string{"n1"}))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if !containsAll(sql2, []string{"name IN"}) || len(tx2.Statement.Vars) != 1 {
@@ -253,7 +464,7 @@ func TestWithIdsNamesUsernames(t *testing.T) {
 
     // WithUsernames 空切片忽略
     db3 := newTestDB(t)
-    updated3 := OptionDB(db3, WithTableSafe("users", twl), WithUsernames([]string{}))
+    updated3 := mustOptionDB(t, db3, WithTableSafe("users", twl), WithUsernames([]string{}))
     tx3 := execFind(t, updated3)
     sql3 := tx3.Statement.SQL.String()
     if containsAll(sql3, []string{"username IN"}) {
@@ -309,4 +520,288 @@ func indexImpl(s, sub string) int {
 }
 
 // stdIndex 直接调用标准库 strings.Index。
-func stdIndex(s, sub string) int { return strings.Index(s, sub) }
\ No newline at end of file
+func stdIndex(s, sub string) int { return strings.Index(s, sub) }
+
+// TestReservedWordQuoting 验证列名在条件与排序中一律使用双引号包裹，与 SQL 保留字同名的列（如 order）同样安全。
+func TestReservedWordQuoting(t *testing.T) {
+	db := newTestDB(t)
+	twl := map[string]struct{}{"orders": {}}
+	wl := map[string]struct{}{"order": {}}
+	updated := mustOptionDB(t, db, WithTableSafe("orders", twl), WithIn("order", []int{1, 2}), OrderAsc("order", wl))
+	tx := execFind(t, updated)
+	sql := tx.Statement.SQL.String()
+	if !containsAll(sql, []string{`"order" IN`, `ORDER BY "order" ASC`}) {
+		t.Fatalf("expected reserved-word column to be double-quoted, got: %s", sql)
+	}
+
+	// 校验失败的标识符不应被包裹
+	if _, err := QuoteIdentifier(`order" OR 1=1 --`); err == nil {
+		t.Fatalf("expected error for unsafe identifier")
+	}
+	if q, err := QuoteIdentifier("created_at"); err != nil || q != `"created_at"` {
+		t.Fatalf(`expected "created_at", got: %q, %v`, q, err)
+	}
+}
+
+// TestWithTransformedEq 验证 WithTransformedEq 绑定的是经过 transform 规范化后的值，且非白名单字段被忽略。
+func TestWithTransformedEq(t *testing.T) {
+    normalize := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+    wl := map[string]struct{}{"email": {}}
+
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db, WithTableSafe("users", map[string]struct{}{"users": {}}), WithTransformedEq("email", "  Alice@Example.COM ", normalize, wl))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{`WHERE "email" = ?`}) {
+        t.Fatalf("expected email condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != "alice@example.com" {
+        t.Fatalf("expected transformed var [alice@example.com], got: %#v", tx.Statement.Vars)
+    }
+
+    // 非白名单字段应被忽略
+    db2 := newTestDB(t)
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", map[string]struct{}{"users": {}}), WithTransformedEq("password", "x", normalize, wl))
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); containsAll(sql2, []string{"password = ?"}) {
+        t.Fatalf("non-whitelist field should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithEqFold 验证忽略大小写的等值条件构造与原值绑定，非白名单字段与空值被忽略。
+func TestWithEqFold(t *testing.T) {
+    wl := map[string]struct{}{"name": {}}
+
+    tx := execFind(t, mustOptionDB(t, newTestDB(t), WithTableSafe("users", map[string]struct{}{"users": {}}), WithEqFold("name", " Bob ", wl)))
+    if sql := tx.Statement.SQL.String(); !contains(sql, `WHERE lower("name") = lower(?)`) {
+        t.Fatalf("expected case-insensitive condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != " Bob " {
+        t.Fatalf("expected value bound unchanged, got: %#v", tx.Statement.Vars)
+    }
+
+    for _, opt := range []QueryOption{
+        WithEqFold("email", "bob", wl),
+        WithEqFold("name", "  ", wl),
+    } {
+        if sql := execFind(t, mustOptionDB(t, newTestDB(t), WithTableSafe("users", map[string]struct{}{"users": {}}), opt)).Statement.SQL.String(); contains(sql, "WHERE") {
+            t.Fatalf("expected condition to be skipped, got: %s", sql)
+        }
+    }
+}
+
+// TestOptionAudit 验证开启审计后按顺序记录已应用选项名称，且不包含绑定值。
+func TestOptionAudit(t *testing.T) {
+    wl := map[string]struct{}{"created_at": {}}
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db,
+        WithOptionAudit(),
+        WithTableSafe("users", map[string]struct{}{"users": {}}),
+        WithId("secret-id"),
+        WithName(" "),
+        OrderAsc("created_at", wl),
+        WithLimit(10),
+    )
+    got := AppliedOptions(updated)
+    want := []string{"WithTableSafe:users", "WithId", "OrderAsc:created_at", "WithLimit"}
+    if len(got) != len(want) {
+        t.Fatalf("expected %v, got %v", want, got)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("expected %v, got %v", want, got)
+        }
+    }
+
+    // 未开启审计时不记录
+    plain := mustOptionDB(t, newTestDB(t), WithId("x"))
+    if names := AppliedOptions(plain); names != nil {
+        t.Fatalf("expected nil without audit, got %v", names)
+    }
+}
+
+// TestWithJSONFieldEq 验证 JSONB 取值语法以及路径与值均作为参数绑定。
+func TestWithJSONFieldEq(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    wl := map[string]struct{}{"profile": {}}
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), WithJSONFieldEq("profile", "city", "Shanghai", wl))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, `WHERE "profile"->>? = ?`) {
+        t.Fatalf("expected JSON accessor condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 2 || tx.Statement.Vars[0] != "city" || tx.Statement.Vars[1] != "Shanghai" {
+        t.Fatalf("expected vars [city Shanghai], got: %#v", tx.Statement.Vars)
+    }
+
+    // 非白名单列与非法路径应被忽略
+    db2 := newTestDB(t)
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl),
+        WithJSONFieldEq("settings", "city", "x", wl),
+        WithJSONFieldEq("profile", "ci\x00ty", "x", wl),
+        WithJSONFieldEq("profile", "", "x", wl),
+    )
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); contains(sql2, "->>") {
+        t.Fatalf("invalid JSON predicates should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithAggSelect 验证聚合列的 SELECT 生成、分隔符参数绑定以及非法表达式被忽略。
+func TestWithAggSelect(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl),
+        WithAggSelect("array_agg(name)", "names"),
+        WithAggSelect("string_agg(username, ',')", "usernames"),
+        WithAggSelect("count(*)", "total"),
+    )
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{`array_agg("name") AS "names"`, `string_agg("username", ?) AS "usernames"`, `count(*) AS "total"`}) {
+        t.Fatalf("expected aggregate select list, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != "," {
+        t.Fatalf("expected vars [,], got: %#v", tx.Statement.Vars)
+    }
+
+    db2 := newTestDB(t)
+    updated2 := mustOptionDB(t, db2, WithTableSafe("users", twl),
+        WithAggSelect("pg_sleep(name)", "x"),
+        WithAggSelect("sum(name); DROP TABLE users", "x"),
+        WithAggSelect("max(*)", "x"),
+        WithAggSelect("string_agg(name)", "x"),
+        WithAggSelect("min(age)", "bad alias"),
+    )
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); !contains(sql2, "SELECT *") {
+        t.Fatalf("invalid aggregate expressions should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithLock 验证行锁子句的生成以及非法锁强度/选项被忽略。
+func TestWithLock(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    db := newPostgresTestDB(t)
+    updated := mustOptionDB(t, db, WithTableSafe("users", twl), WithId("1"), WithLock("update", "skip  locked"))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !contains(sql, "FOR UPDATE SKIP LOCKED") {
+        t.Fatalf("expected FOR UPDATE SKIP LOCKED, got: %s", sql)
+    }
+
+    updated2 := mustOptionDB(t, newPostgresTestDB(t), WithTableSafe("users", twl), WithLock("NO KEY UPDATE", ""))
+    if sql2 := execFind(t, updated2).Statement.SQL.String(); !strings.HasSuffix(sql2, "FOR NO KEY UPDATE") {
+        t.Fatalf("expected FOR NO KEY UPDATE, got: %s", sql2)
+    }
+
+    updated3 := mustOptionDB(t, newPostgresTestDB(t), WithTableSafe("users", twl),
+        WithLock("UPDATE; DROP TABLE users", ""),
+        WithLock("SHARE", "WAIT FOREVER"),
+    )
+    if sql3 := execFind(t, updated3).Statement.SQL.String(); contains(sql3, "FOR ") {
+        t.Fatalf("invalid lock options should be ignored, got: %s", sql3)
+    }
+}
+
+// softDeleteUser 为带软删除字段的测试模型
+type softDeleteUser struct {
+    ID        uint
+    Name      string
+    DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// TestWithDeletedOnly 在真实的内存 SQLite 中写入存活与已删除的记录，验证仅返回已软删除的记录。
+func TestWithDeletedOnly(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    sqlDB.SetMaxOpenConns(1)
+
+    if err := gdb.AutoMigrate(&softDeleteUser{}); err != nil {
+        t.Fatalf("auto migrate failed: %v", err)
+    }
+    users := []softDeleteUser{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}
+    if err := gdb.Create(&users).Error; err != nil {
+        t.Fatalf("seed failed: %v", err)
+    }
+    if err := gdb.Delete(&users[1]).Error; err != nil {
+        t.Fatalf("soft delete failed: %v", err)
+    }
+
+    // 默认作用域仍排除已删除记录
+    var live []softDeleteUser
+    if err := gdb.Find(&live).Error; err != nil || len(live) != 2 {
+        t.Fatalf("expected 2 live rows, got %d err=%v", len(live), err)
+    }
+
+    var deleted []softDeleteUser
+    if err := mustOptionDB(t, &DB{DB: gdb}, WithDeletedOnly()).Find(&deleted).Error; err != nil {
+        t.Fatalf("query deleted rows failed: %v", err)
+    }
+    if len(deleted) != 1 || deleted[0].Name != "bob" {
+        t.Fatalf("expected only bob, got %+v", deleted)
+    }
+
+    // 与其他条件组合，并通过 Model 指定模型
+    var count int64
+    if err := mustOptionDB(t, &DB{DB: gdb.Model(&softDeleteUser{})}, WithDeletedOnly(), WithName("alice")).Count(&count).Error; err != nil || count != 0 {
+        t.Fatalf("expected no deleted alice, got %d err=%v", count, err)
+    }
+
+    // 模型没有软删除字段时应返回错误，而不是返回全部记录
+    var plain []struct{ ID uint }
+    if err := mustOptionDB(t, &DB{DB: gdb.Table("soft_delete_users")}, WithDeletedOnly()).Find(&plain).Error; err == nil || !strings.Contains(err.Error(), "no soft-delete field") {
+        t.Fatalf("expected soft-delete field error, got %v", err)
+    }
+}
+
+// sqlRecorder 记录 GORM 追踪到的 SQL（DryRun 下同样会调用 Trace），用于断言语句的发出顺序。
+type sqlRecorder struct {
+    logger.Interface
+    sqls []string
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+    sql, _ := fc()
+    r.sqls = append(r.sqls, sql)
+}
+
+// TestWithStatementTimeout 验证查询前在同一会话发出 SET LOCAL statement_timeout（毫秒），
+// 不足 1 毫秒按 1 毫秒处理，非正值忽略该选项。
+func TestWithStatementTimeout(t *testing.T) {
+    twl := map[string]struct{}{"users": {}}
+    cases := []struct {
+        d    time.Duration
+        want string
+    }{
+        {1500 * time.Millisecond, "SET LOCAL statement_timeout = 1500"},
+        {2 * time.Second, "SET LOCAL statement_timeout = 2000"},
+        {500 * time.Microsecond, "SET LOCAL statement_timeout = 1"},
+        {0, ""},
+        {-time.Second, ""},
+    }
+    for _, c := range cases {
+        rec := &sqlRecorder{Interface: logger.Discard}
+        db := newPostgresTestDB(t)
+        db.DB = db.DB.Session(&gorm.Session{Logger: rec})
+        tx := execFind(t, mustOptionDB(t, db, WithTableSafe("users", twl), WithStatementTimeout(c.d)))
+
+        if c.want == "" {
+            if len(rec.sqls) != 1 || contains(rec.sqls[0], "statement_timeout") {
+                t.Fatalf("timeout %v should be ignored, got: %#v", c.d, rec.sqls)
+            }
+            continue
+        }
+        if len(rec.sqls) != 2 || rec.sqls[0] != c.want || rec.sqls[1] != tx.Statement.SQL.String() {
+            t.Fatalf("expected %q before the query, got: %#v", c.want, rec.sqls)
+        }
+    }
+}
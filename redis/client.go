@@ -61,6 +61,26 @@ func (cc *ContextClient) Get(key string) (string, error) {
     return cc.base.GetCtx(cc.ctx, key)
 }
 
+// MGet 使用默认上下文批量获取键值，不存在的键对应位置为 nil。
+func (cc *ContextClient) MGet(keys ...string) ([]any, error) {
+    return cc.base.MGetCtx(cc.ctx, keys...)
+}
+
+// MSet 使用默认上下文批量设置键值。
+func (cc *ContextClient) MSet(pairs ...any) (string, error) {
+    return cc.base.MSetCtx(cc.ctx, pairs...)
+}
+
+// SetObject 使用默认上下文将对象编码为 JSON 后写入。
+func (cc *ContextClient) SetObject(key string, value any, expiration time.Duration) error {
+    return cc.base.SetObjectCtx(cc.ctx, key, value, expiration)
+}
+
+// GetObject 使用默认上下文读取并解码 JSON 值，键不存在时返回 redis.Nil。
+func (cc *ContextClient) GetObject(key string, dest any) error {
+    return cc.base.GetObjectCtx(cc.ctx, key, dest)
+}
+
 // Delete 使用默认上下文删除键。
 func (cc *ContextClient) Delete(keys ...string) (int64, error) {
     return cc.base.DeleteCtx(cc.ctx, keys...)
@@ -71,11 +91,46 @@ func (cc *ContextClient) Exists(key string) (int64, error) {
     return cc.base.ExistsCtx(cc.ctx, key)
 }
 
+// ExistsBool 使用默认上下文判断单个键是否存在，返回布尔值。
+func (cc *ContextClient) ExistsBool(key string) (bool, error) {
+    return cc.base.ExistsBoolCtx(cc.ctx, key)
+}
+
 // ScanKeys 使用默认上下文扫描匹配键。
 func (cc *ContextClient) ScanKeys(pattern string, count int64) ([]string, error) {
     return cc.base.ScanKeysCtx(cc.ctx, pattern, count)
 }
 
+// Dump 使用默认上下文序列化键值。
+func (cc *ContextClient) Dump(key string) (string, error) {
+    return cc.base.DumpCtx(cc.ctx, key)
+}
+
+// Restore 使用默认上下文从序列化值恢复键。
+func (cc *ContextClient) Restore(key string, ttl time.Duration, serializedValue string) (string, error) {
+    return cc.base.RestoreCtx(cc.ctx, key, ttl, serializedValue)
+}
+
+// ObjectIdleTime 使用默认上下文获取键的空闲时间。
+func (cc *ContextClient) ObjectIdleTime(key string) (time.Duration, error) {
+    return cc.base.ObjectIdleTimeCtx(cc.ctx, key)
+}
+
+// ObjectFreq 使用默认上下文获取键的访问频率计数器。
+func (cc *ContextClient) ObjectFreq(key string) (int64, error) {
+    return cc.base.ObjectFreqCtx(cc.ctx, key)
+}
+
+// ReplicationOffset 使用默认上下文获取主节点复制偏移量。
+func (cc *ContextClient) ReplicationOffset() (int64, error) {
+    return cc.base.ReplicationOffsetCtx(cc.ctx)
+}
+
+// WaitForReplica 使用默认上下文等待副本确认复制偏移量。
+func (cc *ContextClient) WaitForReplica(offset int64, timeout time.Duration) error {
+    return cc.base.WaitForReplicaCtx(cc.ctx, offset, timeout)
+}
+
 // HSet 使用默认上下文设置哈希字段。
 func (cc *ContextClient) HSet(key string, fieldValues ...interface{}) (int64, error) {
     return cc.base.HSetCtx(cc.ctx, key, fieldValues...)
@@ -96,6 +151,16 @@ func (cc *ContextClient) HGetAll(key string) (map[string]string, error) {
     return cc.base.HGetAllCtx(cc.ctx, key)
 }
 
+// HRandField 使用默认上下文从哈希中随机返回字段名。
+func (cc *ContextClient) HRandField(key string, count int) ([]string, error) {
+    return cc.base.HRandFieldCtx(cc.ctx, key, count)
+}
+
+// HRandFieldWithValues 使用默认上下文从哈希中随机返回字段及其值。
+func (cc *ContextClient) HRandFieldWithValues(key string, count int) ([]redis.KeyValue, error) {
+    return cc.base.HRandFieldWithValuesCtx(cc.ctx, key, count)
+}
+
 // SAdd 使用默认上下文向集合添加成员。
 func (cc *ContextClient) SAdd(key string, values ...interface{}) (int64, error) {
     return cc.base.SAddCtx(cc.ctx, key, values...)
@@ -106,11 +171,21 @@ func (cc *ContextClient) SIsMember(key string, value interface{}) (bool, error)
     return cc.base.SIsMemberCtx(cc.ctx, key, value)
 }
 
+// SMIsMember 使用默认上下文批量判断多个元素是否在集合中。
+func (cc *ContextClient) SMIsMember(key string, members ...interface{}) ([]bool, error) {
+    return cc.base.SMIsMemberCtx(cc.ctx, key, members...)
+}
+
 // ZAdd 使用默认上下文向有序集合添加成员。
 func (cc *ContextClient) ZAdd(key string, member interface{}, score float64) (int64, error) {
     return cc.base.ZAddCtx(cc.ctx, key, member, score)
 }
 
+// ZAddArgs 使用默认上下文按修饰符向有序集合写入成员。
+func (cc *ContextClient) ZAddArgs(key string, args ZAddArgs) (int64, error) {
+    return cc.base.ZAddArgsCtx(cc.ctx, key, args)
+}
+
 // ZRange 使用默认上下文按排名区间返回成员。
 func (cc *ContextClient) ZRange(key string, start int64, end int64) ([]string, error) {
     return cc.base.ZRangeCtx(cc.ctx, key, start, end)
@@ -121,6 +196,26 @@ func (cc *ContextClient) LPush(key string, values ...interface{}) (int64, error)
     return cc.base.LPushCtx(cc.ctx, key, values...)
 }
 
+// ZRangeByScoreWithScores 使用默认上下文按分值区间正序获取元素及其分值。
+func (cc *ContextClient) ZRangeByScoreWithScores(key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return cc.base.ZRangeByScoreWithScoresCtx(cc.ctx, key, minScore, maxScore)
+}
+
+// ZRevRangeByScoreWithScores 使用默认上下文按分值区间倒序获取元素及其分值。
+func (cc *ContextClient) ZRevRangeByScoreWithScores(key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return cc.base.ZRevRangeByScoreWithScoresCtx(cc.ctx, key, minScore, maxScore)
+}
+
+// LMove 使用默认上下文在两个列表间原子地移动元素。
+func (cc *ContextClient) LMove(src, dst, srcDir, dstDir string) (string, error) {
+    return cc.base.LMoveCtx(cc.ctx, src, dst, srcDir, dstDir)
+}
+
+// RPopLPush 使用默认上下文从 src 尾部弹出元素并推入 dst 头部。
+func (cc *ContextClient) RPopLPush(src, dst string) (string, error) {
+    return cc.base.RPopLPushCtx(cc.ctx, src, dst)
+}
+
 // RPop 使用默认上下文从列表尾部弹出元素。
 func (cc *ContextClient) RPop(key string) (string, error) {
     return cc.base.RPopCtx(cc.ctx, key)
@@ -182,6 +277,9 @@ func NewClient(opts ...Option) (*Client, error) {
         ReadTimeout:  readTimeout,
         WriteTimeout: writeTimeout,
     })
+    if conf.LatencyHook != nil {
+        c.AddHook(latencyHook{fn: conf.LatencyHook})
+    }
 
     // 测试连接
     _, err = c.Ping(ctx).Result()
@@ -238,6 +336,9 @@ func NewClientWithoutPing(opts ...Option) (*Client, error) {
         ReadTimeout:  readTimeout,
         WriteTimeout: writeTimeout,
     })
+    if conf.LatencyHook != nil {
+        c.AddHook(latencyHook{fn: conf.LatencyHook})
+    }
 
     return &Client{c}, nil
 }
@@ -5,7 +5,12 @@
 package redis
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 func TestClient(t *testing.T) {
@@ -86,4 +91,51 @@ func TestClientClose(t *testing.T) {
 	// 测试nil客户端
 	var nilClient *Client
 	nilClient.Close() // 应该不会panic
-}
\ No newline at end of file
+}
+
+// TestWithLatencyHook 验证耗时回调在无有效连接时仍会报告失败的命令（含单条命令与管道）。
+func TestWithLatencyHook(t *testing.T) {
+	type call struct {
+		cmd string
+		dur time.Duration
+		err error
+	}
+	var (
+		mu    sync.Mutex
+		calls []call
+	)
+	hook := func(cmd string, dur time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call{cmd, dur, err})
+	}
+
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}), WithLatencyHook(hook))
+	if err != nil {
+		t.Fatalf("failed to create client without ping: %v", err)
+	}
+	defer rc.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := rc.GetCtx(c, "k"); err == nil {
+		t.Fatal("expected error without valid redis")
+	}
+	_, _ = rc.Pipelined(c, func(p redis.Pipeliner) error {
+		p.Get(c, "k")
+		return nil
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 hook calls, got %d: %+v", len(calls), calls)
+	}
+	if calls[0].cmd != "get" || calls[0].err == nil || calls[0].dur <= 0 {
+		t.Errorf("unexpected command report: %+v", calls[0])
+	}
+	if calls[1].cmd != "pipeline" || calls[1].err == nil {
+		t.Errorf("unexpected pipeline report: %+v", calls[1])
+	}
+}
@@ -87,6 +87,20 @@ func (rc *Client) ExistsCtx(ctx context.Context, key string) (int64, error) {
     return rc.UniversalClient.Exists(ctx, key).Result()
 }
 
+// ExistsBool 判断单个键是否存在，返回布尔值（count > 0）。
+func (rc *Client) ExistsBool(key string) (bool, error) {
+    return rc.ExistsBoolCtx(ctx, key)
+}
+
+// ExistsBoolCtx 判断单个键是否存在（带上下文），返回布尔值（count > 0）。
+func (rc *Client) ExistsBoolCtx(ctx context.Context, key string) (bool, error) {
+    n, err := rc.UniversalClient.Exists(ctx, key).Result()
+    if err != nil {
+        return false, err
+    }
+    return n > 0, nil
+}
+
 func (rc *Client) Expire(key string, expireDuration time.Duration) (bool, error) {
     return rc.UniversalClient.Expire(ctx, key, expireDuration).Result()
 }
@@ -123,6 +137,63 @@ func (rc *Client) PTTLCtx(ctx context.Context, key string) (time.Duration, error
     return rc.UniversalClient.PTTL(ctx, key).Result()
 }
 
+// Move 将键移动到指定的逻辑数据库 db。
+// 返回 false 表示未移动（源库中键不存在，或目标库中已存在同名键）。
+// 注意：集群模式仅支持 0 号数据库，不支持 MOVE 指令。
+func (rc *Client) Move(key string, db int) (bool, error) {
+    return rc.UniversalClient.Move(ctx, key, db).Result()
+}
+
+// MoveCtx 将键移动到指定的逻辑数据库 db（带上下文）。
+func (rc *Client) MoveCtx(ctx context.Context, key string, db int) (bool, error) {
+    return rc.UniversalClient.Move(ctx, key, db).Result()
+}
+
+// Dump 返回键值的序列化结果（DUMP），可配合 Restore 在不同实例间迁移键。
+// 注意：返回值是 Redis 内部的二进制格式（以 string 承载），必须原样传给 Restore，不可做编码转换或修改；
+// 键不存在时返回 redis.Nil。
+func (rc *Client) Dump(key string) (string, error) {
+    return rc.UniversalClient.Dump(ctx, key).Result()
+}
+
+// DumpCtx 返回键值的序列化结果（带上下文）。
+func (rc *Client) DumpCtx(ctx context.Context, key string) (string, error) {
+    return rc.UniversalClient.Dump(ctx, key).Result()
+}
+
+// Restore 使用 Dump 得到的序列化值创建键（RESTORE），ttl 为 0 表示不过期。
+// serializedValue 必须是 Dump 返回的原始二进制内容；目标键已存在时返回 BUSYKEY 错误。
+func (rc *Client) Restore(key string, ttl time.Duration, serializedValue string) (string, error) {
+    return rc.UniversalClient.Restore(ctx, key, ttl, serializedValue).Result()
+}
+
+// RestoreCtx 使用序列化值创建键（带上下文）。
+func (rc *Client) RestoreCtx(ctx context.Context, key string, ttl time.Duration, serializedValue string) (string, error) {
+    return rc.UniversalClient.Restore(ctx, key, ttl, serializedValue).Result()
+}
+
+// ObjectIdleTime 返回键自上次被访问以来的空闲时间（OBJECT IDLETIME），用于分析 LRU 淘汰情况。
+// 注意：maxmemory-policy 为 LFU 策略时服务端不记录空闲时间，该指令会返回错误；精度为秒。
+func (rc *Client) ObjectIdleTime(key string) (time.Duration, error) {
+    return rc.UniversalClient.ObjectIdleTime(ctx, key).Result()
+}
+
+// ObjectIdleTimeCtx 返回键的空闲时间（带上下文）。
+func (rc *Client) ObjectIdleTimeCtx(ctx context.Context, key string) (time.Duration, error) {
+    return rc.UniversalClient.ObjectIdleTime(ctx, key).Result()
+}
+
+// ObjectFreq 返回键的对数访问频率计数器（OBJECT FREQ），用于分析 LFU 淘汰情况。
+// 注意：仅在 maxmemory-policy 为 allkeys-lfu 或 volatile-lfu 时可用，其他策略下服务端返回错误。
+func (rc *Client) ObjectFreq(key string) (int64, error) {
+    return rc.UniversalClient.ObjectFreq(ctx, key).Result()
+}
+
+// ObjectFreqCtx 返回键的访问频率计数器（带上下文）。
+func (rc *Client) ObjectFreqCtx(ctx context.Context, key string) (int64, error) {
+    return rc.UniversalClient.ObjectFreq(ctx, key).Result()
+}
+
 func (rc *Client) DBSize() (int64, error) {
     return rc.UniversalClient.DBSize(ctx).Result()
 }
@@ -5,8 +5,11 @@
 package redis
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // TestScanKeys 测试ScanKeys功能，但跳过实际连接
@@ -94,4 +97,121 @@ func TestConfigParsing(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// existsStub 仅覆盖 Exists 的 UniversalClient 桩实现，用于验证计数到布尔值的映射。
+type existsStub struct {
+	redis.UniversalClient
+	count int64
+}
+
+func (s *existsStub) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	return redis.NewIntResult(s.count, nil)
+}
+
+// TestExistsBool 验证 ExistsBool 的 true/false 映射与无连接时的错误路径。
+func TestExistsBool(t *testing.T) {
+	for _, tc := range []struct {
+		count int64
+		want  bool
+	}{
+		{0, false},
+		{1, true},
+	} {
+		rc := &Client{&existsStub{count: tc.count}}
+		got, err := rc.ExistsBool("k")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("count=%d: expected %v, got %v", tc.count, tc.want, got)
+		}
+		if got, _ := rc.WithContext(context.Background()).ExistsBool("k"); got != tc.want {
+			t.Errorf("ContextClient count=%d: expected %v, got %v", tc.count, tc.want, got)
+		}
+	}
+
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("failed to create client without ping: %v", err)
+	}
+	defer rc.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if ok, err := rc.WithContext(c).ExistsBool("k"); err == nil || ok {
+		t.Errorf("expected error and false without valid redis, got ok=%v err=%v", ok, err)
+	}
+	if ok, err := rc.ExistsBool("k"); err == nil || ok {
+		t.Errorf("expected error and false without valid redis, got ok=%v err=%v", ok, err)
+	}
+}
+
+// TestMoveWithoutConnection 验证在无有效连接时 Move/MoveCtx 返回错误且不 panic。
+func TestMoveWithoutConnection(t *testing.T) {
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("failed to create client without ping: %v", err)
+	}
+	defer rc.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if moved, err := rc.MoveCtx(c, "k", 1); err == nil || moved {
+		t.Errorf("expected error and false when MoveCtx without valid redis, got moved=%v err=%v", moved, err)
+	}
+	if moved, err := rc.Move("k", 1); err == nil || moved {
+		t.Errorf("expected error and false when Move without valid redis, got moved=%v err=%v", moved, err)
+	}
+}
+
+// TestDumpRestoreWithoutConnection 验证在无有效连接时 Dump/Restore 及其 Ctx 变体返回错误且不 panic。
+func TestDumpRestoreWithoutConnection(t *testing.T) {
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("failed to create client without ping: %v", err)
+	}
+	defer rc.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if v, err := rc.DumpCtx(c, "k"); err == nil || v != "" {
+		t.Errorf("expected error and empty value when DumpCtx without valid redis, got v=%q err=%v", v, err)
+	}
+	if _, err := rc.RestoreCtx(c, "k", time.Minute, "\x00\x01payload"); err == nil {
+		t.Error("expected error when RestoreCtx without valid redis")
+	}
+	if _, err := rc.WithContext(c).Dump("k"); err == nil {
+		t.Error("expected error when ContextClient.Dump without valid redis")
+	}
+	if _, err := rc.WithContext(c).Restore("k", 0, "\x00\x01payload"); err == nil {
+		t.Error("expected error when ContextClient.Restore without valid redis")
+	}
+}
+
+// TestObjectIdleTimeFreqWithoutConnection 验证在无有效连接时 ObjectIdleTime/ObjectFreq 及其变体返回错误与零值。
+func TestObjectIdleTimeFreqWithoutConnection(t *testing.T) {
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("failed to create client without ping: %v", err)
+	}
+	defer rc.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if d, err := rc.ObjectIdleTimeCtx(c, "k"); err == nil || d != 0 {
+		t.Errorf("expected error and zero duration when ObjectIdleTimeCtx without valid redis, got d=%v err=%v", d, err)
+	}
+	if n, err := rc.ObjectFreqCtx(c, "k"); err == nil || n != 0 {
+		t.Errorf("expected error and zero freq when ObjectFreqCtx without valid redis, got n=%d err=%v", n, err)
+	}
+	if _, err := rc.WithContext(c).ObjectIdleTime("k"); err == nil {
+		t.Error("expected error when ContextClient.ObjectIdleTime without valid redis")
+	}
+	if _, err := rc.WithContext(c).ObjectFreq("k"); err == nil {
+		t.Error("expected error when ContextClient.ObjectFreq without valid redis")
+	}
+}
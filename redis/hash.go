@@ -4,7 +4,11 @@
 // Description:
 package redis
 
-import "context"
+import (
+    "context"
+
+    "github.com/redis/go-redis/v9"
+)
 
 // ======================== hash 指令 ======================== //
 
@@ -128,3 +132,26 @@ func (rc *Client) HSetMap(key string, values map[string]interface{}) (int64, err
 func (rc *Client) HSetMapCtx(ctx context.Context, key string, values map[string]interface{}) (int64, error) {
     return rc.UniversalClient.HSet(ctx, key, values).Result()
 }
+
+// HRandField 从哈希中随机返回 count 个字段名，适用于抽样场景（需 Redis >= 6.2）。
+// 参数：
+// - key: 哈希键名
+// - count: 正数时返回至多 count 个互不重复的字段；负数时返回 |count| 个字段且允许重复
+func (rc *Client) HRandField(key string, count int) ([]string, error) {
+    return rc.UniversalClient.HRandField(ctx, key, count).Result()
+}
+
+// HRandFieldCtx 从哈希中随机返回 count 个字段名（带上下文），count 语义同 HRandField。
+func (rc *Client) HRandFieldCtx(ctx context.Context, key string, count int) ([]string, error) {
+    return rc.UniversalClient.HRandField(ctx, key, count).Result()
+}
+
+// HRandFieldWithValues 从哈希中随机返回 count 个字段及其值，count 语义同 HRandField。
+func (rc *Client) HRandFieldWithValues(key string, count int) ([]redis.KeyValue, error) {
+    return rc.UniversalClient.HRandFieldWithValues(ctx, key, count).Result()
+}
+
+// HRandFieldWithValuesCtx 从哈希中随机返回 count 个字段及其值（带上下文），count 语义同 HRandField。
+func (rc *Client) HRandFieldWithValuesCtx(ctx context.Context, key string, count int) ([]redis.KeyValue, error) {
+    return rc.UniversalClient.HRandFieldWithValues(ctx, key, count).Result()
+}
@@ -3,3 +3,35 @@
 // Author: Amu
 // Description:
 package redis
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// TestHRandFieldWithoutConnection 验证无有效连接时 HRandField 系列方法返回错误且不 panic。
+func TestHRandFieldWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    if _, err := rc.HRandFieldCtx(c, "flags", 3); err == nil {
+        t.Error("expected error when HRandFieldCtx without valid redis, got nil")
+    }
+    if _, err := rc.HRandFieldWithValuesCtx(c, "flags", -3); err == nil {
+        t.Error("expected error when HRandFieldWithValuesCtx without valid redis, got nil")
+    }
+    w := rc.WithContext(c)
+    if _, err := w.HRandField("flags", 1); err == nil {
+        t.Error("expected error when ContextClient.HRandField without valid redis, got nil")
+    }
+    if _, err := w.HRandFieldWithValues("flags", 1); err == nil {
+        t.Error("expected error when ContextClient.HRandFieldWithValues without valid redis, got nil")
+    }
+}
@@ -0,0 +1,42 @@
+// Package redis
+// Date: 2026/10/16 10:00
+// Author: Amu
+// Description: 命令耗时回调
+package redis
+
+import (
+    "context"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// latencyHook 将 LatencyHook 适配为 go-redis 的 Hook，对单条命令与管道分别计时
+type latencyHook struct {
+    fn LatencyHook
+}
+
+var _ redis.Hook = latencyHook{}
+
+func (h latencyHook) DialHook(next redis.DialHook) redis.DialHook {
+    return next
+}
+
+func (h latencyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+    return func(ctx context.Context, cmd redis.Cmder) error {
+        start := time.Now()
+        err := next(ctx, cmd)
+        h.fn(cmd.Name(), time.Since(start), err)
+        return err
+    }
+}
+
+// ProcessPipelineHook 管道（含事务）整体计时一次，err 为管道返回的首个错误
+func (h latencyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+    return func(ctx context.Context, cmds []redis.Cmder) error {
+        start := time.Now()
+        err := next(ctx, cmds)
+        h.fn("pipeline", time.Since(start), err)
+        return err
+    }
+}
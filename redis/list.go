@@ -4,7 +4,11 @@
 // Description:
 package redis
 
-import "context"
+import (
+    "context"
+    "fmt"
+    "strings"
+)
 
 // ======================== list 指令 ======================== //
 
@@ -131,3 +135,45 @@ func (rc *Client) LRem(key string, nums int64, value string) (int64, error) {
 func (rc *Client) LRemCtx(ctx context.Context, key string, nums int64, value string) (int64, error) {
     return rc.UniversalClient.LRem(ctx, key, nums, value).Result()
 }
+
+// LMove 原子地从 src 弹出一个元素并推入 dst，返回被移动的元素，常用于可靠队列（pending → processing）。
+// 参数：
+// - srcDir: 从 src 的哪一端弹出，"LEFT" 为头部、"RIGHT" 为尾部（不区分大小写）
+// - dstDir: 推入 dst 的哪一端，取值同上
+// src 为空时返回 redis.Nil；src 与 dst 可以相同以实现列表轮转。
+func (rc *Client) LMove(src, dst, srcDir, dstDir string) (string, error) {
+    return rc.LMoveCtx(ctx, src, dst, srcDir, dstDir)
+}
+
+// LMoveCtx 原子地在两个列表间移动元素（带上下文），方向参数同 LMove。
+func (rc *Client) LMoveCtx(ctx context.Context, src, dst, srcDir, dstDir string) (string, error) {
+    from, err := listDirection(srcDir)
+    if err != nil {
+        return "", err
+    }
+    to, err := listDirection(dstDir)
+    if err != nil {
+        return "", err
+    }
+    return rc.UniversalClient.LMove(ctx, src, dst, from, to).Result()
+}
+
+// RPopLPush 原子地从 src 尾部弹出元素并推入 dst 头部，等价于 LMove(src, dst, "RIGHT", "LEFT")。
+// Redis 6.2 起该命令已被 LMOVE 取代，保留用于兼容旧版本服务端。
+func (rc *Client) RPopLPush(src, dst string) (string, error) {
+    return rc.UniversalClient.RPopLPush(ctx, src, dst).Result()
+}
+
+// RPopLPushCtx 原子地从 src 尾部弹出元素并推入 dst 头部（带上下文）。
+func (rc *Client) RPopLPushCtx(ctx context.Context, src, dst string) (string, error) {
+    return rc.UniversalClient.RPopLPush(ctx, src, dst).Result()
+}
+
+// listDirection 规范化列表方向参数，仅接受 LEFT 与 RIGHT。
+func listDirection(dir string) (string, error) {
+    d := strings.ToUpper(strings.TrimSpace(dir))
+    if d != "LEFT" && d != "RIGHT" {
+        return "", fmt.Errorf("invalid list direction: %q, expected LEFT or RIGHT", dir)
+    }
+    return d, nil
+}
@@ -3,3 +3,42 @@
 // Author: Amu
 // Description:
 package redis
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+// TestLMoveWithoutConnection 验证无有效连接时 LMove/RPopLPush 返回错误且不 panic。
+func TestLMoveWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    if _, err := rc.LMoveCtx(c, "pending", "processing", "right", "LEFT"); err == nil {
+        t.Error("expected error when LMoveCtx without valid redis, got nil")
+    }
+    if _, err := rc.RPopLPushCtx(c, "pending", "processing"); err == nil {
+        t.Error("expected error when RPopLPushCtx without valid redis, got nil")
+    }
+    if _, err := rc.WithContext(c).LMove("pending", "processing", "RIGHT", "LEFT"); err == nil {
+        t.Error("expected error when ContextClient.LMove without valid redis, got nil")
+    }
+}
+
+// TestLMoveInvalidDirection 验证非法方向参数在发送命令前即被拒绝。
+func TestLMoveInvalidDirection(t *testing.T) {
+    rc := &Client{}
+    if _, err := rc.LMove("a", "b", "UP", "LEFT"); err == nil {
+        t.Error("expected error for invalid source direction")
+    }
+    if _, err := rc.LMove("a", "b", "LEFT", ""); err == nil {
+        t.Error("expected error for empty destination direction")
+    }
+}
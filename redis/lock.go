@@ -0,0 +1,97 @@
+// Package redis
+// Date: 2025/11/24
+// Author: Amu
+// Description: Redis based distributed lock built on SET NX
+package redis
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// unlockLua 仅当 key 的值与持有者令牌一致时删除 key，返回删除的数量（0 或 1）。
+const unlockLua = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// refreshLockLua 仅当 key 的值与持有者令牌一致时重设毫秒级过期时间，成功返回 1，否则返回 0。
+const refreshLockLua = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`
+
+var (
+	unlockScript      = redis.NewScript(unlockLua)
+	refreshLockScript = redis.NewScript(refreshLockLua)
+)
+
+// Lock 尝试获取分布式锁：以随机令牌作为值通过 SET NX 写入 key，并设置过期时间 ttl，防止持有者崩溃后锁永不释放。
+// 获取成功返回令牌与 true，释放（Unlock）与续期（Refresh）时需传入该令牌；锁已被占用时返回空令牌与 false，不阻塞等待。
+// 注意：ttl 应大于临界区的最长执行时间，执行时间不确定时应在到期前调用 Refresh 续期。
+func (rc *Client) Lock(key string, ttl time.Duration) (token string, acquired bool, err error) {
+	if rc.UniversalClient == nil {
+		return "", false, fmt.Errorf("redis client is nil")
+	}
+	if ttl <= 0 {
+		return "", false, fmt.Errorf("ttl must be positive")
+	}
+
+	token, err = newLockToken()
+	if err != nil {
+		return "", false, err
+	}
+	acquired, err = rc.UniversalClient.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !acquired {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// Unlock 释放分布式锁：仅当 key 当前的值与 token 一致时删除，比较与删除在同一 Lua 脚本中原子执行，
+// 避免锁过期后被他人获取时误删他人的锁。返回 true 表示已释放，false 表示锁已过期或由他人持有。
+func (rc *Client) Unlock(key, token string) (bool, error) {
+	if rc.UniversalClient == nil {
+		return false, fmt.Errorf("redis client is nil")
+	}
+
+	n, err := unlockScript.Run(ctx, rc.UniversalClient, []string{key}, token).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Refresh 为仍由 token 持有的锁续期，将过期时间重设为 ttl；比较与续期在同一 Lua 脚本中原子执行。
+// 返回 false 表示锁已过期或由他人持有，此时调用方应视为已失去锁。
+func (rc *Client) Refresh(key, token string, ttl time.Duration) (bool, error) {
+	if rc.UniversalClient == nil {
+		return false, fmt.Errorf("redis client is nil")
+	}
+	if ttl <= 0 {
+		return false, fmt.Errorf("ttl must be positive")
+	}
+
+	n, err := refreshLockScript.Run(ctx, rc.UniversalClient, []string{key}, token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// newLockToken 生成 16 字节随机数的十六进制字符串，作为锁持有者的唯一令牌。
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
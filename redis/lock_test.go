@@ -0,0 +1,131 @@
+// Package redis
+// Date: 2025/11/24
+// Author: Amu
+// Description: Distributed lock tests
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockStub 在内存中模拟 SET NX 与解锁、续期脚本的语义：EVALSHA 总是返回 NOSCRIPT 以回退到 EVAL。
+type lockStub struct {
+	redis.UniversalClient
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newLockStub() *lockStub {
+	return &lockStub{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (s *lockStub) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	if _, ok := s.values[key]; ok {
+		return redis.NewBoolResult(false, nil)
+	}
+	s.values[key] = value.(string)
+	s.ttls[key] = expiration
+	return redis.NewBoolResult(true, nil)
+}
+
+func (s *lockStub) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	cmd := redis.NewCmd(ctx)
+	cmd.SetErr(noScriptError{})
+	return cmd
+}
+
+func (s *lockStub) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	cmd := redis.NewCmd(ctx)
+	key := keys[0]
+	owned := s.values[key] == fmt.Sprint(args[0])
+	switch script {
+	case unlockLua:
+		if !owned {
+			cmd.SetVal(int64(0))
+			return cmd
+		}
+		delete(s.values, key)
+		delete(s.ttls, key)
+	case refreshLockLua:
+		if !owned {
+			cmd.SetVal(int64(0))
+			return cmd
+		}
+		s.ttls[key] = time.Duration(args[1].(int64)) * time.Millisecond
+	default:
+		cmd.SetErr(fmt.Errorf("unexpected script: %q", script))
+		return cmd
+	}
+	cmd.SetVal(int64(1))
+	return cmd
+}
+
+// TestLock 验证锁被占用时获取失败、令牌不匹配时无法释放或续期，以及持有者可续期并释放锁。
+func TestLock(t *testing.T) {
+	stub := newLockStub()
+	rc := &Client{stub}
+
+	token, acquired, err := rc.Lock("job:1", 10*time.Second)
+	if err != nil || !acquired || len(token) != 32 {
+		t.Fatalf("expected lock to be acquired with a token, got %q/%v err=%v", token, acquired, err)
+	}
+	if stub.values["job:1"] != token || stub.ttls["job:1"] != 10*time.Second {
+		t.Fatalf("lock should store the token with ttl, got %q/%v", stub.values["job:1"], stub.ttls["job:1"])
+	}
+
+	other, acquired, err := rc.Lock("job:1", 10*time.Second)
+	if err != nil || acquired || other != "" {
+		t.Fatalf("expected lock acquisition to fail while held, got %q/%v err=%v", other, acquired, err)
+	}
+
+	if ok, err := rc.Unlock("job:1", "not-the-owner"); err != nil || ok {
+		t.Fatalf("expected unlock with mismatched token to fail, got %v err=%v", ok, err)
+	}
+	if stub.values["job:1"] != token {
+		t.Fatal("mismatched unlock should keep the lock")
+	}
+	if ok, err := rc.Refresh("job:1", "not-the-owner", time.Minute); err != nil || ok {
+		t.Fatalf("expected refresh with mismatched token to fail, got %v err=%v", ok, err)
+	}
+
+	if ok, err := rc.Refresh("job:1", token, time.Minute); err != nil || !ok {
+		t.Fatalf("expected owner refresh to succeed, got %v err=%v", ok, err)
+	}
+	if stub.ttls["job:1"] != time.Minute {
+		t.Errorf("expected ttl to be reset to 1m, got %v", stub.ttls["job:1"])
+	}
+
+	if ok, err := rc.Unlock("job:1", token); err != nil || !ok {
+		t.Fatalf("expected owner unlock to succeed, got %v err=%v", ok, err)
+	}
+	if ok, err := rc.Unlock("job:1", token); err != nil || ok {
+		t.Fatalf("expected second unlock to report not held, got %v err=%v", ok, err)
+	}
+
+	next, acquired, err := rc.Lock("job:1", time.Second)
+	if err != nil || !acquired || next == token {
+		t.Fatalf("expected lock to be re-acquired with a new token, got %q/%v err=%v", next, acquired, err)
+	}
+}
+
+// TestLockInvalidArgs 验证客户端为空或 ttl 非正时返回错误。
+func TestLockInvalidArgs(t *testing.T) {
+	if _, _, err := (&Client{}).Lock("k", time.Second); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+	if _, err := (&Client{}).Unlock("k", "t"); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+	rc := &Client{newLockStub()}
+	if _, _, err := rc.Lock("k", 0); err == nil {
+		t.Error("Expected error for non-positive ttl")
+	}
+	if _, err := rc.Refresh("k", "t", -time.Second); err == nil {
+		t.Error("Expected error for non-positive ttl")
+	}
+}
@@ -4,17 +4,23 @@
 // Description:
 package redis
 
+import "time"
+
+// LatencyHook 命令耗时回调：cmd 为命令名（管道执行时为 "pipeline"），dur 为耗时，err 为命令返回的错误
+type LatencyHook func(cmd string, dur time.Duration, err error)
+
 type Option func(*option)
 
 type option struct {
-    Addrs                 []string // redis 地址，兼容单机和集群
-    Password              string   // 密码，没有则为空
-    DB                    int      // 使用数据库
-    PoolSize              int      // 连接池大小
-    MasterName            string   // 有值，则为哨兵模式
-    DialConnectionTimeout string   // 连接超时，默认 5s
-    DialReadTimeout       string   // 读取超时，默认 3s，-1 表示取消读超时
-    DialWriteTimeout      string   // 写入超时，默认 3s， -1 表示取消写超时
+    Addrs                 []string    // redis 地址，兼容单机和集群
+    Password              string      // 密码，没有则为空
+    DB                    int         // 使用数据库
+    PoolSize              int         // 连接池大小
+    MasterName            string      // 有值，则为哨兵模式
+    DialConnectionTimeout string      // 连接超时，默认 5s
+    DialReadTimeout       string      // 读取超时，默认 3s，-1 表示取消读超时
+    DialWriteTimeout      string      // 写入超时，默认 3s， -1 表示取消写超时
+    LatencyHook           LatencyHook // 命令耗时回调，默认不设置
 }
 
 func WithAddrs(addrs []string) Option {
@@ -64,3 +70,11 @@ func WithWriteTimeout(writeTimeout string) Option {
         o.DialWriteTimeout = writeTimeout
     }
 }
+
+// WithLatencyHook 为客户端安装命令耗时回调，每条命令执行完成后调用（包括失败的命令），
+// 可用于慢命令排查或接入指标、链路追踪；回调在命令所在协程中同步执行，应避免耗时操作。
+func WithLatencyHook(hook LatencyHook) Option {
+    return func(o *option) {
+        o.LatencyHook = hook
+    }
+}
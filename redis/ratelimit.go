@@ -0,0 +1,102 @@
+// Package redis
+// Date: 2025/11/20
+// Author: Amu
+// Description: Redis based fixed-window and sliding-window rate limiters
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimiterNow 返回当前时间，测试中可替换为固定时钟。
+var rateLimiterNow = time.Now
+
+// slidingSeq 为同一纳秒内的请求生成不同的成员后缀，避免 ZADD 覆盖。
+var slidingSeq uint64
+
+// RateLimiter 基于 Redis 的限流器，同一 key 的计数在所有实例间共享。
+type RateLimiter struct {
+	client *Client
+}
+
+// NewRateLimiter 使用已有客户端创建限流器
+func NewRateLimiter(client *Client) *RateLimiter {
+	return &RateLimiter{client: client}
+}
+
+// Allow 固定窗口限流：窗口内第 limit+1 次及之后的请求被拒绝，remaining 为窗口内剩余次数。
+// 计数通过 IncrWithExpiry 原子自增，窗口从该 key 第一次请求开始计时。
+func (rl *RateLimiter) Allow(key string, limit int, window time.Duration) (bool, int, error) {
+	if err := rl.validate(limit, window); err != nil {
+		return false, 0, err
+	}
+
+	count, err := rl.client.IncrWithExpiry(key, window)
+	if err != nil {
+		return false, 0, err
+	}
+	allowed, remaining := windowDecision(count, limit)
+	return allowed, remaining, nil
+}
+
+// AllowSliding 滑动窗口限流：使用有序集合记录每次请求的时间戳，统计最近 window 内的请求数。
+// 清理过期成员、写入当前请求与计数在同一个事务管道中完成；被拒绝的请求会从集合中移除，不占用配额。
+func (rl *RateLimiter) AllowSliding(key string, limit int, window time.Duration) (bool, int, error) {
+	if err := rl.validate(limit, window); err != nil {
+		return false, 0, err
+	}
+
+	now := rateLimiterNow()
+	score, minScore := slidingWindowBounds(now, window)
+	member := strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.FormatUint(atomic.AddUint64(&slidingSeq, 1), 10)
+
+	pipe := rl.client.UniversalClient.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", minScore)
+	pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+	card := pipe.ZCard(ctx, key)
+	pipe.PExpire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, 0, err
+	}
+
+	allowed, remaining := windowDecision(card.Val(), limit)
+	if !allowed {
+		if err := rl.client.UniversalClient.ZRem(ctx, key, member).Err(); err != nil {
+			return false, 0, err
+		}
+	}
+	return allowed, remaining, nil
+}
+
+func (rl *RateLimiter) validate(limit int, window time.Duration) error {
+	if rl == nil || rl.client == nil || rl.client.UniversalClient == nil {
+		return fmt.Errorf("redis client is nil")
+	}
+	if limit <= 0 {
+		return fmt.Errorf("limit must be positive")
+	}
+	if window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	return nil
+}
+
+// windowDecision 根据窗口内（含本次）的请求数计算是否放行及剩余次数
+func windowDecision(count int64, limit int) (bool, int) {
+	if count > int64(limit) {
+		return false, 0
+	}
+	return true, limit - int(count)
+}
+
+// slidingWindowBounds 返回本次请求的分值（毫秒时间戳）以及需要清理的分值上界（含）。
+// 分值不大于 now-window 的成员已滑出窗口。
+func slidingWindowBounds(now time.Time, window time.Duration) (float64, string) {
+	nowMs := now.UnixMilli()
+	return float64(nowMs), strconv.FormatInt(nowMs-window.Milliseconds(), 10)
+}
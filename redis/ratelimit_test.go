@@ -0,0 +1,96 @@
+// Package redis
+// Date: 2025/11/20
+// Author: Amu
+// Description: Rate limiter tests
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowDecision(t *testing.T) {
+	cases := []struct {
+		count     int64
+		limit     int
+		allowed   bool
+		remaining int
+	}{
+		{count: 1, limit: 3, allowed: true, remaining: 2},
+		{count: 3, limit: 3, allowed: true, remaining: 0},
+		{count: 4, limit: 3, allowed: false, remaining: 0},
+		{count: 100, limit: 1, allowed: false, remaining: 0},
+	}
+	for _, c := range cases {
+		allowed, remaining := windowDecision(c.count, c.limit)
+		if allowed != c.allowed || remaining != c.remaining {
+			t.Errorf("windowDecision(%d, %d) = (%v, %d), want (%v, %d)",
+				c.count, c.limit, allowed, remaining, c.allowed, c.remaining)
+		}
+	}
+}
+
+func TestSlidingWindowBounds(t *testing.T) {
+	now := time.UnixMilli(1_700_000_010_000)
+	score, minScore := slidingWindowBounds(now, 10*time.Second)
+	if score != 1_700_000_010_000 {
+		t.Errorf("expected score to be now in ms, got %v", score)
+	}
+	if minScore != "1700000000000" {
+		t.Errorf("expected cutoff 10s before now, got %s", minScore)
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	stub := &scriptStub{counters: make(map[string]int64), ttls: make(map[string]string)}
+	rl := NewRateLimiter(&Client{stub})
+
+	for i := 1; i <= 3; i++ {
+		allowed, remaining, err := rl.Allow("api:user:1", 3, time.Minute)
+		if err != nil {
+			t.Fatalf("Allow returned error: %v", err)
+		}
+		if !allowed || remaining != 3-i {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %v/%d", i, 3-i, allowed, remaining)
+		}
+	}
+	if allowed, remaining, err := rl.Allow("api:user:1", 3, time.Minute); err != nil || allowed || remaining != 0 {
+		t.Fatalf("expected 4th request to be rejected, got %v/%d err=%v", allowed, remaining, err)
+	}
+	if ttl := stub.ttls["api:user:1"]; ttl != "60000" {
+		t.Errorf("expected window TTL of 60000ms, got %q", ttl)
+	}
+}
+
+func TestRateLimiterInvalidArgs(t *testing.T) {
+	if _, _, err := NewRateLimiter(&Client{}).Allow("k", 1, time.Second); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+	if _, _, err := NewRateLimiter(nil).AllowSliding("k", 1, time.Second); err == nil {
+		t.Error("Expected error with nil client")
+	}
+
+	rl := NewRateLimiter(&Client{&scriptStub{}})
+	if _, _, err := rl.Allow("k", 0, time.Second); err == nil {
+		t.Error("Expected error for non-positive limit")
+	}
+	if _, _, err := rl.AllowSliding("k", 1, 0); err == nil {
+		t.Error("Expected error for non-positive window")
+	}
+}
+
+func TestRateLimiterUnreachable(t *testing.T) {
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer rc.Close()
+
+	rl := NewRateLimiter(rc)
+	if allowed, _, err := rl.Allow("k", 1, time.Second); err == nil || allowed {
+		t.Errorf("Expected fixed-window error against unreachable server, got allowed=%v err=%v", allowed, err)
+	}
+	if allowed, _, err := rl.AllowSliding("k", 1, time.Second); err == nil || allowed {
+		t.Errorf("Expected sliding-window error against unreachable server, got allowed=%v err=%v", allowed, err)
+	}
+}
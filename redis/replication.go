@@ -0,0 +1,133 @@
+// Package redis
+// Date: 2026/10/16 14:00
+// Author: Amu
+// Description: 基于复制偏移量的写入确认
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrReplicaWaitTimeout 在超时时间内没有副本确认到指定的复制偏移量
+var ErrReplicaWaitTimeout = errors.New("timed out waiting for replica to reach offset")
+
+// replicaPollInterval WaitForReplica 轮询 INFO replication 的间隔
+var replicaPollInterval = 50 * time.Millisecond
+
+// ReplicationOffset 返回主节点当前的复制偏移量（INFO replication 中的 master_repl_offset）。
+// 写入后读取该值并传给 WaitForReplica，即可在不使用阻塞式 WAIT 命令的情况下确认写入已被副本接收。
+// 注意：仅适用于单机主从或哨兵模式（命令需发送到主节点），集群模式下 INFO 可能被路由到任意节点。
+func (rc *Client) ReplicationOffset() (int64, error) {
+	return rc.ReplicationOffsetCtx(ctx)
+}
+
+// ReplicationOffsetCtx 带上下文的 ReplicationOffset
+func (rc *Client) ReplicationOffsetCtx(ctx context.Context) (int64, error) {
+	if rc.UniversalClient == nil {
+		return 0, fmt.Errorf("redis client is nil")
+	}
+	info, err := rc.UniversalClient.Info(ctx, "replication").Result()
+	if err != nil {
+		return 0, err
+	}
+	return parseMasterReplOffset(info)
+}
+
+// WaitForReplica 轮询 INFO replication，直到任一在线副本确认的偏移量（slaveN 行中的 offset）不小于 offset。
+// 超过 timeout 仍未确认时返回 ErrReplicaWaitTimeout；timeout 小于等于 0 时仅检查一次。
+func (rc *Client) WaitForReplica(offset int64, timeout time.Duration) error {
+	return rc.WaitForReplicaCtx(ctx, offset, timeout)
+}
+
+// WaitForReplicaCtx 带上下文的 WaitForReplica，上下文取消时提前返回其错误
+func (rc *Client) WaitForReplicaCtx(ctx context.Context, offset int64, timeout time.Duration) error {
+	if rc.UniversalClient == nil {
+		return fmt.Errorf("redis client is nil")
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		info, err := rc.UniversalClient.Info(ctx, "replication").Result()
+		if err != nil {
+			return err
+		}
+		for _, ack := range parseReplicaOffsets(info) {
+			if ack >= offset {
+				return nil
+			}
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return fmt.Errorf("%w %d", ErrReplicaWaitTimeout, offset)
+		}
+		if wait > replicaPollInterval {
+			wait = replicaPollInterval
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// parseMasterReplOffset 从 INFO replication 的输出中解析 master_repl_offset
+func parseMasterReplOffset(info string) (int64, error) {
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "master_repl_offset:")
+		if !ok {
+			continue
+		}
+		offset, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid master_repl_offset %q: %w", value, err)
+		}
+		return offset, nil
+	}
+	return 0, errors.New("master_repl_offset not found in INFO replication")
+}
+
+// parseReplicaOffsets 从 INFO replication 的 slaveN 行（如 slave0:ip=10.0.0.2,port=6379,state=online,offset=1024,lag=0）
+// 中解析在线副本已确认的偏移量，忽略非 online 状态与格式异常的行
+func parseReplicaOffsets(info string) []int64 {
+	var offsets []int64
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "slave") {
+			continue
+		}
+		_, fields, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		var (
+			online bool
+			offset int64 = -1
+		)
+		for _, kv := range strings.Split(fields, ",") {
+			k, v, _ := strings.Cut(kv, "=")
+			switch k {
+			case "state":
+				online = v == "online"
+			case "offset":
+				if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+					offset = n
+				}
+			}
+		}
+		if online && offset >= 0 {
+			offsets = append(offsets, offset)
+		}
+	}
+	return offsets
+}
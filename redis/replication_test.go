@@ -0,0 +1,100 @@
+// Package redis
+// Date: 2026/10/16 14:00
+// Author: Amu
+// Description:
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const sampleReplicationInfo = "# Replication\r\n" +
+	"role:master\r\n" +
+	"connected_slaves:3\r\n" +
+	"slave0:ip=10.0.0.2,port=6379,state=online,offset=1000,lag=0\r\n" +
+	"slave1:ip=10.0.0.3,port=6379,state=wait_bgsave,offset=5000,lag=0\r\n" +
+	"slave2:ip=10.0.0.4,port=6379,state=online,offset=1200,lag=1\r\n" +
+	"master_failover_state:no-failover\r\n" +
+	"master_repl_offset:1234\r\n" +
+	"repl_backlog_active:1\r\n"
+
+// TestParseReplicationInfo 验证 master_repl_offset 与在线副本偏移量的解析，非 online 副本被忽略。
+func TestParseReplicationInfo(t *testing.T) {
+	offset, err := parseMasterReplOffset(sampleReplicationInfo)
+	if err != nil || offset != 1234 {
+		t.Fatalf("expected master_repl_offset 1234, got %d err=%v", offset, err)
+	}
+	if _, err := parseMasterReplOffset("# Replication\r\nrole:slave\r\n"); err == nil {
+		t.Fatal("expected error when master_repl_offset is missing")
+	}
+	if _, err := parseMasterReplOffset("master_repl_offset:abc\r\n"); err == nil {
+		t.Fatal("expected error for malformed master_repl_offset")
+	}
+
+	got := parseReplicaOffsets(sampleReplicationInfo)
+	if len(got) != 2 || got[0] != 1000 || got[1] != 1200 {
+		t.Fatalf("expected online replica offsets [1000 1200], got %v", got)
+	}
+}
+
+// infoStub 每次 INFO 返回的副本偏移量依次递增 step，用于模拟副本追赶主节点
+type infoStub struct {
+	redis.UniversalClient
+	offset, step int64
+	calls        int
+}
+
+func (s *infoStub) Info(ctx context.Context, sections ...string) *redis.StringCmd {
+	s.calls++
+	info := fmt.Sprintf("master_repl_offset:%d\r\nslave0:ip=127.0.0.1,port=6380,state=online,offset=%d,lag=0\r\n", s.offset+s.step, s.offset)
+	s.offset += s.step
+	return redis.NewStringResult(info, nil)
+}
+
+// TestWaitForReplica 验证副本追上偏移量后返回、始终落后时按超时返回 ErrReplicaWaitTimeout、上下文取消，以及空客户端返回错误。
+func TestWaitForReplica(t *testing.T) {
+	orig := replicaPollInterval
+	replicaPollInterval = time.Millisecond
+	defer func() { replicaPollInterval = orig }()
+
+	catching := &infoStub{offset: 100, step: 10}
+	rc := &Client{catching}
+	if off, err := rc.ReplicationOffset(); err != nil || off != 110 {
+		t.Fatalf("expected ReplicationOffset 110, got %d err=%v", off, err)
+	}
+	if err := rc.WaitForReplica(140, time.Second); err != nil {
+		t.Fatalf("expected replica to catch up, got: %v", err)
+	}
+
+	stalled := &infoStub{offset: 100}
+	start := time.Now()
+	err := (&Client{stalled}).WaitForReplica(200, 20*time.Millisecond)
+	if !errors.Is(err, ErrReplicaWaitTimeout) {
+		t.Fatalf("expected ErrReplicaWaitTimeout, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("expected to wait about the timeout, waited %v", elapsed)
+	}
+	if stalled.calls < 2 {
+		t.Fatalf("expected repeated polling before timeout, got %d calls", stalled.calls)
+	}
+
+	c, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (&Client{&infoStub{offset: 100}}).WithContext(c).WaitForReplica(200, time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	// 空客户端返回错误而不是 panic
+	if _, err := (&Client{}).ReplicationOffset(); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+	if err := (&Client{}).WaitForReplica(1, time.Second); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+}
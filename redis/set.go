@@ -4,7 +4,10 @@
 // Description:
 package redis
 
-import "context"
+import (
+    "context"
+    "errors"
+)
 
 // ======================== set 指令 ======================== //
 
@@ -74,6 +77,24 @@ func (rc *Client) SIsMemberCtx(ctx context.Context, key string, value interface{
     return rc.UniversalClient.SIsMember(ctx, key, value).Result()
 }
 
+// SMIsMember 批量判断多个元素是否存在于集合中，返回结果与 members 按位置一一对应；
+// members 为空时直接返回空切片，不发送指令。
+func (rc *Client) SMIsMember(key string, members ...interface{}) ([]bool, error) {
+    return rc.SMIsMemberCtx(ctx, key, members...)
+}
+
+// SMIsMemberCtx 批量判断多个元素是否存在于集合中（带上下文）。
+// 参数：
+// - ctx: 上下文
+// - key: 集合键名
+// - members: 待判断的元素，返回结果与其按位置一一对应
+func (rc *Client) SMIsMemberCtx(ctx context.Context, key string, members ...interface{}) ([]bool, error) {
+    if len(members) == 0 {
+        return []bool{}, nil
+    }
+    return rc.UniversalClient.SMIsMember(ctx, key, members...).Result()
+}
+
 func (rc *Client) SCard(key string) (int64, error) {
     return rc.UniversalClient.SCard(ctx, key).Result()
 }
@@ -121,3 +142,63 @@ func (rc *Client) SInter(key1, key2 string) ([]string, error) {
 func (rc *Client) SInterCtx(ctx context.Context, key1, key2 string) ([]string, error) {
     return rc.UniversalClient.SInter(ctx, key1, key2).Result()
 }
+
+// errNoSetKeys 多键集合运算未提供任何键
+var errNoSetKeys = errors.New("at least one key is required")
+
+// SUnionN 返回任意多个集合的并集，keys 为空时返回错误。
+func (rc *Client) SUnionN(keys ...string) ([]string, error) {
+    return rc.SUnionNCtx(ctx, keys...)
+}
+
+// SUnionNCtx 返回任意多个集合的并集（带上下文）。
+func (rc *Client) SUnionNCtx(ctx context.Context, keys ...string) ([]string, error) {
+    if len(keys) == 0 {
+        return nil, errNoSetKeys
+    }
+    return rc.UniversalClient.SUnion(ctx, keys...).Result()
+}
+
+// SDiffN 返回第一个集合与其余所有集合的差集，keys 为空时返回错误。
+func (rc *Client) SDiffN(keys ...string) ([]string, error) {
+    return rc.SDiffNCtx(ctx, keys...)
+}
+
+// SDiffNCtx 返回第一个集合与其余所有集合的差集（带上下文）。
+func (rc *Client) SDiffNCtx(ctx context.Context, keys ...string) ([]string, error) {
+    if len(keys) == 0 {
+        return nil, errNoSetKeys
+    }
+    return rc.UniversalClient.SDiff(ctx, keys...).Result()
+}
+
+// SInterN 返回任意多个集合的交集，keys 为空时返回错误。
+func (rc *Client) SInterN(keys ...string) ([]string, error) {
+    return rc.SInterNCtx(ctx, keys...)
+}
+
+// SInterNCtx 返回任意多个集合的交集（带上下文）。
+func (rc *Client) SInterNCtx(ctx context.Context, keys ...string) ([]string, error) {
+    if len(keys) == 0 {
+        return nil, errNoSetKeys
+    }
+    return rc.UniversalClient.SInter(ctx, keys...).Result()
+}
+
+// SInterCard 返回多个集合交集的元素数量（SINTERCARD，Redis 7.0+），不在客户端物化交集。
+// limit 为 0 表示不限制；大于 0 时计数达到 limit 即提前返回，适合只需判断交集是否"足够大"的场景。
+// keys 为空或 limit 为负数时返回错误。
+func (rc *Client) SInterCard(limit int, keys ...string) (int64, error) {
+    return rc.SInterCardCtx(ctx, limit, keys...)
+}
+
+// SInterCardCtx 返回多个集合交集的元素数量（带上下文）。
+func (rc *Client) SInterCardCtx(ctx context.Context, limit int, keys ...string) (int64, error) {
+    if len(keys) == 0 {
+        return 0, errNoSetKeys
+    }
+    if limit < 0 {
+        return 0, errors.New("limit must not be negative")
+    }
+    return rc.UniversalClient.SInterCard(ctx, int64(limit), keys...).Result()
+}
@@ -3,3 +3,166 @@
 // Author: Amu
 // Description:
 package redis
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// setStub 记录多键集合运算收到的键与 limit，并返回固定结果。
+type setStub struct {
+    redis.UniversalClient
+    op    string
+    keys  []string
+    limit int64
+}
+
+func (s *setStub) SUnion(ctx context.Context, keys ...string) *redis.StringSliceCmd {
+    s.op, s.keys = "SUNION", keys
+    return redis.NewStringSliceResult([]string{"a", "b", "c"}, nil)
+}
+
+func (s *setStub) SDiff(ctx context.Context, keys ...string) *redis.StringSliceCmd {
+    s.op, s.keys = "SDIFF", keys
+    return redis.NewStringSliceResult([]string{"a"}, nil)
+}
+
+func (s *setStub) SInter(ctx context.Context, keys ...string) *redis.StringSliceCmd {
+    s.op, s.keys = "SINTER", keys
+    return redis.NewStringSliceResult([]string{"b"}, nil)
+}
+
+func (s *setStub) SInterCard(ctx context.Context, limit int64, keys ...string) *redis.IntCmd {
+    s.op, s.keys, s.limit = "SINTERCARD", keys, limit
+    return redis.NewIntResult(1, nil)
+}
+
+// TestMultiKeySetOps 验证多键集合运算将全部键原样传递给对应指令，空键列表与负数 limit 在客户端直接报错。
+func TestMultiKeySetOps(t *testing.T) {
+    stub := &setStub{}
+    rc := &Client{stub}
+    keys := []string{"s1", "s2", "s3"}
+
+    for _, tc := range []struct {
+        op   string
+        call func() (int, error)
+    }{
+        {"SUNION", func() (int, error) { v, err := rc.SUnionN(keys...); return len(v), err }},
+        {"SDIFF", func() (int, error) { v, err := rc.SDiffN(keys...); return len(v), err }},
+        {"SINTER", func() (int, error) { v, err := rc.SInterN(keys...); return len(v), err }},
+    } {
+        stub.keys = nil
+        if _, err := tc.call(); err != nil {
+            t.Fatalf("%s: unexpected error: %v", tc.op, err)
+        }
+        if stub.op != tc.op || !reflect.DeepEqual(stub.keys, keys) {
+            t.Errorf("%s: expected keys %v, got op=%s keys=%v", tc.op, keys, stub.op, stub.keys)
+        }
+    }
+
+    n, err := rc.SInterCard(10, keys...)
+    if err != nil || n != 1 {
+        t.Fatalf("SInterCard: n=%d err=%v", n, err)
+    }
+    if stub.op != "SINTERCARD" || stub.limit != 10 || !reflect.DeepEqual(stub.keys, keys) {
+        t.Errorf("SInterCard: unexpected args op=%s limit=%d keys=%v", stub.op, stub.limit, stub.keys)
+    }
+
+    // 空键列表与负数 limit 不发送指令
+    stub.op = ""
+    if _, err := rc.SUnionN(); !errors.Is(err, errNoSetKeys) {
+        t.Errorf("SUnionN without keys: expected errNoSetKeys, got %v", err)
+    }
+    if _, err := rc.SDiffN(); !errors.Is(err, errNoSetKeys) {
+        t.Errorf("SDiffN without keys: expected errNoSetKeys, got %v", err)
+    }
+    if _, err := rc.SInterN(); !errors.Is(err, errNoSetKeys) {
+        t.Errorf("SInterN without keys: expected errNoSetKeys, got %v", err)
+    }
+    if _, err := rc.SInterCard(0); !errors.Is(err, errNoSetKeys) {
+        t.Errorf("SInterCard without keys: expected errNoSetKeys, got %v", err)
+    }
+    if _, err := rc.SInterCard(-1, "s1"); err == nil {
+        t.Error("SInterCard with negative limit: expected error")
+    }
+    if stub.op != "" {
+        t.Errorf("invalid arguments should not reach redis, got %s", stub.op)
+    }
+}
+
+// TestMultiKeySetOpsWithoutConnection 验证无有效连接时多键集合运算的 Ctx 变体返回错误。
+func TestMultiKeySetOpsWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    if _, err := rc.SUnionNCtx(c, "s1", "s2", "s3"); err == nil {
+        t.Error("expected error when SUnionNCtx without valid redis")
+    }
+    if _, err := rc.SDiffNCtx(c, "s1", "s2", "s3"); err == nil {
+        t.Error("expected error when SDiffNCtx without valid redis")
+    }
+    if _, err := rc.SInterNCtx(c, "s1", "s2", "s3"); err == nil {
+        t.Error("expected error when SInterNCtx without valid redis")
+    }
+    if n, err := rc.SInterCardCtx(c, 0, "s1", "s2"); err == nil || n != 0 {
+        t.Errorf("expected error and zero count when SInterCardCtx without valid redis, got n=%d err=%v", n, err)
+    }
+}
+
+// smIsMemberStub 按预置集合计算 SMISMEMBER 结果，并记录收到的成员。
+type smIsMemberStub struct {
+    redis.UniversalClient
+    set     map[interface{}]bool
+    members []interface{}
+    err     error
+}
+
+func (s *smIsMemberStub) SMIsMember(ctx context.Context, key string, members ...interface{}) *redis.BoolSliceCmd {
+    s.members = members
+    if s.err != nil {
+        return redis.NewBoolSliceResult(nil, s.err)
+    }
+    result := make([]bool, len(members))
+    for i, m := range members {
+        result[i] = s.set[m]
+    }
+    return redis.NewBoolSliceResult(result, nil)
+}
+
+// TestSMIsMember 验证批量成员判断的结果与传入元素按位置对应，空成员列表不发送指令，错误原样返回。
+func TestSMIsMember(t *testing.T) {
+    stub := &smIsMemberStub{set: map[interface{}]bool{"a": true, "c": true}}
+    rc := &Client{stub}
+
+    got, err := rc.SMIsMember("s", "a", "b", "c", "a")
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if want := []bool{true, false, true, true}; !reflect.DeepEqual(got, want) {
+        t.Errorf("expected %v, got %v", want, got)
+    }
+    if !reflect.DeepEqual(stub.members, []interface{}{"a", "b", "c", "a"}) {
+        t.Errorf("members should be passed in order, got %v", stub.members)
+    }
+
+    stub.members = nil
+    if got, err := rc.SMIsMember("s"); err != nil || len(got) != 0 || stub.members != nil {
+        t.Errorf("empty members: expected empty result without command, got %v err=%v sent=%v", got, err, stub.members)
+    }
+
+    stub.err = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+    if got, err := rc.SMIsMemberCtx(context.Background(), "s", "a"); err == nil || got != nil {
+        t.Errorf("expected error and nil result, got %v err=%v", got, err)
+    }
+}
@@ -6,6 +6,8 @@ package redis
 
 import (
     "context"
+    "encoding/json"
+    "fmt"
     "time"
 )
 
@@ -89,6 +91,96 @@ func (rc *Client) GetCtx(ctx context.Context, key string) (string, error) {
     return rc.UniversalClient.Get(ctx, key).Result()
 }
 
+// MGet 批量获取多个字符串键的值，一次往返完成，返回值与传入的 keys 按位置对应。
+// 不存在的键（或非字符串类型的键）对应位置为 nil，而不是返回 redis.Nil 错误；keys 为空时直接返回空切片，不发送命令。
+// 参数：
+// - keys: 键名列表
+func (rc *Client) MGet(keys ...string) ([]any, error) {
+    return rc.MGetCtx(ctx, keys...)
+}
+
+// MGetCtx 批量获取多个字符串键的值（带上下文）。
+// 不存在的键对应位置为 nil。
+// 参数：
+// - ctx: 上下文
+// - keys: 键名列表
+func (rc *Client) MGetCtx(ctx context.Context, keys ...string) ([]any, error) {
+    if len(keys) == 0 {
+        return []any{}, nil
+    }
+    return rc.UniversalClient.MGet(ctx, keys...).Result()
+}
+
+// MSet 批量设置多个字符串键的值（原子操作），已存在的键被覆盖。
+// 返回设置结果的状态字符串，例如 "OK"。
+// 参数：
+// - pairs: 键值对，支持 "k1", "v1", "k2", "v2" 形式、[]string{"k1", "v1"} 或 map[string]interface{}
+// 注意：集群模式下所有键须位于同一哈希槽（可使用 {tag} 形式的键名）。
+func (rc *Client) MSet(pairs ...any) (string, error) {
+    return rc.UniversalClient.MSet(ctx, pairs...).Result()
+}
+
+// MSetCtx 批量设置多个字符串键的值（带上下文）。
+// 参数：
+// - ctx: 上下文
+// - pairs: 键值对，形式同 MSet
+func (rc *Client) MSetCtx(ctx context.Context, pairs ...any) (string, error) {
+    return rc.UniversalClient.MSet(ctx, pairs...).Result()
+}
+
+// SetObject 将 value 编码为 JSON 后写入字符串键，expiration 为 0 表示不过期。
+// 编码失败时返回错误且不发送命令。
+// 参数：
+// - key: 键名
+// - value: 待编码的对象（需可被 encoding/json 序列化）
+// - expiration: 过期时长
+func (rc *Client) SetObject(key string, value any, expiration time.Duration) error {
+    return rc.SetObjectCtx(ctx, key, value, expiration)
+}
+
+// SetObjectCtx 将 value 编码为 JSON 后写入字符串键（带上下文）。
+// 参数：
+// - ctx: 上下文
+// - key: 键名
+// - value: 待编码的对象
+// - expiration: 过期时长，0 表示不过期
+func (rc *Client) SetObjectCtx(ctx context.Context, key string, value any, expiration time.Duration) error {
+    data, err := json.Marshal(value)
+    if err != nil {
+        return fmt.Errorf("failed to marshal value for key %q: %w", key, err)
+    }
+    return rc.UniversalClient.Set(ctx, key, string(data), expiration).Err()
+}
+
+// GetObject 读取字符串键并将 JSON 值解码到 dest（须为非 nil 指针）。
+// 键不存在时原样返回 redis.Nil，调用方可用 errors.Is(err, redis.Nil) 区分缓存未命中与解码失败。
+// 参数：
+// - key: 键名
+// - dest: 解码目标
+func (rc *Client) GetObject(key string, dest any) error {
+    return rc.GetObjectCtx(ctx, key, dest)
+}
+
+// GetObjectCtx 读取字符串键并将 JSON 值解码到 dest（带上下文）。
+// 键不存在时返回 redis.Nil。
+// 参数：
+// - ctx: 上下文
+// - key: 键名
+// - dest: 解码目标，须为非 nil 指针
+func (rc *Client) GetObjectCtx(ctx context.Context, key string, dest any) error {
+    if dest == nil {
+        return fmt.Errorf("dest cannot be nil")
+    }
+    val, err := rc.UniversalClient.Get(ctx, key).Result()
+    if err != nil {
+        return err
+    }
+    if err := json.Unmarshal([]byte(val), dest); err != nil {
+        return fmt.Errorf("failed to unmarshal value of key %q: %w", key, err)
+    }
+    return nil
+}
+
 // GetRange 按区间 [startIndex, endIndex] 获取子串。
 // 索引支持负数，-1 表示最后一个字符。
 // 参数：
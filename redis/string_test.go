@@ -3,3 +3,92 @@
 // Author: Amu
 // Description:
 package redis
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// TestMGetMSetWithoutConnection 验证无有效连接时 MGet/MSet 系列方法返回错误且不 panic，以及 MGet 空键列表不发送命令。
+func TestMGetMSetWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    if _, err := rc.MGetCtx(c, "k1", "k2"); err == nil {
+        t.Error("expected error when MGetCtx without valid redis, got nil")
+    }
+    if _, err := rc.MSetCtx(c, "k1", "v1", "k2", "v2"); err == nil {
+        t.Error("expected error when MSetCtx without valid redis, got nil")
+    }
+    w := rc.WithContext(c)
+    if _, err := w.MGet("k1"); err == nil {
+        t.Error("expected error when ContextClient.MGet without valid redis, got nil")
+    }
+    if _, err := w.MSet(map[string]interface{}{"k1": "v1"}); err == nil {
+        t.Error("expected error when ContextClient.MSet without valid redis, got nil")
+    }
+
+    vals, err := rc.MGet()
+    if err != nil || len(vals) != 0 {
+        t.Fatalf("expected empty result without error for no keys, got %v, %v", vals, err)
+    }
+}
+
+// objectProbe 用于验证 SetObject/GetObject 往返的测试对象
+type objectProbe struct {
+    ID    int               `json:"id"`
+    Name  string            `json:"name"`
+    Tags  []string          `json:"tags"`
+    Attrs map[string]string `json:"attrs"`
+}
+
+// TestSetGetObject 验证对象经 JSON 编码写入后可完整读回，键不存在时返回 redis.Nil，编码、解码失败时返回错误。
+func TestSetGetObject(t *testing.T) {
+    stub := &cacheStub{data: make(map[string]string)}
+    rc := &Client{stub}
+
+    in := objectProbe{ID: 7, Name: "alice", Tags: []string{"a", "b"}, Attrs: map[string]string{"env": "dev"}}
+    if err := rc.SetObject("user:7", in, time.Minute); err != nil {
+        t.Fatalf("SetObject failed: %v", err)
+    }
+    if got := stub.data["user:7"]; got != `{"id":7,"name":"alice","tags":["a","b"],"attrs":{"env":"dev"}}` {
+        t.Errorf("unexpected stored value: %s", got)
+    }
+    var out objectProbe
+    if err := rc.GetObject("user:7", &out); err != nil {
+        t.Fatalf("GetObject failed: %v", err)
+    }
+    if !reflect.DeepEqual(in, out) {
+        t.Errorf("expected %+v, got %+v", in, out)
+    }
+
+    w := rc.WithContext(context.Background())
+    if err := w.GetObject("missing", &out); !errors.Is(err, redis.Nil) {
+        t.Errorf("expected redis.Nil for missing key, got %v", err)
+    }
+
+    stub.data["broken"] = "not json"
+    if err := rc.GetObject("broken", &out); err == nil || errors.Is(err, redis.Nil) {
+        t.Errorf("expected decode error, got %v", err)
+    }
+    if err := rc.GetObject("user:7", nil); err == nil {
+        t.Error("expected error for nil dest")
+    }
+    if err := w.SetObject("bad", make(chan int), 0); err == nil {
+        t.Error("expected marshal error for unsupported value")
+    }
+    if _, ok := stub.data["bad"]; ok {
+        t.Error("value should not be written when marshal fails")
+    }
+}
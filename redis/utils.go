@@ -6,10 +6,20 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
+// JitterRand 返回 [0, n) 区间内的随机数，供 SetWithJitter 计算随机 TTL 使用。
+// 默认使用 math/rand（并发安全）；测试中可替换为确定性实现。
+var JitterRand = rand.Int63n
+
 // PingRedis 测试Redis连接是否正常
 func (rc *Client) PingRedis() error {
 	if rc.UniversalClient == nil {
@@ -38,6 +48,24 @@ func (rc *Client) SetWithExpiration(key, value string, expiration time.Duration)
 	return rc.UniversalClient.Set(ctx, key, value, expiration).Err()
 }
 
+// SetWithJitter 设置带随机过期时间的key，实际 TTL 为 base + [0, jitter] 内的随机值。
+// 用于批量写入的缓存项错开过期时间，避免同时失效导致的缓存雪崩；jitter <= 0 时等同于 SetWithExpiration。
+func (rc *Client) SetWithJitter(key, value string, base, jitter time.Duration) error {
+	if rc.UniversalClient == nil {
+		return fmt.Errorf("redis client is nil")
+	}
+
+	return rc.UniversalClient.Set(ctx, key, value, jitteredTTL(base, jitter)).Err()
+}
+
+// jitteredTTL 计算 base + [0, jitter] 内的随机时长。
+func jitteredTTL(base, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return base
+	}
+	return base + time.Duration(JitterRand(int64(jitter)+1))
+}
+
 // GetOrSet 获取key，如果不存在则设置默认值
 func (rc *Client) GetOrSet(key, defaultValue string) (string, error) {
 	if rc.UniversalClient == nil {
@@ -57,6 +85,92 @@ func (rc *Client) GetOrSet(key, defaultValue string) (string, error) {
 	return val, nil
 }
 
+// Remember 实现 cache-aside：命中缓存时直接返回；未命中时调用 loader 加载并以 ttl 写入缓存。
+// 同一客户端上相同 key 的并发未命中只会执行一次 loader，其余调用方等待并共享结果，避免缓存击穿时压垮后端存储。
+// loader 返回错误时不写缓存；读取缓存出错（非 key 不存在）时直接返回错误；写缓存失败时返回加载到的值及错误。
+func (rc *Client) Remember(key string, ttl time.Duration, loader func() (string, error)) (string, error) {
+	if rc.UniversalClient == nil {
+		return "", fmt.Errorf("redis client is nil")
+	}
+	if loader == nil {
+		return "", fmt.Errorf("loader cannot be nil")
+	}
+
+	val, err := rc.UniversalClient.Get(ctx, key).Result()
+	if err == nil {
+		return val, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+
+	return rememberFlight.do(flightKey{client: rc.UniversalClient, key: key}, func() (string, error) {
+		// 上一轮单飞可能在本次检查之后、进入单飞之前刚写完缓存，加载前再读一次避免重复调用 loader
+		val, err := rc.UniversalClient.Get(ctx, key).Result()
+		if err == nil {
+			return val, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			return "", err
+		}
+
+		val, err = loader()
+		if err != nil {
+			return "", err
+		}
+		if err := rc.UniversalClient.Set(ctx, key, val, ttl).Err(); err != nil {
+			return val, fmt.Errorf("failed to cache loaded value: %w", err)
+		}
+		return val, nil
+	})
+}
+
+// rememberFlight 为 Remember 使用的进程内单飞分组。
+var rememberFlight = &flightGroup{calls: make(map[flightKey]*flightCall)}
+
+// flightKey 以客户端与 key 共同标识一次加载，避免不同 Redis 实例的同名 key 互相合并。
+type flightKey struct {
+	client redis.UniversalClient
+	key    string
+}
+
+// flightCall 表示一次进行中的加载，等待方通过 wg 获取结果。
+type flightCall struct {
+	wg  sync.WaitGroup
+	val string
+	err error
+}
+
+// flightGroup 是 singleflight 的最小实现：相同 key 的并发调用只执行一次 fn。
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[flightKey]*flightCall
+}
+
+func (g *flightGroup) do(k flightKey, fn func() (string, error)) (string, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[k]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &flightCall{err: fmt.Errorf("loader panicked")}
+	c.wg.Add(1)
+	g.calls[k] = c
+	g.mu.Unlock()
+
+	// fn 发生 panic 时也要释放等待方并清理记录
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, k)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+	return c.val, c.err
+}
+
 // IncrementAtomic 原子性增加计数器
 func (rc *Client) IncrementAtomic(key string, increment int64) (int64, error) {
 	if rc.UniversalClient == nil {
@@ -66,6 +180,30 @@ func (rc *Client) IncrementAtomic(key string, increment int64) (int64, error) {
 	return rc.UniversalClient.IncrBy(ctx, key, increment).Result()
 }
 
+// incrWithExpiryLua 原子地自增计数，并仅在计数首次创建（值为 1）时设置毫秒级过期时间。
+const incrWithExpiryLua = `
+local current = redis.call("INCR", KEYS[1])
+if current == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return current
+`
+
+var incrWithExpiryScript = redis.NewScript(incrWithExpiryLua)
+
+// IncrWithExpiry 原子地自增 key 并在首次自增时设置过期时间 window，返回自增后的计数。
+// INCR 与 EXPIRE 在同一 Lua 脚本中执行，避免分两步调用时进程崩溃或并发导致计数器永不过期，可作为固定窗口限流的基础原语。
+func (rc *Client) IncrWithExpiry(key string, window time.Duration) (int64, error) {
+	if rc.UniversalClient == nil {
+		return 0, fmt.Errorf("redis client is nil")
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("window must be positive")
+	}
+
+	return incrWithExpiryScript.Run(ctx, rc.UniversalClient, []string{key}, window.Milliseconds()).Int64()
+}
+
 // BatchDelete 批量删除keys
 func (rc *Client) BatchDelete(keys []string) (int64, error) {
 	if rc.UniversalClient == nil {
@@ -79,6 +217,64 @@ func (rc *Client) BatchDelete(keys []string) (int64, error) {
 	return rc.UniversalClient.Del(ctx, keys...).Result()
 }
 
+// redisKeyTypes 为 TYPE 命令可能返回的键类型
+var redisKeyTypes = map[string]struct{}{
+	"string": {}, "list": {}, "set": {}, "zset": {}, "hash": {}, "stream": {},
+}
+
+// DeleteIfType 仅删除类型为 expectedType（如 "hash"、"zset"）的键，返回删除数量与被跳过的键。
+// 先以管道批量执行 TYPE 检查，再一次性删除类型匹配的键；类型不符或不存在（TYPE 为 none）的键计入 skipped。
+// 注意：检查与删除并非原子操作，两者之间键被其他客户端修改类型时仍可能被删除。
+func (rc *Client) DeleteIfType(keys []string, expectedType string) (int64, []string, error) {
+	if rc.UniversalClient == nil {
+		return 0, nil, fmt.Errorf("redis client is nil")
+	}
+
+	expected := strings.ToLower(strings.TrimSpace(expectedType))
+	if _, ok := redisKeyTypes[expected]; !ok {
+		return 0, nil, fmt.Errorf("invalid key type: %q", expectedType)
+	}
+	if len(keys) == 0 {
+		return 0, nil, nil
+	}
+
+	pipe := rc.UniversalClient.Pipeline()
+	cmds := make([]*redis.StatusCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Type(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	types := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		types[i] = cmd.Val()
+	}
+	matched, skipped := partitionKeysByType(keys, types, expected)
+	if len(matched) == 0 {
+		return 0, skipped, nil
+	}
+
+	deleted, err := rc.UniversalClient.Del(ctx, matched...).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	return deleted, skipped, nil
+}
+
+// partitionKeysByType 按 TYPE 结果将键划分为类型匹配与需跳过的两组，保持输入顺序
+func partitionKeysByType(keys, types []string, expected string) (matched, skipped []string) {
+	for i, key := range keys {
+		if i < len(types) && types[i] == expected {
+			matched = append(matched, key)
+		} else {
+			skipped = append(skipped, key)
+		}
+	}
+	return matched, skipped
+}
+
 // BatchExpire 批量设置过期时间
 func (rc *Client) BatchExpire(keys []string, expiration time.Duration) error {
 	if rc.UniversalClient == nil {
@@ -99,6 +295,16 @@ func (rc *Client) BatchExpire(keys []string, expiration time.Duration) error {
 	return err
 }
 
+// PoolStats 返回连接池统计（命中、未命中、超时、空闲与失效连接数等），便于导出到监控指标。
+// 客户端未初始化时返回 nil。
+func (rc *Client) PoolStats() *redis.PoolStats {
+	if rc == nil || rc.UniversalClient == nil {
+		return nil
+	}
+
+	return rc.UniversalClient.PoolStats()
+}
+
 // GetClientInfo 获取客户端信息
 func (rc *Client) GetClientInfo() (map[string]string, error) {
 	if rc.UniversalClient == nil {
@@ -5,8 +5,16 @@
 package redis
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 func TestPingRedis(t *testing.T) {
@@ -218,4 +226,299 @@ func TestValidateConfig(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestSetWithJitter(t *testing.T) {
+	// nil 客户端应返回错误
+	if err := (&Client{}).SetWithJitter("k", "v", time.Minute, time.Second); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer rc.Close()
+
+	if err := rc.SetWithJitter("k", "v", time.Minute, time.Second); err == nil {
+		t.Error("Expected set to fail with invalid Redis connection")
+	}
+}
+
+func TestJitteredTTL(t *testing.T) {
+	base, jitter := time.Minute, 10*time.Second
+
+	// 默认随机源：结果应落在 [base, base+jitter]
+	for i := 0; i < 100; i++ {
+		ttl := jitteredTTL(base, jitter)
+		if ttl < base || ttl > base+jitter {
+			t.Fatalf("ttl %v out of range [%v, %v]", ttl, base, base+jitter)
+		}
+	}
+
+	// 替换随机源以验证边界
+	orig := JitterRand
+	defer func() { JitterRand = orig }()
+
+	JitterRand = func(n int64) int64 { return 0 }
+	if ttl := jitteredTTL(base, jitter); ttl != base {
+		t.Errorf("Expected %v with zero jitter, got %v", base, ttl)
+	}
+	JitterRand = func(n int64) int64 { return n - 1 }
+	if ttl := jitteredTTL(base, jitter); ttl != base+jitter {
+		t.Errorf("Expected %v with max jitter, got %v", base+jitter, ttl)
+	}
+
+	// jitter <= 0 时不引入随机
+	if ttl := jitteredTTL(base, 0); ttl != base {
+		t.Errorf("Expected %v when jitter is 0, got %v", base, ttl)
+	}
+}
+
+// cacheStub 为内存版 Get/Set 桩实现，用于在无 Redis 的情况下验证 Remember 的缓存与单飞逻辑。
+type cacheStub struct {
+	redis.UniversalClient
+	mu   sync.Mutex
+	data map[string]string
+	// staleGets 前 staleGets 次 Get 视为未命中，模拟首次检查之后其他调用方才写入缓存
+	staleGets int
+}
+
+func (s *cacheStub) Get(ctx context.Context, key string) *redis.StringCmd {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.staleGets > 0 {
+		s.staleGets--
+		return redis.NewStringResult("", redis.Nil)
+	}
+	if v, ok := s.data[key]; ok {
+		return redis.NewStringResult(v, nil)
+	}
+	return redis.NewStringResult("", redis.Nil)
+}
+
+func (s *cacheStub) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value.(string)
+	return redis.NewStatusResult("OK", nil)
+}
+
+func TestRememberSingleFlight(t *testing.T) {
+	rc := &Client{&cacheStub{data: make(map[string]string)}}
+
+	var calls int32
+	release := make(chan struct{})
+	loader := func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "loaded", nil
+	}
+
+	const n = 20
+	var wg sync.WaitGroup
+	results := make([]string, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = rc.Remember("user:1", time.Minute, loader)
+		}(i)
+	}
+
+	// 等待首个加载开始后稍作停留，让其余调用方进入等待
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected loader to run once, ran %d times", got)
+	}
+	for i := 0; i < n; i++ {
+		if errs[i] != nil || results[i] != "loaded" {
+			t.Fatalf("caller %d: expected loaded, got %q err=%v", i, results[i], errs[i])
+		}
+	}
+
+	// 已缓存：不再调用 loader
+	if v, err := rc.Remember("user:1", time.Minute, loader); err != nil || v != "loaded" {
+		t.Fatalf("expected cached value, got %q err=%v", v, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected cache hit without loader, ran %d times", got)
+	}
+}
+
+func TestRememberRecheckInFlight(t *testing.T) {
+	// 首次 Get 未命中，但进入单飞前缓存已被上一轮加载写入：不应再调用 loader
+	rc := &Client{&cacheStub{data: map[string]string{"k": "cached"}, staleGets: 1}}
+	v, err := rc.Remember("k", time.Minute, func() (string, error) {
+		t.Fatal("loader should not run when the value was cached before the flight started")
+		return "", nil
+	})
+	if err != nil || v != "cached" {
+		t.Fatalf("expected cached value, got %q err=%v", v, err)
+	}
+}
+
+func TestRememberLoaderError(t *testing.T) {
+	stub := &cacheStub{data: make(map[string]string)}
+	rc := &Client{stub}
+	loadErr := errors.New("backend down")
+	if _, err := rc.Remember("k", time.Minute, func() (string, error) { return "", loadErr }); !errors.Is(err, loadErr) {
+		t.Fatalf("expected loader error, got %v", err)
+	}
+	if _, ok := stub.data["k"]; ok {
+		t.Fatal("loader error should not be cached")
+	}
+
+	if _, err := (&Client{}).Remember("k", time.Minute, func() (string, error) { return "", nil }); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	// 空客户端应返回 nil 而不是 panic
+	if stats := (&Client{}).PoolStats(); stats != nil {
+		t.Errorf("Expected nil stats for empty client, got %+v", stats)
+	}
+
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer rc.Close()
+
+	if stats := rc.PoolStats(); stats == nil {
+		t.Error("Expected pool stats for initialized client")
+	}
+}
+
+// scriptStub 模拟 Redis 对 incrWithExpiryLua 的执行：首次 EVALSHA 返回 NOSCRIPT，
+// 之后按脚本语义在内存中自增计数并记录 PEXPIRE 的调用。
+type scriptStub struct {
+	redis.UniversalClient
+	counters map[string]int64
+	ttls     map[string]string
+	evalSeen bool
+}
+
+// noScriptError 模拟服务端返回的 NOSCRIPT 错误，以触发 Script.Run 回退到 EVAL。
+type noScriptError struct{}
+
+func (noScriptError) Error() string { return "NOSCRIPT No matching script" }
+func (noScriptError) RedisError()   {}
+
+func (s *scriptStub) run(keys []string, args ...interface{}) *redis.Cmd {
+	key := keys[0]
+	s.counters[key]++
+	n := s.counters[key]
+	if n == 1 {
+		s.ttls[key] = fmt.Sprint(args[0])
+	}
+	cmd := redis.NewCmd(context.Background())
+	cmd.SetVal(n)
+	return cmd
+}
+
+func (s *scriptStub) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	if !s.evalSeen {
+		cmd := redis.NewCmd(ctx)
+		cmd.SetErr(noScriptError{})
+		return cmd
+	}
+	return s.run(keys, args...)
+}
+
+func (s *scriptStub) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	s.evalSeen = true
+	if script != incrWithExpiryLua {
+		cmd := redis.NewCmd(ctx)
+		cmd.SetErr(fmt.Errorf("unexpected script: %q", script))
+		return cmd
+	}
+	return s.run(keys, args...)
+}
+
+func TestIncrWithExpiryScript(t *testing.T) {
+	// 脚本必须先 INCR，且仅在计数为 1 时设置过期时间
+	incr := strings.Index(incrWithExpiryLua, `redis.call("INCR", KEYS[1])`)
+	guard := strings.Index(incrWithExpiryLua, "if current == 1 then")
+	expire := strings.Index(incrWithExpiryLua, `redis.call("PEXPIRE", KEYS[1], ARGV[1])`)
+	if incr < 0 || guard < 0 || expire < 0 || !(incr < guard && guard < expire) {
+		t.Fatalf("unexpected script body:\n%s", incrWithExpiryLua)
+	}
+	if !strings.Contains(incrWithExpiryLua, "return current") {
+		t.Fatal("script should return the current count")
+	}
+}
+
+func TestIncrWithExpiry(t *testing.T) {
+	stub := &scriptStub{counters: make(map[string]int64), ttls: make(map[string]string)}
+	rc := &Client{stub}
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := rc.IncrWithExpiry("rate:user:1", 1500*time.Millisecond)
+		if err != nil {
+			t.Fatalf("IncrWithExpiry returned error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("expected count %d, got %d", want, got)
+		}
+	}
+	if ttl := stub.ttls["rate:user:1"]; ttl != "1500" {
+		t.Errorf("expected TTL of 1500ms on first increment, got %q", ttl)
+	}
+
+	if _, err := rc.IncrWithExpiry("rate:user:1", 0); err == nil {
+		t.Error("Expected error for non-positive window")
+	}
+	if _, err := (&Client{}).IncrWithExpiry("k", time.Second); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+}
+
+func TestPartitionKeysByType(t *testing.T) {
+	keys := []string{"user:1", "user:2", "user:3", "user:4"}
+	types := []string{"hash", "string", "hash", "none"}
+
+	matched, skipped := partitionKeysByType(keys, types, "hash")
+	if strings.Join(matched, ",") != "user:1,user:3" {
+		t.Errorf("Expected hash keys to match, got %v", matched)
+	}
+	if strings.Join(skipped, ",") != "user:2,user:4" {
+		t.Errorf("Expected mismatched and missing keys to be skipped, got %v", skipped)
+	}
+
+	// 缺少 TYPE 结果的键视为跳过
+	matched, skipped = partitionKeysByType(keys, types[:1], "hash")
+	if len(matched) != 1 || len(skipped) != 3 {
+		t.Errorf("Expected keys without type results to be skipped, got matched=%v skipped=%v", matched, skipped)
+	}
+}
+
+func TestDeleteIfType(t *testing.T) {
+	if _, _, err := (&Client{}).DeleteIfType([]string{"k"}, "hash"); err == nil {
+		t.Error("Expected error with nil redis client")
+	}
+
+	rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer rc.Close()
+
+	deleted, skipped, err := rc.DeleteIfType(nil, "hash")
+	if err != nil || deleted != 0 || len(skipped) != 0 {
+		t.Errorf("Expected no-op for empty keys, got deleted=%d skipped=%v err=%v", deleted, skipped, err)
+	}
+	if _, _, err := rc.DeleteIfType([]string{"k"}, "document"); err == nil {
+		t.Error("Expected error for unknown key type")
+	}
+	if _, _, err := rc.DeleteIfType([]string{"k"}, " HASH "); err == nil {
+		t.Error("Expected error with invalid Redis connection")
+	}
+}
@@ -6,6 +6,8 @@ package redis
 
 import (
     "context"
+    "fmt"
+
     "github.com/redis/go-redis/v9"
 )
 
@@ -26,6 +28,70 @@ func (rc *Client) ZAddCtx(ctx context.Context, key string, member interface{}, s
     return rc.UniversalClient.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Result()
 }
 
+// ZAddMode 指定 ZADD 的写入模式。
+type ZAddMode string
+
+const (
+    ZAddModeDefault ZAddMode = ""   // 新增或更新
+    ZAddModeNX      ZAddMode = "NX" // 仅新增，不更新已存在成员
+    ZAddModeXX      ZAddMode = "XX" // 仅更新已存在成员，不新增
+)
+
+// ZAddArgs 描述带修饰符的 ZADD 参数。
+// - Mode: NX/XX 写入模式，默认新增或更新；
+// - GT/LT: 仅当新分值大于/小于当前分值时更新（不阻止新增），二者互斥且不能与 NX 同时使用；
+// - Ch: 返回值统计新增与分值发生变化的成员数，而非仅新增数；
+// - Members: 待写入的成员及分值，不能为空。
+type ZAddArgs struct {
+    Mode    ZAddMode
+    GT      bool
+    LT      bool
+    Ch      bool
+    Members []redis.Z
+}
+
+// toRedis 校验参数组合并转换为 go-redis 的 ZAddArgs。
+func (a ZAddArgs) toRedis() (redis.ZAddArgs, error) {
+    if len(a.Members) == 0 {
+        return redis.ZAddArgs{}, fmt.Errorf("zadd members cannot be empty")
+    }
+    if a.GT && a.LT {
+        return redis.ZAddArgs{}, fmt.Errorf("zadd GT and LT are mutually exclusive")
+    }
+    switch a.Mode {
+    case ZAddModeDefault, ZAddModeXX:
+    case ZAddModeNX:
+        if a.GT || a.LT {
+            return redis.ZAddArgs{}, fmt.Errorf("zadd NX cannot be combined with GT or LT")
+        }
+    default:
+        return redis.ZAddArgs{}, fmt.Errorf("unsupported zadd mode: %s", a.Mode)
+    }
+    return redis.ZAddArgs{
+        NX:      a.Mode == ZAddModeNX,
+        XX:      a.Mode == ZAddModeXX,
+        GT:      a.GT,
+        LT:      a.LT,
+        Ch:      a.Ch,
+        Members: a.Members,
+    }, nil
+}
+
+// ZAddArgs 按修饰符向有序集合写入成员，如排行榜仅在新分值更高时更新（GT）。
+// 返回新增成员数；设置 Ch 时返回新增与分值变化的成员总数。
+func (rc *Client) ZAddArgs(key string, args ZAddArgs) (int64, error) {
+    return rc.ZAddArgsCtx(ctx, key, args)
+}
+
+// ZAddArgsCtx 按修饰符向有序集合写入成员（带上下文）。
+func (rc *Client) ZAddArgsCtx(ctx context.Context, key string, args ZAddArgs) (int64, error) {
+    zargs, err := args.toRedis()
+    if err != nil {
+        return 0, err
+    }
+    return rc.UniversalClient.ZAddArgs(ctx, key, zargs).Result()
+}
+
 // ZIncrBy 增加 member 的分值，返回更新后的分值
 func (rc *Client) ZIncrBy(key string, member string, score float64) (float64, error) {
     return rc.UniversalClient.ZIncrBy(ctx, key, score, member).Result()
@@ -65,6 +131,17 @@ func (rc *Client) ZRangeByScoreCtx(ctx context.Context, key string, minScore str
     return rc.UniversalClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
 }
 
+// ZRangeByScoreWithScores 根据分值区间正序获取元素及其分值（WITHSCORES）。
+// min/max 语法同 ZRangeByScore，支持 "-inf"/"+inf" 与 "(" 前缀表示开区间。
+func (rc *Client) ZRangeByScoreWithScores(key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return rc.UniversalClient.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
+}
+
+// ZRangeByScoreWithScoresCtx 根据分值区间正序获取元素及其分值（带上下文）。
+func (rc *Client) ZRangeByScoreWithScoresCtx(ctx context.Context, key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return rc.UniversalClient.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
+}
+
 // ZRevRangeByScore 根据分值区间倒序获取元素（分值从高到低）。
 func (rc *Client) ZRevRangeByScore(key string, minScore string, maxScore string) ([]string, error) {
     return rc.UniversalClient.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
@@ -75,6 +152,16 @@ func (rc *Client) ZRevRangeByScoreCtx(ctx context.Context, key string, minScore
     return rc.UniversalClient.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
 }
 
+// ZRevRangeByScoreWithScores 根据分值区间倒序获取元素及其分值（分值从高到低，WITHSCORES）。
+func (rc *Client) ZRevRangeByScoreWithScores(key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return rc.UniversalClient.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
+}
+
+// ZRevRangeByScoreWithScoresCtx 根据分值区间倒序获取元素及其分值（分值从高到低，带上下文）。
+func (rc *Client) ZRevRangeByScoreWithScoresCtx(ctx context.Context, key string, minScore string, maxScore string) ([]redis.Z, error) {
+    return rc.UniversalClient.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: minScore, Max: maxScore}).Result()
+}
+
 func (rc *Client) ZCard(key string) (int64, error) {
     return rc.UniversalClient.ZCard(ctx, key).Result()
 }
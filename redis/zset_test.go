@@ -3,3 +3,122 @@
 // Author: Amu
 // Description:
 package redis
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// TestZAddArgsMapping 验证 ZAddArgs 到 go-redis ZAddArgs 的映射与非法组合校验。
+func TestZAddArgsMapping(t *testing.T) {
+    members := []redis.Z{{Score: 100, Member: "alice"}}
+
+    got, err := ZAddArgs{Mode: ZAddModeXX, GT: true, Ch: true, Members: members}.toRedis()
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if !got.XX || got.NX || !got.GT || got.LT || !got.Ch || len(got.Members) != 1 || got.Members[0].Member != "alice" {
+        t.Errorf("unexpected mapping: %+v", got)
+    }
+
+    got, err = ZAddArgs{Mode: ZAddModeNX, Members: members}.toRedis()
+    if err != nil || !got.NX || got.XX {
+        t.Errorf("expected NX mapping, got %+v err=%v", got, err)
+    }
+
+    invalid := []ZAddArgs{
+        {Members: nil},
+        {GT: true, LT: true, Members: members},
+        {Mode: ZAddModeNX, GT: true, Members: members},
+        {Mode: "YY", Members: members},
+    }
+    for _, args := range invalid {
+        if _, err := args.toRedis(); err == nil {
+            t.Errorf("expected error for %+v", args)
+        }
+    }
+}
+
+// TestZAddArgsWithoutConnection 验证无有效连接时返回错误且不 panic。
+func TestZAddArgsWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    args := ZAddArgs{GT: true, Members: []redis.Z{{Score: 1, Member: "m"}}}
+    if _, err := rc.ZAddArgsCtx(c, "board", args); err == nil {
+        t.Error("expected error when ZAddArgsCtx without valid redis, got nil")
+    }
+    if _, err := rc.WithContext(c).ZAddArgs("board", args); err == nil {
+        t.Error("expected error when ContextClient.ZAddArgs without valid redis, got nil")
+    }
+}
+
+// zsetStub 覆盖带分值的区间查询，按调用记录参数并返回固定结果。
+type zsetStub struct {
+    redis.UniversalClient
+    by  *redis.ZRangeBy
+    rev bool
+}
+
+func (s *zsetStub) ZRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd {
+    s.by, s.rev = opt, false
+    return redis.NewZSliceCmdResult([]redis.Z{{Score: 10, Member: "a"}, {Score: 20, Member: "b"}}, nil)
+}
+
+func (s *zsetStub) ZRevRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd {
+    s.by, s.rev = opt, true
+    return redis.NewZSliceCmdResult([]redis.Z{{Score: 20, Member: "b"}, {Score: 10, Member: "a"}}, nil)
+}
+
+// TestZRangeByScoreWithScores 验证返回结果同时携带成员与分值，且区间参数被正确传递。
+func TestZRangeByScoreWithScores(t *testing.T) {
+    stub := &zsetStub{}
+    rc := &Client{stub}
+
+    got, err := rc.ZRangeByScoreWithScores("board", "0", "+inf")
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if len(got) != 2 || got[0].Member != "a" || got[0].Score != 10 || stub.rev {
+        t.Errorf("unexpected result: %+v", got)
+    }
+    if stub.by.Min != "0" || stub.by.Max != "+inf" {
+        t.Errorf("unexpected range: %+v", stub.by)
+    }
+
+    got, err = rc.WithContext(context.Background()).ZRevRangeByScoreWithScores("board", "(5", "100")
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if len(got) != 2 || got[0].Member != "b" || got[0].Score != 20 || !stub.rev || stub.by.Min != "(5" {
+        t.Errorf("unexpected reverse result: %+v range=%+v", got, stub.by)
+    }
+}
+
+// TestZRangeByScoreWithScoresWithoutConnection 验证无有效连接时返回错误且不 panic。
+func TestZRangeByScoreWithScoresWithoutConnection(t *testing.T) {
+    rc, err := NewClientWithoutPing(WithAddrs([]string{"localhost:9999"}))
+    if err != nil {
+        t.Fatalf("failed to create client without ping: %v", err)
+    }
+    defer rc.Close()
+
+    c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+
+    if _, err := rc.ZRangeByScoreWithScoresCtx(c, "board", "-inf", "+inf"); err == nil {
+        t.Error("expected error when ZRangeByScoreWithScoresCtx without valid redis, got nil")
+    }
+    if _, err := rc.ZRevRangeByScoreWithScoresCtx(c, "board", "-inf", "+inf"); err == nil {
+        t.Error("expected error when ZRevRangeByScoreWithScoresCtx without valid redis, got nil")
+    }
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import "strings"
+
+// optionAuditKey 为 GORM Statement.Settings 中保存已应用选项名称的键名。
+const optionAuditKey = "conan:applied_options"
+
+// WithOptionAudit 开启查询选项审计：其后成功应用的选项会按顺序记录名称（如 "WithId"、"OrderAsc:created_at"），
+// 仅包含选项名与列/表名，不记录任何绑定值，可通过 AppliedOptions 读取用于审计日志。
+// 默认关闭以避免额外开销；应作为 OptionDB 的第一个选项传入，之前的选项不会被记录。
+func WithOptionAudit() QueryOption {
+	return func(db *DB) *DB {
+		if _, ok := db.DB.Get(optionAuditKey); ok {
+			return db
+		}
+		db.DB = db.DB.Set(optionAuditKey, []string{})
+		return db
+	}
+}
+
+// AppliedOptions 返回当前查询会话已记录的选项名称副本；未开启审计时返回 nil。
+func AppliedOptions(db *DB) []string {
+	if db == nil || db.DB == nil || db.DB.Statement == nil {
+		return nil
+	}
+	v, ok := db.DB.Get(optionAuditKey)
+	if !ok {
+		return nil
+	}
+	names, _ := v.([]string)
+	return append([]string{}, names...)
+}
+
+// recordOption 在开启审计时追加一条选项记录，details 以冒号拼接在名称之后。
+// 每次写入新切片，避免克隆出的会话共享底层数组而互相影响。
+func recordOption(db *DB, name string, details ...string) {
+	v, ok := db.DB.Get(optionAuditKey)
+	if !ok {
+		return
+	}
+	names, _ := v.([]string)
+	entry := strings.Join(append([]string{name}, details...), ":")
+	db.DB = db.DB.Set(optionAuditKey, append(names[:len(names):len(names)], entry))
+}
@@ -1,12 +1,37 @@
 package sqlite
 
+import "time"
+
 type Config struct {
-	Debug        bool   // 是否开启调试模式，默认 false
-	AutoMigrate  bool   // 是否自动迁移数据库结构，默认 false
-	Type         string // 数据库类型，默认 sqlite
-	DatabasePath string // 数据库文件路径，默认 :memory:
-	MaxLifetime  int    // 连接最大生命周期，默认 5 分钟
-	MaxOpenConns int    // 最大打开连接数，默认 100
-	MaxIdleConns int    // 最大空闲连接数，默认 100
-	BusyTimeout  int    // SQLite 忙等待超时时间，默认 5 秒
-}
\ No newline at end of file
+	Debug         bool   // 是否开启调试模式，默认 false
+	AutoMigrate   bool   // 是否自动迁移数据库结构，默认 false
+	Type          string // 数据库类型，默认 sqlite
+	DatabasePath  string // 数据库文件路径，默认 :memory:
+	MaxLifetime   int    // 连接最大生命周期，默认 5 分钟
+	MaxOpenConns  int    // 最大打开连接数，默认 100
+	MaxIdleConns  int    // 最大空闲连接数，默认 100
+	MaxIdleTime   int    // 连接最大空闲时间（秒），超时的空闲连接会被回收，默认 0 表示不限制
+	TablePrefix   string // 表名前缀（如 "app_"），默认无前缀
+	SingularTable bool   // 是否使用单数表名（user 而非 users），默认 false
+	BusyTimeout   int    // SQLite 忙等待超时时间（秒），未设置时默认 5 秒
+	JournalMode   string // 日志模式（_journal_mode，如 WAL、DELETE），默认 WAL
+	// DisableForeignKeys 是否关闭外键约束（_foreign_keys=off），默认 false 即开启外键约束
+	DisableForeignKeys bool
+	// DefaultQueryTimeout 默认查询超时，大于 0 时每次执行（查询、写入、原生 SQL 等）在上下文未设置截止时间的情况下
+	// 自动派生带该超时的子上下文，防止失控查询长期占用连接；传入的上下文已设置截止时间时以其为准，默认 0 表示不限制
+	DefaultQueryTimeout time.Duration
+}
+
+// DefaultConfig 返回预填充文档默认值的配置（内存数据库、忙等待 5 秒、WAL 日志模式、连接池 100/100、生命周期 300 秒），
+// 调用方只需覆盖数据库文件路径等差异项即可。
+func DefaultConfig() *Config {
+	return &Config{
+		Type:         "sqlite",
+		DatabasePath: ":memory:",
+		MaxLifetime:  300,
+		MaxOpenConns: 100,
+		MaxIdleConns: 100,
+		BusyTimeout:  5,
+		JournalMode:  "WAL",
+	}
+}
@@ -0,0 +1,22 @@
+package sqlite
+
+import "testing"
+
+// TestDefaultConfig 验证 DefaultConfig 返回文档约定的默认值，且可直接用于打开内存数据库。
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.DatabasePath != ":memory:" || cfg.BusyTimeout != 5 {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.MaxLifetime != 300 || cfg.MaxOpenConns != 100 || cfg.MaxIdleConns != 100 {
+		t.Fatalf("unexpected pool defaults: %+v", cfg)
+	}
+
+	db, err := NewDB(cfg)
+	if err != nil {
+		t.Fatalf("NewDB with DefaultConfig failed: %v", err)
+	}
+	if sqlDB, err := db.DB.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+}
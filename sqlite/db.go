@@ -1,13 +1,33 @@
 package sqlite
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
+// Database 抽象 clickhouse、pg、sqlite 三个驱动包中 *DB 的公共能力。
+// 三个包定义的 Database 方法集完全一致，应用可依赖该接口（或自行声明同样的接口）编写与驱动无关的代码并按需切换驱动。
+type Database interface {
+	// Ping 检查数据库连通性
+	Ping(ctx context.Context) error
+	// Close 关闭底层连接池
+	Close() error
+	// Gorm 返回底层 *gorm.DB，用于构造查询
+	Gorm() *gorm.DB
+	// AutoMigrate 迁移模型结构（pg 包的实现仅在 Config.AutoMigrate 开启时执行）
+	AutoMigrate(models ...any) error
+}
+
 type DB struct {
 	*gorm.DB
 	autoMigrate bool
@@ -16,7 +36,7 @@ type DB struct {
 func NewDB(config *Config) (*DB, error) {
 
 	dial := dial(config)
-	db, err := gorm.Open(dial, &gorm.Config{})
+	db, err := gorm.Open(dial, newGormConfig(config))
 	if err != nil {
 		return nil, err
 	}
@@ -30,19 +50,128 @@ func NewDB(config *Config) (*DB, error) {
 		return nil, err
 	}
 
-	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
-	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(config.MaxLifetime))
+	configureConnectionPool(sqlDB, config)
+
+	if config.DefaultQueryTimeout > 0 {
+		if err := registerQueryTimeout(db, config.DefaultQueryTimeout); err != nil {
+			return nil, err
+		}
+	}
 
 	return &DB{DB: db, autoMigrate: config.AutoMigrate}, nil
 }
 
+// connPool 抽象连接池参数设置方法（由 *sql.DB 实现），便于测试中替换。
+type connPool interface {
+	SetMaxIdleConns(n int)
+	SetMaxOpenConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+	SetConnMaxIdleTime(d time.Duration)
+}
+
+// configureConnectionPool 按配置设置连接池参数：最大空闲/打开连接数、连接生命周期与最大空闲时间。
+func configureConnectionPool(p connPool, cfg *Config) {
+	p.SetMaxIdleConns(cfg.MaxIdleConns)
+	p.SetMaxOpenConns(cfg.MaxOpenConns)
+	p.SetConnMaxLifetime(time.Second * time.Duration(cfg.MaxLifetime))
+	p.SetConnMaxIdleTime(time.Second * time.Duration(cfg.MaxIdleTime))
+}
+
+// newGormConfig 根据配置构建 gorm.Config，通过 NamingStrategy 应用表名前缀与单数表名设置。
+// 两项均未配置时与 GORM 默认命名（复数 snake_case）一致。
+func newGormConfig(cfg *Config) *gorm.Config {
+	return &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{
+			TablePrefix:         cfg.TablePrefix,
+			SingularTable:       cfg.SingularTable,
+			IdentifierMaxLength: 64,
+		},
+	}
+}
+
 func dial(cfg *Config) gorm.Dialector {
+	dialector := sqlite.Open(buildDSN(cfg))
+	return dialector
+}
+
+// buildDSN 构建数据库文件的 DSN，追加忙等待超时、日志模式与外键约束参数：
+// BusyTimeout 未设置时默认 5000 毫秒，JournalMode 未设置时默认 WAL；
+// DatabasePath 中已显式给出的同名参数保持不变，内存数据库不追加任何参数。
+func buildDSN(cfg *Config) string {
 	dsn := cfg.DatabasePath
 	if dsn == "" {
 		dsn = ":memory:"
 	}
+	if isMemoryPath(dsn) {
+		return dsn
+	}
 
-	dialector := sqlite.Open(dsn)
-	return dialector
-}
\ No newline at end of file
+	busyTimeout := 5000
+	if cfg.BusyTimeout > 0 {
+		busyTimeout = cfg.BusyTimeout * 1000
+	}
+	journalMode := cfg.JournalMode
+	if journalMode == "" {
+		journalMode = "WAL"
+	}
+	foreignKeys := "on"
+	if cfg.DisableForeignKeys {
+		foreignKeys = "off"
+	}
+
+	params := []struct{ key, value string }{
+		{"_busy_timeout", strconv.Itoa(busyTimeout)},
+		{"_journal_mode", journalMode},
+		{"_foreign_keys", foreignKeys},
+	}
+	for _, p := range params {
+		if strings.Contains(dsn, p.key+"=") {
+			continue
+		}
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + p.key + "=" + url.QueryEscape(p.value)
+	}
+	return dsn
+}
+
+// isMemoryPath 判断 DSN 是否指向内存数据库（:memory:、file::memory: 或 mode=memory）
+func isMemoryPath(dsn string) bool {
+	return dsn == ":memory:" || strings.HasPrefix(dsn, "file::memory:") || strings.Contains(dsn, "mode=memory")
+}
+
+// Ping 检查数据库连通性，调用方可通过带超时的 ctx 控制最长等待时间。
+func (d *DB) Ping(ctx context.Context) error {
+	sqlDB, err := d.sqlDB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close 关闭底层连接池，关闭后该实例不可再使用。
+func (d *DB) Close() error {
+	sqlDB, err := d.sqlDB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Gorm 返回底层 *gorm.DB；d 为空时返回 nil。
+func (d *DB) Gorm() *gorm.DB {
+	if d == nil {
+		return nil
+	}
+	return d.DB
+}
+
+// sqlDB 返回底层 *sql.DB 连接池。
+func (d *DB) sqlDB() (*sql.DB, error) {
+	if d == nil || d.DB == nil {
+		return nil, errors.New("database instance is nil")
+	}
+	return d.DB.DB()
+}
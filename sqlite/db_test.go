@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// recordingPool 记录连接池参数设置调用，用于验证 MaxIdleTime 被传递。
+type recordingPool struct {
+	idle, open        int
+	lifetime, idleTTL time.Duration
+}
+
+func (p *recordingPool) SetMaxIdleConns(n int)              { p.idle = n }
+func (p *recordingPool) SetMaxOpenConns(n int)              { p.open = n }
+func (p *recordingPool) SetConnMaxLifetime(d time.Duration) { p.lifetime = d }
+func (p *recordingPool) SetConnMaxIdleTime(d time.Duration) { p.idleTTL = d }
+
+// TestConfigureConnectionPoolIdleTime 验证 MaxIdleTime 以秒为单位设置到连接池，并可作用于真实的 *sql.DB。
+func TestConfigureConnectionPoolIdleTime(t *testing.T) {
+	cfg := &Config{MaxIdleConns: 5, MaxOpenConns: 10, MaxLifetime: 300, MaxIdleTime: 60}
+	p := &recordingPool{}
+	configureConnectionPool(p, cfg)
+	if p.idleTTL != time.Minute || p.lifetime != 5*time.Minute || p.open != 10 || p.idle != 5 {
+		t.Fatalf("unexpected pool settings: %+v", p)
+	}
+
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+	configureConnectionPool(sqlDB, cfg)
+	if stats := sqlDB.Stats(); stats.MaxOpenConnections != 10 {
+		t.Fatalf("expected MaxOpenConnections=10, got %d", stats.MaxOpenConnections)
+	}
+}
+
+// namingProbe 用于验证命名策略的测试模型
+type namingProbe struct {
+	ID   uint
+	Name string
+}
+
+// TestNewGormConfigNaming 验证 TablePrefix 与 SingularTable 通过 NamingStrategy 作用于迁移生成的表名。
+func TestNewGormConfigNaming(t *testing.T) {
+	cases := []struct {
+		cfg   *Config
+		table string
+	}{
+		{cfg: &Config{}, table: "naming_probes"},
+		{cfg: &Config{SingularTable: true}, table: "naming_probe"},
+		{cfg: &Config{TablePrefix: "app_", SingularTable: true}, table: "app_naming_probe"},
+		{cfg: &Config{TablePrefix: "app_"}, table: "app_naming_probes"},
+	}
+	for _, c := range cases {
+		gdb, err := gorm.Open(sqlite.Open(":memory:"), newGormConfig(c.cfg))
+		if err != nil {
+			t.Fatalf("failed to open sqlite: %v", err)
+		}
+		sqlDB, err := gdb.DB()
+		if err != nil {
+			t.Fatalf("failed to get sql.DB: %v", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+		if err := gdb.AutoMigrate(&namingProbe{}); err != nil {
+			t.Fatalf("auto migrate failed: %v", err)
+		}
+		if !gdb.Migrator().HasTable(c.table) {
+			t.Errorf("expected table %q for config %+v", c.table, c.cfg)
+		}
+		sqlDB.Close()
+	}
+}
+
+// TestNewDBNamingStrategy 验证 NewDB 将表名前缀与单数表名配置应用于迁移。
+func TestNewDBNamingStrategy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxOpenConns = 1
+	cfg.MaxIdleConns = 1
+	cfg.TablePrefix = "app_"
+	cfg.SingularTable = true
+
+	db, err := NewDB(cfg)
+	if err != nil {
+		t.Fatalf("NewDB failed: %v", err)
+	}
+	if err := db.AutoMigrate(&namingProbe{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	if !db.Migrator().HasTable("app_naming_probe") {
+		t.Fatal("expected prefixed singular table app_naming_probe")
+	}
+}
+
+// 编译期断言：*DB 实现 Database 接口
+var _ Database = (*DB)(nil)
+
+// TestDatabaseInterface 验证 Database 接口方法：Gorm 返回底层实例，Ping 在关闭前成功、关闭后失败；空实例返回错误。
+func TestDatabaseInterface(t *testing.T) {
+	gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	var d Database = &DB{DB: gdb}
+	if d.Gorm() != gdb {
+		t.Fatal("Gorm should return the underlying *gorm.DB")
+	}
+	if err := d.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := d.Ping(context.Background()); err == nil {
+		t.Fatal("Ping should fail after Close")
+	}
+
+	var empty *DB
+	if empty.Gorm() != nil {
+		t.Fatal("Gorm on nil DB should return nil")
+	}
+	if err := empty.Close(); err == nil {
+		t.Fatalf("Close on nil DB should fail, got: %v", err)
+	}
+}
+
+// TestBuildDSNPragmas 验证 DSN 按配置追加忙等待、日志模式与外键参数，显式给出的参数保持不变，内存数据库不追加参数。
+func TestBuildDSNPragmas(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  *Config
+		want string
+	}{
+		{"defaults", &Config{DatabasePath: "app.db"}, "app.db?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on"},
+		{"configured", &Config{DatabasePath: "app.db", BusyTimeout: 2, JournalMode: "DELETE", DisableForeignKeys: true},
+			"app.db?_busy_timeout=2000&_journal_mode=DELETE&_foreign_keys=off"},
+		{"explicit param kept", &Config{DatabasePath: "app.db?_journal_mode=TRUNCATE"},
+			"app.db?_journal_mode=TRUNCATE&_busy_timeout=5000&_foreign_keys=on"},
+		{"empty path", &Config{}, ":memory:"},
+		{"memory", &Config{DatabasePath: ":memory:", BusyTimeout: 2}, ":memory:"},
+		{"shared memory", &Config{DatabasePath: "file::memory:?cache=shared"}, "file::memory:?cache=shared"},
+	}
+	for _, tc := range cases {
+		if got := buildDSN(tc.cfg); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestNewDBPragmas 打开基于文件的数据库，验证 PRAGMA busy_timeout、journal_mode 与 foreign_keys 与配置一致。
+func TestNewDBPragmas(t *testing.T) {
+	pragmas := func(t *testing.T, cfg *Config) (busyTimeout int, journalMode string, foreignKeys int) {
+		t.Helper()
+		db, err := NewDB(cfg)
+		if err != nil {
+			t.Fatalf("NewDB failed: %v", err)
+		}
+		defer db.Close()
+		if err := db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout).Error; err != nil {
+			t.Fatalf("query busy_timeout failed: %v", err)
+		}
+		if err := db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error; err != nil {
+			t.Fatalf("query journal_mode failed: %v", err)
+		}
+		if err := db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error; err != nil {
+			t.Fatalf("query foreign_keys failed: %v", err)
+		}
+		return busyTimeout, journalMode, foreignKeys
+	}
+
+	cfg := DefaultConfig()
+	cfg.DatabasePath = filepath.Join(t.TempDir(), "default.db")
+	cfg.BusyTimeout = 0
+	cfg.JournalMode = ""
+	if busy, mode, fk := pragmas(t, cfg); busy != 5000 || mode != "wal" || fk != 1 {
+		t.Fatalf("unexpected default pragmas: busy_timeout=%d journal_mode=%s foreign_keys=%d", busy, mode, fk)
+	}
+
+	cfg = DefaultConfig()
+	cfg.DatabasePath = filepath.Join(t.TempDir(), "custom.db")
+	cfg.BusyTimeout = 2
+	cfg.JournalMode = "DELETE"
+	cfg.DisableForeignKeys = true
+	if busy, mode, fk := pragmas(t, cfg); busy != 2000 || mode != "delete" || fk != 0 {
+		t.Fatalf("unexpected configured pragmas: busy_timeout=%d journal_mode=%s foreign_keys=%d", busy, mode, fk)
+	}
+}
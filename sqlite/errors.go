@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrorType 定义错误的分类类型
+type ErrorType string
+
+const (
+	// ErrorTypeQuery 查询相关错误
+	ErrorTypeQuery ErrorType = "query"
+	// ErrorTypeValidation 参数验证错误
+	ErrorTypeValidation ErrorType = "validation"
+)
+
+// SQLiteError 自定义错误结构，与 clickhouse 包的 ClickHouseError 保持一致的分类方式
+type SQLiteError struct {
+	Type    ErrorType
+	Message string
+	Cause   error
+	Context map[string]interface{}
+	Code    string // 可选的错误代码
+}
+
+// Error 实现 error 接口，上下文按键名排序输出
+func (e *SQLiteError) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("[%s] %s", e.Type, e.Message))
+
+	if e.Code != "" {
+		builder.WriteString(fmt.Sprintf(" (code: %s)", e.Code))
+	}
+
+	if e.Cause != nil {
+		builder.WriteString(fmt.Sprintf(": %v", e.Cause))
+	}
+
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		builder.WriteString(" | context: ")
+		for i, k := range keys {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(fmt.Sprintf("%s=%v", k, e.Context[k]))
+		}
+	}
+
+	return builder.String()
+}
+
+// Unwrap 支持 errors.Unwrap
+func (e *SQLiteError) Unwrap() error {
+	return e.Cause
+}
+
+// Is 支持 errors.Is：类型与错误代码均相同时视为匹配
+func (e *SQLiteError) Is(target error) bool {
+	if sqliteErr, ok := target.(*SQLiteError); ok {
+		return e.Type == sqliteErr.Type && e.Code == sqliteErr.Code
+	}
+	return false
+}
+
+// NewSQLiteError 创建新的 sqlite 错误
+func NewSQLiteError(errType ErrorType, message string, cause error) *SQLiteError {
+	return &SQLiteError{
+		Type:    errType,
+		Message: message,
+		Cause:   cause,
+		Context: make(map[string]interface{}),
+	}
+}
+
+// WithContext 添加上下文信息
+func (e *SQLiteError) WithContext(key string, value interface{}) *SQLiteError {
+	if e.Context == nil {
+		e.Context = make(map[string]interface{})
+	}
+	e.Context[key] = value
+	return e
+}
+
+// WithCode 设置错误代码
+func (e *SQLiteError) WithCode(code string) *SQLiteError {
+	e.Code = code
+	return e
+}
+
+// IsQueryError 判断是否为查询错误
+func IsQueryError(err error) bool {
+	var sqliteErr *SQLiteError
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Type == ErrorTypeQuery
+	}
+	return false
+}
+
+// IsValidationError 判断是否为验证错误
+func IsValidationError(err error) bool {
+	var sqliteErr *SQLiteError
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Type == ErrorTypeValidation
+	}
+	return false
+}
+
+// WrapError 包装现有错误为 SQLiteError；err 已是 SQLiteError 时保留其类型与代码，只在消息前追加说明
+func WrapError(err error, errType ErrorType, message string) *SQLiteError {
+	if err == nil {
+		return NewSQLiteError(errType, message, nil)
+	}
+
+	if sqliteErr, ok := err.(*SQLiteError); ok {
+		return &SQLiteError{
+			Type:    sqliteErr.Type,
+			Message: message + ": " + sqliteErr.Message,
+			Cause:   sqliteErr.Cause,
+			Context: sqliteErr.Context,
+			Code:    sqliteErr.Code,
+		}
+	}
+
+	return NewSQLiteError(errType, message, err)
+}
+
+// NewQueryError 创建查询错误
+func NewQueryError(message string, cause error) *SQLiteError {
+	return NewSQLiteError(ErrorTypeQuery, message, cause).
+		WithCode("QUERY_ERROR")
+}
+
+// NewValidationError 创建验证错误
+func NewValidationError(message string, cause error) *SQLiteError {
+	return NewSQLiteError(ErrorTypeValidation, message, cause).
+		WithCode("VALIDATION_ERROR")
+}
@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSQLiteError 验证错误消息格式（上下文按键名排序）、errors.Is/As 匹配与 WrapError 保留原有类型。
+func TestSQLiteError(t *testing.T) {
+	cause := errors.New("boom")
+	err := NewQueryError("query failed", cause).
+		WithContext("table", "users").
+		WithContext("index", 1)
+	want := "[query] query failed (code: QUERY_ERROR): boom | context: index=1, table=users"
+	if err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is should match the cause")
+	}
+	if !errors.Is(err, NewQueryError("other", nil)) || errors.Is(err, NewValidationError("other", nil)) {
+		t.Error("errors.Is should match on type and code")
+	}
+
+	wrapped := WrapError(NewValidationError("bad field", nil).WithCode("FIELD_INVALID"), ErrorTypeQuery, "apply options")
+	if !IsValidationError(wrapped) || wrapped.Code != "FIELD_INVALID" || wrapped.Message != "apply options: bad field" {
+		t.Errorf("WrapError should keep the original type and code, got %+v", wrapped)
+	}
+	if !IsQueryError(WrapError(cause, ErrorTypeQuery, "wrapped")) {
+		t.Error("WrapError should use the given type for plain errors")
+	}
+	if IsQueryError(cause) || IsValidationError(nil) {
+		t.Error("plain and nil errors should not match")
+	}
+}
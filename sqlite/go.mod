@@ -3,11 +3,14 @@ module github.com/amuluze/conan/sqlite
 go 1.24
 
 require gorm.io/gorm v1.31.1
-require gorm.io/driver/sqlite v1.6.0
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.22
+	gorm.io/driver/sqlite v1.6.0
+)
 
 require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
-	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	golang.org/x/text v0.21.0 // indirect
-)
\ No newline at end of file
+)
@@ -1,6 +1,14 @@
 package sqlite
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
 
 // QueryOption 定义对 *DB 进行链式包装的函数类型，返回经变更后的 *DB，便于组合多个查询配置。
 // 所有实现都应满足幂等与安全（避免 SQL 注入）的要求。
@@ -8,19 +16,61 @@ type QueryOption func(db *DB) *DB
 
 // OptionDB 按序应用一组 QueryOption 到传入的 *DB 并返回更新后的 *DB。
 // 为提升健壮性：
-// 1) 当 db 为 nil 时直接返回 nil，避免后续调用产生 panic；
-// 2) 跳过为 nil 的选项，保证调用安全。
-func OptionDB(db *DB, options ...QueryOption) *DB {
+// 1) 当 db 为 nil 时返回查询错误；
+// 2) 跳过为 nil 的选项，保证调用安全；
+// 3) 捕获选项中的 panic 并以查询错误返回，避免崩溃调用方；选项返回 nil 时同样返回错误。
+func OptionDB(db *DB, options ...QueryOption) (*DB, error) {
 	if db == nil {
-		return nil
+		return nil, NewQueryError("database instance cannot be nil", nil).
+			WithCode("DB_NIL")
+	}
+
+	if db.DB == nil {
+		return nil, NewQueryError("gorm database instance cannot be nil", nil).
+			WithCode("GORM_DB_NIL")
 	}
-	for _, option := range options {
+
+	var lastError error
+	for i, option := range options {
 		if option == nil {
 			continue
 		}
-		db = option(db)
+
+		// 在应用选项时进行错误捕获
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					lastError = NewQueryError(fmt.Sprintf("panic applying query option at index %d", i),
+						fmt.Errorf("panic: %v", r)).
+						WithCode("QUERY_OPTION_PANIC").
+						WithContext("option_index", i)
+				}
+			}()
+			db = option(db)
+		}()
+
+		if db == nil {
+			return nil, NewQueryError(fmt.Sprintf("query option at index %d returned nil database", i), lastError).
+				WithCode("QUERY_OPTION_NIL_RESULT").
+				WithContext("option_index", i)
+		}
+	}
+
+	if lastError != nil {
+		return db, WrapError(lastError, ErrorTypeQuery, "some query options failed to apply")
+	}
+
+	return db, nil
+}
+
+// OptionDBMust 与 OptionDB 功能相同，但不返回错误，用于向后兼容旧版 OptionDB 的单返回值签名：
+// 出错时返回传入的 db（db 为 nil 时即返回 nil）。需要感知选项 panic 等错误时请使用 OptionDB。
+func OptionDBMust(db *DB, options ...QueryOption) *DB {
+	result, err := OptionDB(db, options...)
+	if err != nil {
+		return db
 	}
-	return db
+	return result
 }
 
 // WithTable 设置查询所使用的表名。
@@ -32,6 +82,29 @@ func WithTable(tableName string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Table(t)
+		recordOption(db, "WithTable", t)
+		return db
+	}
+}
+
+// WithTableSafe 设置查询表名并强制进行白名单校验，防止通过非法表名造成 SQL 注入。
+// 规则：
+// - 空白表名会被忽略，保持既有表名不变；
+// - 不在白名单中的表名会被忽略；
+// - 仅当 tableName 存在于 whitelist 时才会应用。
+// 使用建议：表名可能来自外部输入时优先使用该函数替代 WithTable。
+func WithTableSafe(tableName string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		t := strings.TrimSpace(tableName)
+		if t == "" {
+			return db
+		}
+		if _, ok := whitelist[t]; !ok {
+			// 非白名单表名直接忽略
+			return db
+		}
+		db.DB = db.DB.Table(t)
+		recordOption(db, "WithTableSafe", t)
 		return db
 	}
 }
@@ -43,6 +116,7 @@ func WithId(id string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("id = ?", id)
+		recordOption(db, "WithId")
 		return db
 	}
 }
@@ -54,6 +128,7 @@ func WithUserName(username string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("username = ?", username)
+		recordOption(db, "WithUserName")
 		return db
 	}
 }
@@ -65,6 +140,7 @@ func WithName(name string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("name = ?", name)
+		recordOption(db, "WithName")
 		return db
 	}
 }
@@ -77,10 +153,153 @@ func WithStatus(status int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("status = ?", status)
+		recordOption(db, "WithStatus")
+		return db
+	}
+}
+
+// WithTransformedEq 对绑定值应用 transform 后追加等值条件（field = ?）。
+// 用于集中处理规范化存储的列（如小写邮箱、去空白的名称），保证查询值与存储值一致。
+// field 必须出现在白名单中；transform 为 nil 时按原值绑定。
+func WithTransformedEq(field string, value string, transform func(string) string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || !identifierPattern.MatchString(f) {
+			return db
+		}
+		if _, ok := whitelist[f]; !ok {
+			return db
+		}
+		v := value
+		if transform != nil {
+			v = transform(v)
+		}
+		db.DB = db.DB.Where(columnName(f)+" = ?", v)
+		recordOption(db, "WithTransformedEq", f)
+		return db
+	}
+}
+
+// WithEqFold 追加忽略大小写的等值条件，用于面向用户的搜索（如按用户名查找 "Bob" 与 "bob"）。
+// 生成 field = ? COLLATE NOCASE。
+// 注意：SQLite 内置的 NOCASE 仅折叠 ASCII 字母，非 ASCII 字符（如 Ä/ä）仍按原样比较。
+// field 必须出现在白名单中；value 去除首尾空白后为空时忽略该条件，否则按原值（不去除空白）参数绑定。
+func WithEqFold(field, value string, whitelist map[string]struct{}) QueryOption {
+	return func(db *DB) *DB {
+		f := strings.TrimSpace(field)
+		if f == "" || !identifierPattern.MatchString(f) {
+			return db
+		}
+		if _, ok := whitelist[f]; !ok {
+			return db
+		}
+		if strings.TrimSpace(value) == "" {
+			return db
+		}
+		db.DB = db.DB.Where(columnName(f)+" = ? COLLATE NOCASE", value)
+		recordOption(db, "WithEqFold", f)
 		return db
 	}
 }
 
+// WithDeletedOnly 仅查询已软删除的记录：取消默认的软删除过滤（Unscoped），并追加 `<deleted_at 列> IS NOT NULL` 条件。
+// 软删除列在查询执行时根据模型（Model 或 Find 的目标）的 gorm.DeletedAt 字段确定，支持自定义列名。
+// 若无法确定模型或模型没有软删除字段，查询将返回错误，而不是退化为返回全部记录。
+func WithDeletedOnly() QueryOption {
+	return func(db *DB) *DB {
+		db.DB = db.DB.Scopes(deletedOnlyScope)
+		recordOption(db, "WithDeletedOnly")
+		return db
+	}
+}
+
+// deletedOnlyScope 在查询执行前解析模型并定位软删除列
+func deletedOnlyScope(tx *gorm.DB) *gorm.DB {
+	model := tx.Statement.Model
+	if model == nil {
+		model = tx.Statement.Dest
+	}
+	if model == nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly requires a model"))
+		return tx
+	}
+	if err := tx.Statement.Parse(model); err != nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly: %w", err))
+		return tx
+	}
+	field := softDeleteField(tx.Statement.Schema)
+	if field == nil {
+		_ = tx.AddError(fmt.Errorf("WithDeletedOnly: model %s has no soft-delete field", tx.Statement.Schema.Name))
+		return tx
+	}
+	return tx.Unscoped().Where(clause.Expr{
+		SQL:  "? IS NOT NULL",
+		Vars: []any{clause.Column{Table: clause.CurrentTable, Name: field.DBName}},
+	})
+}
+
+// softDeleteField 返回模型中以 gorm.DeletedAt 实现的软删除字段，不存在时返回 nil
+func softDeleteField(s *schema.Schema) *schema.Field {
+	if s == nil {
+		return nil
+	}
+	for _, qc := range s.QueryClauses {
+		if sd, ok := qc.(gorm.SoftDeleteQueryClause); ok {
+			return sd.Field
+		}
+	}
+	return nil
+}
+
+// WithIndexedBy 为查询的表追加 `INDEXED BY <index>` 提示，强制 SQLite 查询规划器使用指定索引。
+// index 须为合法标识符（字母、数字、下划线，且不以数字开头），否则忽略该选项。
+// 注意：指定的索引不存在或无法用于该查询时，SQLite 在执行阶段返回错误（如 "no such index"），而不是退化为全表扫描。
+// 表名取自 WithTable 或模型（Model 或 Find 的目标）；与 WithNotIndexed 同时使用时以后设置者为准。
+func WithIndexedBy(index string) QueryOption {
+	return func(db *DB) *DB {
+		quoted, err := QuoteIdentifier(index)
+		if err != nil {
+			return db
+		}
+		db.DB = db.DB.Scopes(indexHintScope("WithIndexedBy", "INDEXED BY "+quoted))
+		recordOption(db, "WithIndexedBy", strings.TrimSpace(index))
+		return db
+	}
+}
+
+// WithNotIndexed 为查询的表追加 `NOT INDEXED` 提示，禁止 SQLite 使用任何索引（主键查找除外）。
+func WithNotIndexed() QueryOption {
+	return func(db *DB) *DB {
+		db.DB = db.DB.Scopes(indexHintScope("WithNotIndexed", "NOT INDEXED"))
+		recordOption(db, "WithNotIndexed")
+		return db
+	}
+}
+
+// indexHintScope 在查询执行前确定表名，并以带索引提示的 FROM 子句替换默认的表引用
+func indexHintScope(option, hint string) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if tx.Statement.Table == "" {
+			model := tx.Statement.Model
+			if model == nil {
+				model = tx.Statement.Dest
+			}
+			if model == nil {
+				_ = tx.AddError(fmt.Errorf("%s requires a table or model", option))
+				return tx
+			}
+			if err := tx.Statement.Parse(model); err != nil {
+				_ = tx.AddError(fmt.Errorf("%s: %w", option, err))
+				return tx
+			}
+		}
+		return tx.Clauses(clause.From{Tables: []clause.Table{{
+			Name: tx.Statement.Quote(tx.Statement.Table) + " " + hint,
+			Raw:  true,
+		}}})
+	}
+}
+
 // WithOffset 设置查询的 OFFSET（偏移量）。当 offset 为负数时忽略该选项。
 func WithOffset(offset int) QueryOption {
 	// WithOffset 返回一个合法的 QueryOption。
@@ -90,6 +309,7 @@ func WithOffset(offset int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Offset(offset)
+		recordOption(db, "WithOffset")
 		return db
 	}
 }
@@ -103,6 +323,23 @@ func WithLimit(limit int) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Limit(limit)
+		recordOption(db, "WithLimit")
+		return db
+	}
+}
+
+// WithPage 按页码设置 LIMIT/OFFSET：offset = (page-1)*pageSize，LIMIT 为 pageSize。
+// page 小于 1 时按第 1 页处理；pageSize 小于等于 0 时忽略该选项。
+func WithPage(page, pageSize int) QueryOption {
+	return func(db *DB) *DB {
+		if pageSize <= 0 {
+			return db
+		}
+		if page < 1 {
+			page = 1
+		}
+		db.DB = db.DB.Limit(pageSize).Offset((page - 1) * pageSize)
+		recordOption(db, "WithPage")
 		return db
 	}
 }
@@ -119,7 +356,8 @@ func OrderAsc(field string, whitelist map[string]struct{}) QueryOption {
 			// 非白名单字段直接忽略或记录告警
 			return db
 		}
-		db.DB = db.DB.Order(f + " ASC")
+		db.DB = db.DB.Order(columnName(f) + " ASC")
+		recordOption(db, "OrderAsc", f)
 		return db
 	}
 }
@@ -135,11 +373,35 @@ func OrderDesc(field string, whitelist map[string]struct{}) QueryOption {
 		if _, ok := whitelist[f]; !ok {
 			return db
 		}
-		db.DB = db.DB.Order(f + " DESC")
+		db.DB = db.DB.Order(columnName(f) + " DESC")
+		recordOption(db, "OrderDesc", f)
 		return db
 	}
 }
 
+// identifierPattern 合法标识符格式：字母或下划线开头，仅包含字母、数字、下划线。
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// QuoteIdentifier 校验标识符后使用 SQLite 方言的反引号包裹（如 `order`）。
+// 仅在通过格式校验后才进行包裹，校验失败返回错误，避免借引号注入。
+func QuoteIdentifier(name string) (string, error) {
+	n := strings.TrimSpace(name)
+	if n == "" || len(n) > 64 || !identifierPattern.MatchString(n) {
+		return "", fmt.Errorf("invalid identifier: %q", name)
+	}
+	return "`" + n + "`", nil
+}
+
+// columnName 返回拼接到条件中的列名：通过格式校验的列名一律加引号，无需维护保留字列表即可安全使用 order、user 等列名。
+// 未通过格式校验的列名（如 t.id）原样返回，由调用方的既有校验逻辑决定是否使用。
+func columnName(field string) string {
+	quoted, err := QuoteIdentifier(field)
+	if err != nil {
+		return field
+	}
+	return quoted
+}
+
 // WithIn 构建一个通用的 IN 条件（如 field IN ?），当 values 为空时忽略该条件。
 // 参数 field 必须是已知安全的列名（建议调用前进行白名单校验）。此处不对 values 做深度清洗，交由 GORM 预处理。
 func WithIn(field string, values any) QueryOption {
@@ -161,7 +423,8 @@ func WithIn(field string, values any) QueryOption {
 		default:
 			// 其他类型直接交给 GORM 处理，但一般建议限制到常用类型
 		}
-		db.DB = db.DB.Where(field+" IN ?", values)
+		db.DB = db.DB.Where(columnName(field)+" IN ?", values)
+		recordOption(db, "WithIn", field)
 		return db
 	}
 }
@@ -173,6 +436,7 @@ func WithIds(ids []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("id IN ?", ids)
+		recordOption(db, "WithIds")
 		return db
 	}
 }
@@ -184,6 +448,7 @@ func WithNames(names []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("name IN ?", names)
+		recordOption(db, "WithNames")
 		return db
 	}
 }
@@ -195,6 +460,7 @@ func WithUsernames(usernames []string) QueryOption {
 			return db
 		}
 		db.DB = db.DB.Where("username IN ?", usernames)
+		recordOption(db, "WithUsernames")
 		return db
 	}
 }
\ No newline at end of file
@@ -30,16 +30,29 @@ func execFind(t *testing.T, db *DB) *gorm.DB {
     return tx
 }
 
+// mustOptionDB 应用选项并在 OptionDB 返回错误时终止测试。
+func mustOptionDB(t *testing.T, db *DB, options ...QueryOption) *DB {
+    t.Helper()
+    updated, err := OptionDB(db, options...)
+    if err != nil {
+        t.Fatalf("OptionDB should not return error: %v", err)
+    }
+    return updated
+}
+
 // TestOptionDB_NilSafety 验证 OptionDB 对 nil 的安全处理，以及忽略 nil 选项的行为。
 func TestOptionDB_NilSafety(t *testing.T) {
-    // db 为 nil 时应直接返回 nil
-    if OptionDB(nil, WithId("1")) != nil {
-        t.Fatalf("OptionDB should return nil when input db is nil")
+    // db 为 nil 时应返回查询错误
+    if updated, err := OptionDB(nil, WithId("1")); updated != nil || !IsQueryError(err) {
+        t.Fatalf("OptionDB should return query error when input db is nil, got %v, %v", updated, err)
+    }
+    if _, err := OptionDB(&DB{}, WithId("1")); !IsQueryError(err) {
+        t.Fatalf("OptionDB should return query error when gorm db is nil, got %v", err)
     }
 
     // 忽略 nil 的 QueryOption
     db := newTestDB(t)
-    updated := OptionDB(db, nil, WithId("abc"))
+    updated := mustOptionDB(t, db, nil, WithId("abc"))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"WHERE id = ?"}) {
@@ -50,13 +63,42 @@ func TestOptionDB_NilSafety(t *testing.T) {
     }
 }
 
+// TestOptionDB_ErrorHandling 验证选项 panic 被捕获为查询错误且后续选项继续应用、选项返回 nil 时报错，
+// 以及 OptionDBMust 的向后兼容行为。
+func TestOptionDB_ErrorHandling(t *testing.T) {
+    panicking := func(db *DB) *DB { panic("boom") }
+    updated, err := OptionDB(newTestDB(t), WithId("1"), panicking, WithName("alice"))
+    if !IsQueryError(err) || !strings.Contains(err.Error(), "QUERY_OPTION_PANIC") {
+        t.Fatalf("expected QUERY_OPTION_PANIC query error, got %v", err)
+    }
+    if sql := execFind(t, updated).Statement.SQL.String(); !containsAll(sql, []string{"id = ?", "name = ?"}) {
+        t.Fatalf("options around the panicking one should still apply, got: %s", sql)
+    }
+
+    nilResult := func(db *DB) *DB { return nil }
+    if updated, err := OptionDB(newTestDB(t), nilResult); updated != nil || !IsQueryError(err) {
+        t.Fatalf("expected query error for nil option result, got %v, %v", updated, err)
+    }
+
+    if OptionDBMust(nil, WithId("1")) != nil {
+        t.Fatal("OptionDBMust should return nil when input db is nil")
+    }
+    db := newTestDB(t)
+    if got := OptionDBMust(db, nilResult); got != db {
+        t.Fatal("OptionDBMust should return the input db on error")
+    }
+    if sql := execFind(t, OptionDBMust(newTestDB(t), WithId("x"))).Statement.SQL.String(); !strings.Contains(sql, "id = ?") {
+        t.Fatalf("OptionDBMust should apply options, got: %s", sql)
+    }
+}
+
 // TestWithTable 验证 WithTable 设置/忽略表名的行为。
 func TestWithTable(t *testing.T) {
     db := newTestDB(t)
 
     // 空白表名应被忽略，保持既有表名不变
     db.DB = db.DB.Table("users")
-    updated := OptionDB(db, WithTable("  "))
+    updated := mustOptionDB(t, db, WithTable("  "))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"FROM `users`"}) {
@@ -65,7 +107,7 @@ func TestWithTable(t *testing.T) {
 
     // 设置有效表名
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("accounts"))
+    updated2 := mustOptionDB(t, db2, WithTable("accounts"))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if !containsAll(sql2, []string{"FROM `accounts`"}) {
@@ -73,10 +115,55 @@ func TestWithTable(t *testing.T) {
     }
 }
 
+// TestWithTableWhitelist 验证 WithTableSafe 的白名单校验逻辑：
+// 仅允许在 whitelist 中的表名生效，非白名单表名与空白表名应被忽略。
+func TestWithTableWhitelist(t *testing.T) {
+    db := newTestDB(t)
+
+    wl := map[string]struct{}{"users": {}, "accounts": {}}
+
+    // 允许的表名
+    updated := mustOptionDB(t, db, WithTableSafe("users", wl))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"FROM `users`"}) {
+        t.Fatalf("expected SQL to use table users with whitelist, got: %s", sql)
+    }
+
+    // 非白名单表名应被忽略
+    db2 := newTestDB(t)
+    db2.DB = db2.DB.Table("accounts")
+    updated2 := mustOptionDB(t, db2, WithTableSafe("not_allowed", wl))
+    tx2 := execFind(t, updated2)
+    sql2 := tx2.Statement.SQL.String()
+    if !containsAll(sql2, []string{"FROM `accounts`"}) || strings.Contains(sql2, "not_allowed") {
+        t.Fatalf("non-whitelist table should be ignored, got: %s", sql2)
+    }
+
+    // 空白表名应被忽略，保持既有表名不变
+    db3 := newTestDB(t)
+    db3.DB = db3.DB.Table("accounts")
+    updated3 := mustOptionDB(t, db3, WithTableSafe("   ", wl))
+    tx3 := execFind(t, updated3)
+    sql3 := tx3.Statement.SQL.String()
+    if !containsAll(sql3, []string{"FROM `accounts`"}) {
+        t.Fatalf("blank table name should be ignored, expect accounts to remain, got: %s", sql3)
+    }
+
+    // nil 白名单拒绝所有表名
+    db4 := newTestDB(t)
+    db4.DB = db4.DB.Table("accounts")
+    updated4 := mustOptionDB(t, db4, WithTableSafe("users", nil))
+    tx4 := execFind(t, updated4)
+    if sql4 := tx4.Statement.SQL.String(); !containsAll(sql4, []string{"FROM `accounts`"}) {
+        t.Fatalf("nil whitelist should reject all tables, got: %s", sql4)
+    }
+}
+
 // TestWhereBasic 验证 WithId/WithUserName/WithName/WithStatus 的 WHERE 条件拼接与 0 值忽略逻辑。
 func TestWhereBasic(t *testing.T) {
     db := newTestDB(t)
-    updated := OptionDB(db,
+    updated := mustOptionDB(t, db,
         WithTable("users"),
         WithId("id123"),
         WithUserName("u1"),
@@ -102,7 +189,7 @@ func TestWhereBasic(t *testing.T) {
 
     // status == 0 应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("users"), WithStatus(0))
+    updated2 := mustOptionDB(t, db2, WithTable("users"), WithStatus(0))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"status = ?"}) {
@@ -113,7 +200,7 @@ func TestWhereBasic(t *testing.T) {
 // TestLimitOffset 验证 Limit/Offset 的设置与忽略逻辑。
 func TestLimitOffset(t *testing.T) {
     db := newTestDB(t)
-    updated := OptionDB(db, WithTable("users"), WithLimit(10), WithOffset(5))
+    updated := mustOptionDB(t, db, WithTable("users"), WithLimit(10), WithOffset(5))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"LIMIT 10", "OFFSET 5"}) {
@@ -122,7 +209,7 @@ func TestLimitOffset(t *testing.T) {
 
     // 非法值应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("users"), WithLimit(0), WithOffset(-1))
+    updated2 := mustOptionDB(t, db2, WithTable("users"), WithLimit(0), WithOffset(-1))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"LIMIT", "OFFSET"}) {
@@ -130,20 +217,54 @@ func TestLimitOffset(t *testing.T) {
     }
 }
 
+// TestWithPage 验证按页码计算的 LIMIT/OFFSET，以及页码小于 1 与非法页大小的处理。
+func TestWithPage(t *testing.T) {
+    cases := []struct {
+        page, size int
+        want       []string
+        noOffset   bool
+    }{
+        {page: 1, size: 20, want: []string{"LIMIT 20"}, noOffset: true},
+        {page: 3, size: 20, want: []string{"LIMIT 20", "OFFSET 40"}},
+        {page: 2, size: 7, want: []string{"LIMIT 7", "OFFSET 7"}},
+        {page: 0, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+        {page: -5, size: 10, want: []string{"LIMIT 10"}, noOffset: true},
+    }
+    for _, c := range cases {
+        updated := mustOptionDB(t, newTestDB(t), WithTable("users"), WithPage(c.page, c.size))
+        sql := execFind(t, updated).Statement.SQL.String()
+        if !containsAll(sql, c.want) {
+            t.Fatalf("page=%d size=%d: expected %v, got: %s", c.page, c.size, c.want, sql)
+        }
+        if c.noOffset && contains(sql, "OFFSET") {
+            t.Fatalf("page=%d size=%d: first page should not set OFFSET, got: %s", c.page, c.size, sql)
+        }
+    }
+
+    // pageSize <= 0 时忽略
+    for _, size := range []int{0, -1} {
+        updated := mustOptionDB(t, newTestDB(t), WithTable("users"), WithPage(3, size))
+        sql := execFind(t, updated).Statement.SQL.String()
+        if contains(sql, "LIMIT") || contains(sql, "OFFSET") {
+            t.Fatalf("size=%d should be ignored, got: %s", size, sql)
+        }
+    }
+}
+
 // TestOrderWhitelist 验证升降序排序在白名单内才生效的逻辑。
 func TestOrderWhitelist(t *testing.T) {
     db := newTestDB(t)
     wl := map[string]struct{}{"created_at": {}, "username": {}}
-    updated := OptionDB(db, WithTable("users"), OrderAsc("created_at", wl), OrderDesc("username", wl))
+    updated := mustOptionDB(t, db, WithTable("users"), OrderAsc("created_at", wl), OrderDesc("username", wl))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"ORDER BY created_at ASC", "username DESC"}) {
+    if !containsAll(sql, []string{"ORDER BY `created_at` ASC", "`username` DESC"}) {
         t.Fatalf("expected order clauses, got: %s", sql)
     }
 
     // 非白名单字段应被忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("users"), OrderAsc("not_allowed", wl))
+    updated2 := mustOptionDB(t, db2, WithTable("users"), OrderAsc("not_allowed", wl))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{"ORDER BY not_allowed ASC"}) {
@@ -155,10 +276,10 @@ func TestOrderWhitelist(t *testing.T) {
 func TestWithIn(t *testing.T) {
     // string 切片
     db := newTestDB(t)
-    updated := OptionDB(db, WithTable("users"), WithIn("id", []string{"a", "b"}))
+    updated := mustOptionDB(t, db, WithTable("users"), WithIn("id", []string{"a", "b"}))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
-    if !containsAll(sql, []string{"id IN"}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
+    if !containsAll(sql, []string{"`id` IN"}) { // GORM 会展开为 (?,?)，这里只断言 IN 片段存在
         t.Fatalf("expected IN clause, got: %s", sql)
     }
     if len(tx.Statement.Vars) != 2 {
@@ -167,7 +288,7 @@ func TestWithIn(t *testing.T) {
 
     // 空切片应忽略
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("users"), WithIn("id", []string{}))
+    updated2 := mustOptionDB(t, db2, WithTable("users"), WithIn("id", []string{}))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if containsAll(sql2, []string{" IN "}) {
@@ -179,7 +300,7 @@ func TestWithIn(t *testing.T) {
 func TestWithIdsNamesUsernames(t *testing.T) {
     // WithIds
     db := newTestDB(t)
-    updated := OptionDB(db, WithTable("users"), WithIds([]string{"i1", "i2"}))
+    updated := mustOptionDB(t, db, WithTable("users"), WithIds([]string{"i1", "i2"}))
     tx := execFind(t, updated)
     sql := tx.Statement.SQL.String()
     if !containsAll(sql, []string{"id IN"}) || len(tx.Statement.Vars) != 2 {
@@ -188,7 +309,7 @@ func TestWithIdsNamesUsernames(t *testing.T) {
 
     // WithNames
     db2 := newTestDB(t)
-    updated2 := OptionDB(db2, WithTable("users"), WithNames([]string{"n1"}))
+    updated2 := mustOptionDB(t, db2, WithTable("users"), WithNames([]string{"n1"}))
     tx2 := execFind(t, updated2)
     sql2 := tx2.Statement.SQL.String()
     if !containsAll(sql2, []string{"name IN"}) || len(tx2.Statement.Vars) != 1 {
@@ -197,7 +318,7 @@ func TestWithIdsNamesUsernames(t *testing.T) {
 
     // WithUsernames 空切片忽略
     db3 := newTestDB(t)
-    updated3 := OptionDB(db3, WithTable("users"), WithUsernames([]string{}))
+    updated3 := mustOptionDB(t, db3, WithTable("users"), WithUsernames([]string{}))
     tx3 := execFind(t, updated3)
     sql3 := tx3.Statement.SQL.String()
     if containsAll(sql3, []string{"username IN"}) {
@@ -253,4 +374,212 @@ func indexImpl(s, sub string) int {
 }
 
 // stdIndex 直接调用标准库 strings.Index。
-func stdIndex(s, sub string) int { return strings.Index(s, sub) }
\ No newline at end of file
+func stdIndex(s, sub string) int { return strings.Index(s, sub) }
+
+// TestReservedWordQuoting 验证列名在条件与排序中一律使用反引号包裹，与 SQL 保留字同名的列（如 order）同样安全。
+func TestReservedWordQuoting(t *testing.T) {
+	db := newTestDB(t)
+	wl := map[string]struct{}{"order": {}}
+	updated := mustOptionDB(t, db, WithTable("orders"), WithIn("order", []int{1, 2}), OrderAsc("order", wl))
+	tx := execFind(t, updated)
+	sql := tx.Statement.SQL.String()
+	if !containsAll(sql, []string{"`order` IN", "ORDER BY `order` ASC"}) {
+		t.Fatalf("expected reserved-word column to be quoted, got: %s", sql)
+	}
+
+	// 校验失败的标识符不应被包裹
+	if _, err := QuoteIdentifier("order` OR 1=1 --"); err == nil {
+		t.Fatalf("expected error for unsafe identifier")
+	}
+	if q, err := QuoteIdentifier("created_at"); err != nil || q != "`created_at`" {
+		t.Fatalf("expected `created_at`, got: %q, %v", q, err)
+	}
+}
+
+// TestWithTransformedEq 验证 WithTransformedEq 绑定的是经过 transform 规范化后的值，且非白名单字段被忽略。
+func TestWithTransformedEq(t *testing.T) {
+    normalize := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+    wl := map[string]struct{}{"email": {}}
+
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db, WithTable("users"), WithTransformedEq("email", "  Alice@Example.COM ", normalize, wl))
+    tx := execFind(t, updated)
+    sql := tx.Statement.SQL.String()
+    if !containsAll(sql, []string{"WHERE `email` = ?"}) {
+        t.Fatalf("expected email condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != "alice@example.com" {
+        t.Fatalf("expected transformed var [alice@example.com], got: %#v", tx.Statement.Vars)
+    }
+
+    // 非白名单字段应被忽略
+    db2 := newTestDB(t)
+    updated2 := mustOptionDB(t, db2, WithTable("users"), WithTransformedEq("password", "x", normalize, wl))
+    tx2 := execFind(t, updated2)
+    if sql2 := tx2.Statement.SQL.String(); containsAll(sql2, []string{"password = ?"}) {
+        t.Fatalf("non-whitelist field should be ignored, got: %s", sql2)
+    }
+}
+
+// TestWithEqFold 验证忽略大小写的等值条件构造与原值绑定，非白名单字段与空值被忽略。
+func TestWithEqFold(t *testing.T) {
+    wl := map[string]struct{}{"name": {}}
+
+    tx := execFind(t, mustOptionDB(t, newTestDB(t), WithTable("users"), WithEqFold("name", " Bob ", wl)))
+    if sql := tx.Statement.SQL.String(); !contains(sql, "WHERE `name` = ? COLLATE NOCASE") {
+        t.Fatalf("expected case-insensitive condition, got: %s", sql)
+    }
+    if len(tx.Statement.Vars) != 1 || tx.Statement.Vars[0] != " Bob " {
+        t.Fatalf("expected value bound unchanged, got: %#v", tx.Statement.Vars)
+    }
+
+    for _, opt := range []QueryOption{
+        WithEqFold("email", "bob", wl),
+        WithEqFold("name", "  ", wl),
+    } {
+        if sql := execFind(t, mustOptionDB(t, newTestDB(t), WithTable("users"), opt)).Statement.SQL.String(); contains(sql, "WHERE") {
+            t.Fatalf("expected condition to be skipped, got: %s", sql)
+        }
+    }
+}
+
+// TestOptionAudit 验证开启审计后按顺序记录已应用选项名称，且不包含绑定值。
+func TestOptionAudit(t *testing.T) {
+    wl := map[string]struct{}{"created_at": {}}
+    db := newTestDB(t)
+    updated := mustOptionDB(t, db,
+        WithOptionAudit(),
+        WithTable("users"),
+        WithId("secret-id"),
+        WithName(" "),
+        OrderAsc("created_at", wl),
+        WithLimit(10),
+    )
+    got := AppliedOptions(updated)
+    want := []string{"WithTable:users", "WithId", "OrderAsc:created_at", "WithLimit"}
+    if len(got) != len(want) {
+        t.Fatalf("expected %v, got %v", want, got)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("expected %v, got %v", want, got)
+        }
+    }
+
+    // 未开启审计时不记录
+    plain := mustOptionDB(t, newTestDB(t), WithId("x"))
+    if names := AppliedOptions(plain); names != nil {
+        t.Fatalf("expected nil without audit, got %v", names)
+    }
+}
+
+// softDeleteUser 为带软删除字段的测试模型
+type softDeleteUser struct {
+    ID        uint
+    Name      string
+    DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// TestWithDeletedOnly 在真实的内存 SQLite 中写入存活与已删除的记录，验证仅返回已软删除的记录。
+func TestWithDeletedOnly(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    sqlDB.SetMaxOpenConns(1)
+
+    if err := gdb.AutoMigrate(&softDeleteUser{}); err != nil {
+        t.Fatalf("auto migrate failed: %v", err)
+    }
+    users := []softDeleteUser{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}
+    if err := gdb.Create(&users).Error; err != nil {
+        t.Fatalf("seed failed: %v", err)
+    }
+    if err := gdb.Delete(&users[1]).Error; err != nil {
+        t.Fatalf("soft delete failed: %v", err)
+    }
+
+    // 默认作用域仍排除已删除记录
+    var live []softDeleteUser
+    if err := gdb.Find(&live).Error; err != nil || len(live) != 2 {
+        t.Fatalf("expected 2 live rows, got %d err=%v", len(live), err)
+    }
+
+    var deleted []softDeleteUser
+    if err := mustOptionDB(t, &DB{DB: gdb}, WithDeletedOnly()).Find(&deleted).Error; err != nil {
+        t.Fatalf("query deleted rows failed: %v", err)
+    }
+    if len(deleted) != 1 || deleted[0].Name != "bob" {
+        t.Fatalf("expected only bob, got %+v", deleted)
+    }
+
+    // 与其他条件组合，并通过 Model 指定模型
+    var count int64
+    if err := mustOptionDB(t, &DB{DB: gdb.Model(&softDeleteUser{})}, WithDeletedOnly(), WithName("alice")).Count(&count).Error; err != nil || count != 0 {
+        t.Fatalf("expected no deleted alice, got %d err=%v", count, err)
+    }
+
+    // 模型没有软删除字段时应返回错误，而不是返回全部记录
+    var plain []struct{ ID uint }
+    if err := mustOptionDB(t, &DB{DB: gdb.Table("soft_delete_users")}, WithDeletedOnly()).Find(&plain).Error; err == nil || !strings.Contains(err.Error(), "no soft-delete field") {
+        t.Fatalf("expected soft-delete field error, got %v", err)
+    }
+}
+
+// TestWithIndexedBy 验证 INDEXED BY / NOT INDEXED 提示紧跟在表名之后，非法索引名被忽略。
+func TestWithIndexedBy(t *testing.T) {
+    sql := execFind(t, mustOptionDB(t, newTestDB(t), WithTable("users"), WithIndexedBy("idx_users_email"), WithName("alice"))).Statement.SQL.String()
+    if !contains(sql, "FROM `users` INDEXED BY `idx_users_email` WHERE") {
+        t.Fatalf("expected INDEXED BY after table, got: %s", sql)
+    }
+
+    sql = execFind(t, mustOptionDB(t, newTestDB(t), WithTable("users"), WithNotIndexed())).Statement.SQL.String()
+    if !contains(sql, "FROM `users` NOT INDEXED") {
+        t.Fatalf("expected NOT INDEXED after table, got: %s", sql)
+    }
+
+    // 未设置 WithTable 时从模型解析表名
+    tx := mustOptionDB(t, newTestDB(t), WithIndexedBy("idx_name")).Session(&gorm.Session{DryRun: true}).Find(&[]softDeleteUser{})
+    if tx.Error != nil {
+        t.Fatalf("dryrun find failed: %v", tx.Error)
+    }
+    if sql := tx.Statement.SQL.String(); !contains(sql, "FROM `soft_delete_users` INDEXED BY `idx_name`") {
+        t.Fatalf("expected table from model, got: %s", sql)
+    }
+
+    sql = execFind(t, mustOptionDB(t, newTestDB(t), WithTable("users"), WithIndexedBy("idx; DROP TABLE users"))).Statement.SQL.String()
+    if contains(sql, "INDEXED") {
+        t.Fatalf("expected invalid index name to be ignored, got: %s", sql)
+    }
+}
+
+// TestWithIndexedByMissingIndex 验证在真实 SQLite 中指定不存在的索引时于执行阶段报错。
+func TestWithIndexedByMissingIndex(t *testing.T) {
+    gdb, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+    if err != nil {
+        t.Fatalf("failed to open sqlite: %v", err)
+    }
+    sqlDB, err := gdb.DB()
+    if err != nil {
+        t.Fatalf("failed to get sql.DB: %v", err)
+    }
+    defer sqlDB.Close()
+    sqlDB.SetMaxOpenConns(1)
+
+    if err := gdb.AutoMigrate(&softDeleteUser{}); err != nil {
+        t.Fatalf("auto migrate failed: %v", err)
+    }
+
+    var users []softDeleteUser
+    if err := mustOptionDB(t, &DB{DB: gdb}, WithIndexedBy("idx_soft_delete_users_deleted_at")).Find(&users).Error; err != nil {
+        t.Fatalf("expected existing index to work, got: %v", err)
+    }
+    if err := mustOptionDB(t, &DB{DB: gdb}, WithIndexedBy("idx_missing")).Find(&users).Error; err == nil {
+        t.Fatal("expected error for missing index")
+    }
+}
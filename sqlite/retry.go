@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// maxRetryBackoff 为单次重试等待时间的上限，避免指数退避增长过快。
+const maxRetryBackoff = 30 * time.Second
+
+// NewDBWithRetry 创建数据库实例并在连接失败时按带抖动的指数退避重试。
+// 适用于容器编排场景中数据库启动晚于服务的情况，等价于使用 context.Background() 调用 NewDBWithRetryContext。
+func NewDBWithRetry(config *Config, maxAttempts int, backoff time.Duration) (*DB, error) {
+	return NewDBWithRetryContext(context.Background(), config, maxAttempts, backoff)
+}
+
+// NewDBWithRetryContext 与 NewDBWithRetry 相同，但允许通过 ctx 取消等待或限制总耗时。
+// 说明：
+// 1) 每次尝试都会执行 NewDB 并 Ping 数据库，Ping 失败时关闭本次打开的连接池；
+// 2) 仅对连接类错误（数据库文件暂不可打开、数据库繁忙或被锁定、连接中断）重试，其他错误立即返回；
+// 3) 第 n 次重试前等待 backoff*2^(n-1) 的 [1/2, 1] 倍随机时长，单次等待不超过 30 秒；
+// 4) maxAttempts 小于 1 时按 1 处理。
+func NewDBWithRetryContext(ctx context.Context, config *Config, maxAttempts int, backoff time.Duration) (*DB, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	return connectWithRetry(ctx, maxAttempts, backoff, func(ctx context.Context) (*DB, error) {
+		return openAndPing(ctx, config)
+	})
+}
+
+// openAndPing 打开数据库并 Ping 确认连通性。
+func openAndPing(ctx context.Context, config *Config) (*DB, error) {
+	db, err := NewDB(config)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// connectWithRetry 执行 open 并在连接错误时按退避策略重试，open 由调用方注入以便测试模拟连接失败。
+func connectWithRetry(ctx context.Context, maxAttempts int, backoff time.Duration, open func(context.Context) (*DB, error)) (*DB, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		db, err := open(ctx)
+		if err == nil {
+			return db, nil
+		}
+		lastErr = err
+		if !isConnectionError(err) {
+			return nil, err
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(retryDelay(backoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("database connection retry canceled after %d attempts: %w", attempt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// isConnectionError 判断错误是否为可通过重试恢复的连接错误。
+func isConnectionError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrCantOpen, sqlite3.ErrBusy, sqlite3.ErrLocked, sqlite3.ErrIoErr:
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// retryDelay 计算第 attempt 次失败后的等待时长（带抖动的指数退避）。
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	delay := backoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
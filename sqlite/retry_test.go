@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// TestConnectWithRetry 使用前两次失败、第三次成功的模拟拨号函数验证重试流程。
+func TestConnectWithRetry(t *testing.T) {
+	calls := 0
+	want := &DB{}
+	dialer := func(ctx context.Context) (*DB, error) {
+		calls++
+		if calls <= 2 {
+			return nil, fmt.Errorf("dial: %w", driver.ErrBadConn)
+		}
+		return want, nil
+	}
+
+	got, err := connectWithRetry(context.Background(), 5, time.Millisecond, dialer)
+	if err != nil {
+		t.Fatalf("expected success after retries, got: %v", err)
+	}
+	if got != want || calls != 3 {
+		t.Fatalf("expected 3 attempts returning the dialed db, got calls=%d", calls)
+	}
+
+	// 尝试次数用尽时返回包装后的最后一次错误
+	calls = 0
+	if _, err := connectWithRetry(context.Background(), 2, time.Millisecond, dialer); !errors.Is(err, driver.ErrBadConn) || calls != 2 {
+		t.Fatalf("expected wrapped connection error after 2 attempts, got calls=%d err=%v", calls, err)
+	}
+}
+
+// TestConnectWithRetryConfigError 验证非连接错误不会触发重试。
+func TestConnectWithRetryConfigError(t *testing.T) {
+	calls := 0
+	cfgErr := errors.New("cannot parse dsn")
+	_, err := connectWithRetry(context.Background(), 5, time.Millisecond, func(ctx context.Context) (*DB, error) {
+		calls++
+		return nil, cfgErr
+	})
+	if !errors.Is(err, cfgErr) || calls != 1 {
+		t.Fatalf("expected config error without retry, got calls=%d err=%v", calls, err)
+	}
+
+	if _, err := NewDBWithRetry(nil, 3, time.Millisecond); err == nil {
+		t.Fatal("expected error for nil config")
+	}
+}
+
+// TestConnectWithRetryCanceled 验证在退避等待期间取消 ctx 会立即返回。
+func TestConnectWithRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := connectWithRetry(ctx, 5, time.Hour, func(ctx context.Context) (*DB, error) {
+		calls++
+		cancel()
+		return nil, driver.ErrBadConn
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Fatalf("expected canceled error after 1 attempt, got calls=%d err=%v", calls, err)
+	}
+}
+
+// TestIsConnectionError 验证 SQLite 错误码的可重试分类。
+func TestIsConnectionError(t *testing.T) {
+	if !isConnectionError(fmt.Errorf("open: %w", sqlite3.Error{Code: sqlite3.ErrCantOpen})) {
+		t.Error("SQLITE_CANTOPEN should be retried")
+	}
+	if !isConnectionError(sqlite3.Error{Code: sqlite3.ErrBusy}) {
+		t.Error("SQLITE_BUSY should be retried")
+	}
+	if isConnectionError(sqlite3.Error{Code: sqlite3.ErrCorrupt}) {
+		t.Error("SQLITE_CORRUPT should not be retried")
+	}
+}
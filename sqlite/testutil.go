@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// testingTB 为 NewTempDB 所需的 testing.TB 子集，*testing.T 与 *testing.B 均满足该接口
+type testingTB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// NewTempDB 在新建的临时目录中创建基于文件的 SQLite 数据库，供需要验证 WAL、忙等待或并发行为
+// （:memory: 无法覆盖）的测试使用；创建失败时通过 t.Fatalf 终止测试。
+// 返回的 cleanup 关闭连接并删除临时目录（含 -wal、-shm 文件），可重复调用，通常配合 defer 或 t.Cleanup 使用。
+func NewTempDB(t testingTB) (*DB, func()) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "conan-sqlite-")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	// WAL 日志模式、5 秒忙等待与外键约束由 buildDSN 追加到文件 DSN
+	cfg := DefaultConfig()
+	cfg.DatabasePath = filepath.Join(dir, "test.db")
+	cfg.BusyTimeout = 5
+	cfg.JournalMode = "WAL"
+	cfg.DisableForeignKeys = false
+	db, err := NewDB(cfg)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatalf("failed to open temp sqlite: %v", err)
+	}
+
+	var once sync.Once
+	cleanup := func() {
+		once.Do(func() {
+			_ = db.Close()
+			_ = os.RemoveAll(dir)
+		})
+	}
+	return db, cleanup
+}
@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type tempDBRecord struct {
+	ID   uint
+	Name string
+}
+
+// TestNewTempDB 验证临时文件数据库可写入并读回数据、启用 WAL，且 cleanup 后文件被删除。
+func TestNewTempDB(t *testing.T) {
+	db, cleanup := NewTempDB(t)
+	defer cleanup()
+
+	if err := db.AutoMigrate(&tempDBRecord{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	if err := db.Create(&tempDBRecord{Name: "alice"}).Error; err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var got tempDBRecord
+	if err := db.First(&got).Error; err != nil || got.Name != "alice" {
+		t.Fatalf("expected to read back alice, got %+v err=%v", got, err)
+	}
+
+	var mode string
+	if err := db.Raw("PRAGMA journal_mode").Scan(&mode).Error; err != nil || !strings.EqualFold(mode, "wal") {
+		t.Fatalf("expected WAL journal mode, got %q err=%v", mode, err)
+	}
+
+	var files []struct {
+		Seq  int
+		Name string
+		File string
+	}
+	if err := db.Raw("PRAGMA database_list").Scan(&files).Error; err != nil || len(files) == 0 || files[0].File == "" {
+		t.Fatalf("expected file-backed database, got %+v err=%v", files, err)
+	}
+	path := files[0].File
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("database file should exist before cleanup: %v", err)
+	}
+
+	cleanup()
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("database file should be removed after cleanup, stat err=%v", err)
+	}
+}
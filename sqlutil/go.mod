@@ -0,0 +1,3 @@
+module github.com/amuluze/conan/sqlutil
+
+go 1.24.5
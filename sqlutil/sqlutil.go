@@ -0,0 +1,158 @@
+// Package sqlutil 提供针对生成 SQL 的断言辅助函数，便于查询构造器的使用方编写稳定的测试。
+package sqlutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// keywords 为比较时忽略大小写的 SQL 关键字（统一转为大写）；其余标识符与字面量保持大小写敏感。
+var keywords = map[string]struct{}{
+	"ALL": {}, "ALTER": {}, "AND": {}, "ANY": {}, "AS": {}, "ASC": {}, "BETWEEN": {}, "BY": {},
+	"CASE": {}, "CROSS": {}, "DELETE": {}, "DESC": {}, "DISTINCT": {}, "DROP": {}, "ELSE": {},
+	"END": {}, "EXISTS": {}, "FALSE": {}, "FINAL": {}, "FOR": {}, "FROM": {}, "FULL": {},
+	"GROUP": {}, "HAVING": {}, "ILIKE": {}, "IN": {}, "INNER": {}, "INSERT": {}, "INTO": {},
+	"IS": {}, "JOIN": {}, "LEFT": {}, "LIKE": {}, "LIMIT": {}, "LOCKED": {}, "NOT": {},
+	"NOWAIT": {}, "NULL": {}, "OFFSET": {}, "ON": {}, "OR": {}, "ORDER": {}, "OUTER": {},
+	"PARTITION": {}, "PREWHERE": {}, "RETURNING": {}, "RIGHT": {}, "SELECT": {}, "SET": {},
+	"SETTINGS": {}, "SHARE": {}, "SKIP": {}, "TABLE": {}, "THEN": {}, "TRUE": {}, "TRUNCATE": {},
+	"UNION": {}, "UPDATE": {}, "USING": {}, "VALUES": {}, "WHEN": {}, "WHERE": {}, "WITH": {},
+}
+
+// ContainsClause 判断 sql 中是否包含片段 fragment，比较前对两者做相同的规范化：
+//   - 连续空白折叠为单个空格，并去除括号内侧、左括号之前及逗号两侧的空白（"IN ( ?, ? )" 与 "IN(?,?)" 等价）；
+//   - SQL 关键字不区分大小写（"order by" 与 "ORDER BY" 等价），标识符与引号内的字面量保持原样；
+//   - 片段首尾为标识符字符时须落在词边界上，避免 "id = ?" 误匹配 "user_id = ?"。
+//
+// 空片段（规范化后）始终返回 false。
+func ContainsClause(sql, fragment string) bool {
+	haystack := Normalize(sql)
+	needle := Normalize(fragment)
+	if needle == "" {
+		return false
+	}
+
+	first, _ := firstRune(needle)
+	last, _ := lastRune(needle)
+	for offset := 0; offset <= len(haystack)-len(needle); {
+		i := strings.Index(haystack[offset:], needle)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(needle)
+		if (!isWordRune(first) || !wordBefore(haystack, start)) &&
+			(!isWordRune(last) || !wordAfter(haystack, end)) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+// Normalize 返回 ContainsClause 使用的规范化 SQL：折叠空白、按上述规则去除括号与逗号周围的空白，
+// 并将引号外的 SQL 关键字转为大写。可用于在断言失败时输出便于比对的内容。
+func Normalize(sql string) string {
+	var b strings.Builder
+	b.Grow(len(sql))
+	pendingSpace := false
+
+	runes := []rune(sql)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = true
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			j := closingQuote(runes, i)
+			writeSpace(&b, &pendingSpace, r)
+			b.WriteString(string(runes[i:j]))
+			i = j
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			if _, ok := keywords[strings.ToUpper(word)]; ok {
+				word = strings.ToUpper(word)
+			}
+			writeSpace(&b, &pendingSpace, r)
+			b.WriteString(word)
+			i = j
+		default:
+			if isTightPunct(r) {
+				pendingSpace = false
+			} else {
+				writeSpace(&b, &pendingSpace, r)
+			}
+			b.WriteRune(r)
+			i++
+			if r == '(' || r == ',' {
+				// 跳过紧随其后的空白
+				for i < len(runes) && unicode.IsSpace(runes[i]) {
+					i++
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeSpace 在写入下一个记号前补上折叠后的单个空格（开头及括号、逗号之前不补）。
+func writeSpace(b *strings.Builder, pending *bool, next rune) {
+	if *pending && b.Len() > 0 && !isTightPunct(next) {
+		b.WriteByte(' ')
+	}
+	*pending = false
+}
+
+// closingQuote 返回从 start 处引号开始的字面量结束位置（不含），支持以连续两个引号转义；未闭合时返回末尾。
+func closingQuote(runes []rune, start int) int {
+	q := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != q {
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == q {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(runes)
+}
+
+func isTightPunct(r rune) bool {
+	return r == '(' || r == ')' || r == ','
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func wordBefore(s string, i int) bool {
+	r, ok := lastRune(s[:i])
+	return ok && isWordRune(r)
+}
+
+func wordAfter(s string, i int) bool {
+	r, ok := firstRune(s[i:])
+	return ok && isWordRune(r)
+}
+
+func firstRune(s string) (rune, bool) {
+	for _, r := range s {
+		return r, true
+	}
+	return 0, false
+}
+
+func lastRune(s string) (rune, bool) {
+	r := []rune(s)
+	if len(r) == 0 {
+		return 0, false
+	}
+	return r[len(r)-1], true
+}
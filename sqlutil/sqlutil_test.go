@@ -0,0 +1,68 @@
+package sqlutil
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "select  *\n\tfrom users", want: "SELECT * FROM users"},
+		{in: "  WHERE id IN ( ?, ? )  ", want: "WHERE id IN(?,?)"},
+		{in: "order by `Order` desc", want: "ORDER BY `Order` DESC"},
+		{in: "name = 'select  From'", want: "name = 'select  From'"},
+		{in: "note = 'it''s  ok' and x", want: "note = 'it''s  ok' AND x"},
+		{in: "count( * ) AS Total", want: "count(*) AS Total"},
+	}
+	for _, c := range cases {
+		if got := Normalize(c.in); got != c.want {
+			t.Errorf("Normalize(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestContainsClause(t *testing.T) {
+	sql := "SELECT * FROM `users` WHERE status = ? AND user_id IN (?,?)\n  ORDER BY created_at DESC LIMIT 10"
+
+	matches := []string{
+		"where status = ?",
+		"WHERE   status = ?",
+		"user_id in ( ?, ? )",
+		"order by created_at desc",
+		"LIMIT 10",
+		"FROM `users`",
+	}
+	for _, f := range matches {
+		if !ContainsClause(sql, f) {
+			t.Errorf("expected %q to match %q", f, sql)
+		}
+	}
+
+	misses := []string{
+		"",
+		"   ",
+		"id IN (?,?)",         // 仅为 user_id 的后缀，不在词边界上
+		"LIMIT 1",             // 仅为 LIMIT 10 的前缀
+		"FROM `USERS`",        // 引号内标识符大小写敏感
+		"ORDER BY CREATED_AT", // 列名大小写敏感
+		"WHERE status = ? OR",
+	}
+	for _, f := range misses {
+		if ContainsClause(sql, f) {
+			t.Errorf("expected %q not to match %q", f, sql)
+		}
+	}
+}
+
+func TestContainsClauseQuotedLiterals(t *testing.T) {
+	sql := `SELECT * FROM t WHERE name = 'Order  By' AND "Group" = 1`
+	if !ContainsClause(sql, `name = 'Order  By'`) {
+		t.Error("expected quoted literal to be preserved verbatim")
+	}
+	if ContainsClause(sql, `name = 'ORDER BY'`) {
+		t.Error("quoted literal must stay case- and whitespace-sensitive")
+	}
+	if !ContainsClause(sql, `and "Group" = 1`) {
+		t.Error("expected keyword outside quotes to be case-insensitive")
+	}
+}